@@ -0,0 +1,19 @@
+package main
+
+import (
+	"github.com/zhuboris/never-expires/internal/id/mailing/mailsender"
+	"github.com/zhuboris/never-expires/internal/shared/health"
+	"github.com/zhuboris/never-expires/internal/shared/prometheusexporter"
+	"github.com/zhuboris/never-expires/internal/shared/rabbitmq"
+	"github.com/zhuboris/never-expires/internal/shared/runapi"
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
+)
+
+type appConfig struct {
+	SMTP     mailsender.Config
+	RabbitMQ rabbitmq.Config
+	Health   health.Config
+	Metrics  prometheusexporter.Config
+	Tracing  tracing.Config
+	Shutdown runapi.ShutdownConfig
+}
@@ -11,22 +11,20 @@ import (
 
 	"github.com/zhuboris/never-expires/internal/id/mailing/mailqueue"
 	"github.com/zhuboris/never-expires/internal/id/mailing/mailsender"
-	"github.com/zhuboris/never-expires/internal/id/mailing/rabbitmq"
+	"github.com/zhuboris/never-expires/internal/shared/config"
+	"github.com/zhuboris/never-expires/internal/shared/health"
+	"github.com/zhuboris/never-expires/internal/shared/prometheusexporter"
+	"github.com/zhuboris/never-expires/internal/shared/rabbitmq"
+	"github.com/zhuboris/never-expires/internal/shared/runapi"
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
 	"github.com/zhuboris/never-expires/internal/shared/zaplog"
 )
 
-const (
-	smptUsername = "SMPT_USERNAME"
-	smptPassword = "SMPT_PASSWORD"
-	smptHost     = "SMPT_HOST"
-	smptPort     = "SMPT_PORT"
-	smptFrom     = "SMPT_FROM"
-)
-
 const (
 	serviceNameLogKey    = "service"
 	smptClientName       = "smptClient"
 	rabbitMQConsumerName = "rabbitMQConsumer"
+	rabbitMQCheckName    = "rabbitMQ"
 )
 const allowedInitDurationForInit = 1 * time.Minute
 
@@ -38,18 +36,76 @@ func main() {
 		log.Fatal(err)
 	}
 
+	var cfg appConfig
+	if err := config.Load(&cfg); err != nil {
+		logger.Fatal("Configuration is invalid", zap.Error(err))
+	}
+
+	logger.Info("Configuration is loaded", zap.String("config", config.Redact(cfg)))
+
 	defer func() {
 		logger.Fatal("API is shutdown")
 		logger.Sync()
 	}()
 
-	ctx, cancel := context.WithCancel(context.Background())
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing, "emailsender")
+	if err != nil {
+		logger.Fatal("Tracing is not initialized", zap.Error(err))
+	}
+
+	defer shutdownTracing(context.Background())
+
+	prometheusExporter := prometheusexporter.New(cfg.Metrics)
+	metrics, err := newWorkerMetrics(prometheusExporter)
+	if err != nil {
+		logger.Fatal("Metrics are not registered", zap.Error(err))
+	}
+
+	healthChecker := health.NewChecker()
+	healthChecker.Add(rabbitMQCheckName, rabbitmq.NewPinger(cfg.RabbitMQ).Ping)
+
+	ctx, cancel := runapi.OnSignal(context.Background(), cfg.Shutdown, healthChecker.SetShuttingDown)
 	defer cancel()
 
-	runWorkersPool(ctx, numberOfWorkers, logger)
+	go runHealthServer(ctx, cfg, healthChecker, logger)
+	go runMetricsExporter(ctx, prometheusExporter, logger)
+	runWorkersPool(ctx, cfg, numberOfWorkers, metrics, logger)
+}
+
+// workerMetrics are registered once and shared by all workers.
+type workerMetrics struct {
+	rabbitMQ     *rabbitmq.Metrics
+	smtpDuration *prometheusexporter.DurationHistogram
+}
+
+func newWorkerMetrics(exporter *prometheusexporter.PrometheusExporter) (workerMetrics, error) {
+	rabbitMQMetrics, err := rabbitmq.NewMetrics(exporter)
+	if err != nil {
+		return workerMetrics{}, err
+	}
+
+	smtpDuration, err := exporter.NewDurationHistogram("smtp_send_duration_seconds", "Duration of sending emails to SMTP server", "result")
+	if err != nil {
+		return workerMetrics{}, err
+	}
+
+	return workerMetrics{
+		rabbitMQ:     rabbitMQMetrics,
+		smtpDuration: smtpDuration,
+	}, nil
+}
+
+func runMetricsExporter(ctx context.Context, exporter *prometheusexporter.PrometheusExporter, logger *zap.Logger) {
+	err := exporter.RunWithCtx(ctx)
+	logger.Error("Metrics exporter is shutdown", zap.Error(err))
 }
 
-func runWorkersPool(ctx context.Context, workersCount int, logger *zap.Logger) {
+func runHealthServer(ctx context.Context, cfg appConfig, healthChecker *health.Checker, logger *zap.Logger) {
+	err := health.NewServer(cfg.Health, healthChecker).RunWithCtx(ctx)
+	logger.Error("Health server is shutdown", zap.Error(err))
+}
+
+func runWorkersPool(ctx context.Context, cfg appConfig, workersCount int, metrics workerMetrics, logger *zap.Logger) {
 	var wg sync.WaitGroup
 	wg.Add(workersCount)
 
@@ -59,7 +115,7 @@ func runWorkersPool(ctx context.Context, workersCount int, logger *zap.Logger) {
 		go func() {
 			defer wg.Done()
 
-			err := runWorker(ctx, workerLogger)
+			err := runWorker(ctx, cfg, metrics, workerLogger)
 			if err != nil {
 				workerLogger.Error("worker stopped with error", zap.Error(err))
 			}
@@ -69,34 +125,21 @@ func runWorkersPool(ctx context.Context, workersCount int, logger *zap.Logger) {
 	wg.Wait()
 }
 
-func runWorker(cancelCtx context.Context, logger *zap.Logger) error {
+func runWorker(cancelCtx context.Context, cfg appConfig, metrics workerMetrics, logger *zap.Logger) error {
 	logger = logger.With(zap.String("api", "mailSender"))
-	smtpConfig, err := mailsender.NewConfig(mailsender.ConfigEnvs{
-		UsernameKey: smptUsername,
-		PasswordKey: smptPassword,
-		HostKey:     smptHost,
-		PortKey:     smptPort,
-		FromKey:     smptFrom,
-	})
-	if err != nil {
-		return err
-	}
-
 	smptInitCtx, cancel := context.WithTimeout(context.Background(), allowedInitDurationForInit)
 	defer cancel()
 
-	smtpClient, err := mailsender.NewSMTPClient(smptInitCtx, smtpConfig, logger.With(zap.String(serviceNameLogKey, smptClientName)))
+	smtpClient, err := mailsender.NewSMTPClient(smptInitCtx, cfg.SMTP, logger.With(zap.String(serviceNameLogKey, smptClientName)))
 	if err != nil {
 		return fmt.Errorf("mail client creation failed, %w", err)
 	}
 
 	defer smtpClient.Quit()
+	smtpClient.SetSendDurationHistogram(metrics.smtpDuration)
 
-	rabbitMQConsumer, err := rabbitmq.NewConsumer(mailqueue.QueueName, logger.With(zap.String(serviceNameLogKey, rabbitMQConsumerName)))
-	if err != nil {
-		return fmt.Errorf("rabbitMQ consumer client creation failed, %w", err)
-	}
-
+	rabbitMQConsumer := rabbitmq.NewConsumer(cfg.RabbitMQ, mailqueue.QueueName, logger.With(zap.String(serviceNameLogKey, rabbitMQConsumerName)))
+	rabbitMQConsumer.SetMetrics(metrics.rabbitMQ)
 	worker := mailsender.NewWorker(smtpClient, rabbitMQConsumer)
 	return worker.DoWork(cancelCtx)
 }
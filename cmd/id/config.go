@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/zhuboris/never-expires/internal/id/passkey"
+	"github.com/zhuboris/never-expires/internal/id/pw"
+	"github.com/zhuboris/never-expires/internal/id/tkn"
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/id/usr/avatarfs"
+	"github.com/zhuboris/never-expires/internal/id/usr/oauth/googleoauthios"
+	"github.com/zhuboris/never-expires/internal/id/usr/oauth/oidc"
+	"github.com/zhuboris/never-expires/internal/shared/appleconfig"
+	"github.com/zhuboris/never-expires/internal/shared/config"
+	"github.com/zhuboris/never-expires/internal/shared/prometheusexporter"
+	"github.com/zhuboris/never-expires/internal/shared/rabbitmq"
+	"github.com/zhuboris/never-expires/internal/shared/ratelimit"
+	"github.com/zhuboris/never-expires/internal/shared/runapi"
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
+)
+
+type appConfig struct {
+	AuthServerAddress string `env:"AUTH_SERVER_ADDRESS" required:"true"`
+	// AdminServerAddress is set only where admin API must be started, it must not be exposed to the internet.
+	AdminServerAddress string `env:"ADMIN_SERVER_ADDRESS"`
+	PublicURL          string `env:"ID_PUBLIC_URL"`
+
+	DB             usr.DBConfig
+	JWT            tkn.Config
+	Passkey        passkey.Config
+	PasswordPolicy pw.PolicyConfig
+	PasswordHash   pw.HashConfig
+	Deletion       usr.DeletionConfig
+	Avatars        avatarfs.Config
+	GoogleIOS      googleoauthios.Config
+	Apple          appleconfig.Config
+	Metrics        prometheusexporter.Config
+	RabbitMQ       rabbitmq.Config
+	RateLimit      ratelimit.Config
+	OIDCProviders  []oidc.Config
+	Tracing        tracing.Config
+	Shutdown       runapi.ShutdownConfig
+}
+
+func loadConfig() (appConfig, error) {
+	source, err := config.NewSource()
+	if err != nil {
+		return appConfig{}, err
+	}
+
+	var cfg appConfig
+	err = source.Load(&cfg)
+	if cfg.AdminServerAddress != "" && cfg.PublicURL == "" {
+		err = errors.Join(err, errors.New("ID_PUBLIC_URL is required to run admin API"))
+	}
+
+	providers, providersErr := oidc.ConfigsFrom(source)
+	cfg.OIDCProviders = providers
+	return cfg, errors.Join(err, providersErr)
+}
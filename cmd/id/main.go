@@ -7,33 +7,50 @@ import (
 	"log"
 	"os"
 	"time"
+	_ "time/tzdata"
 
 	"go.uber.org/zap"
 
+	"github.com/zhuboris/never-expires/internal/id/admin"
+	"github.com/zhuboris/never-expires/internal/id/adminapi"
 	"github.com/zhuboris/never-expires/internal/id/api"
 	"github.com/zhuboris/never-expires/internal/id/api/request"
 	"github.com/zhuboris/never-expires/internal/id/authservice"
+	"github.com/zhuboris/never-expires/internal/id/loginguard"
 	"github.com/zhuboris/never-expires/internal/id/mailing/mailbuilder"
 	"github.com/zhuboris/never-expires/internal/id/mailing/mailqueue"
-	"github.com/zhuboris/never-expires/internal/id/mailing/rabbitmq"
+	"github.com/zhuboris/never-expires/internal/id/migrations"
+	"github.com/zhuboris/never-expires/internal/id/passkey"
+	"github.com/zhuboris/never-expires/internal/id/pw"
 	"github.com/zhuboris/never-expires/internal/id/session"
+	"github.com/zhuboris/never-expires/internal/id/tkn"
 	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/id/usr/avatarfs"
 	"github.com/zhuboris/never-expires/internal/id/usr/oauth/applesignin"
 	"github.com/zhuboris/never-expires/internal/id/usr/oauth/googleoauthios"
+	"github.com/zhuboris/never-expires/internal/id/usr/oauth/oidc"
+	"github.com/zhuboris/never-expires/internal/shared/config"
+	"github.com/zhuboris/never-expires/internal/shared/eventbus"
+	"github.com/zhuboris/never-expires/internal/shared/health"
+	"github.com/zhuboris/never-expires/internal/shared/migration"
 	"github.com/zhuboris/never-expires/internal/shared/postgresql"
 	"github.com/zhuboris/never-expires/internal/shared/prometheusexporter"
+	"github.com/zhuboris/never-expires/internal/shared/rabbitmq"
+	"github.com/zhuboris/never-expires/internal/shared/ratelimit"
 	"github.com/zhuboris/never-expires/internal/shared/runapi"
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
 	"github.com/zhuboris/never-expires/internal/shared/zaplog"
 )
 
-const authServerListenAddrKey = "AUTH_SERVER_ADDRESS"
-
 const (
 	apiLogKey              = "api"
 	rabbitMQLogKey         = "rabbitMQProducer"
 	apiName                = "authAPI"
+	adminAPIName           = "adminAPI"
 	prometheusExporterName = "prometheusExporter"
 	rabbitMQName           = "rabbitMQ"
+	eventsProducerName     = "eventsProducer"
+	eventsSource           = "id"
 )
 
 const (
@@ -41,29 +58,63 @@ const (
 	sessionsRepoName = "sessionsRepo"
 )
 
+const (
+	postgresCheckName = "postgres"
+	rabbitMQCheckName = "rabbitMQ"
+)
+
 const allowedInitDurationForInit = 1 * time.Minute
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == migration.Command {
+		if err := runMigrationCommand(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
 	if logger, err := run(); err != nil {
 		handleError(logger, err)
 	}
 }
 
+func runMigrationCommand(args []string) error {
+	var dbConfig usr.DBConfig
+	if err := config.Load(&dbConfig); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), allowedInitDurationForInit)
+	defer cancel()
+
+	return postgresql.RunMigrationCommand(ctx, dbConfig.Postgres(), migrations.FS, args)
+}
+
 func run() (*zap.Logger, error) {
 	logger, err := zaplog.NewLogger()
 	if err != nil {
 		return logger, err
 	}
 
-	config, err := usr.DBConfig()
+	cfg, err := loadConfig()
 	if err != nil {
 		return logger, err
 	}
 
+	logger.Info("Configuration is loaded", zap.String("config", config.Redact(cfg)))
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing, "id")
+	if err != nil {
+		return logger, err
+	}
+
+	defer shutdownTracing(context.Background())
+
 	ctx, cancel := context.WithTimeout(context.Background(), allowedInitDurationForInit)
 	defer cancel()
 
-	authDBPool, err := postgresql.MakePool(ctx, config)
+	authDBPool, err := postgresql.MakeMigratedPool(ctx, cfg.DB.Postgres(), migrations.FS)
 	if err != nil {
 		return logger, err
 	}
@@ -78,17 +129,67 @@ func run() (*zap.Logger, error) {
 		return logger, err
 	}
 
-	oAuthGoogleIOSService, err := googleoauthios.NewService()
+	passkeyRepo, err := passkey.NewPostgresqlRepository(authDBPool)
+	if err != nil {
+		return logger, err
+	}
+
+	loginGuardRepo, err := loginguard.NewPostgresqlRepository(authDBPool)
+	if err != nil {
+		return logger, err
+	}
+
+	adminRepo, err := admin.NewPostgresqlRepository(authDBPool)
+	if err != nil {
+		return logger, err
+	}
+
+	passkeyService, err := passkey.NewService(passkeyRepo, cfg.Passkey)
+	if err != nil {
+		return logger, fmt.Errorf("passkey service creation failed, %w", err)
+	}
+
+	passwordPolicy, err := pw.NewPolicy(cfg.PasswordPolicy)
+	if err != nil {
+		return logger, fmt.Errorf("password policy configuration failed, %w", err)
+	}
+
+	pw.InitPolicy(passwordPolicy)
+
+	passwordHashParams, err := pw.NewHashParams(cfg.PasswordHash)
+	if err != nil {
+		return logger, fmt.Errorf("password hashing configuration failed, %w", err)
+	}
+
+	pw.InitHashing(passwordHashParams)
+	tkn.Init(cfg.JWT)
+
+	avatarStorage, err := avatarfs.New(cfg.Avatars)
 	if err != nil {
-		return logger, fmt.Errorf("google oAuth service for iOS creation failed, %w", err)
+		return logger, fmt.Errorf("avatar storage creation failed, %w", err)
 	}
 
-	appleSignInService, err := applesignin.NewService()
+	oAuthGoogleIOSService := googleoauthios.NewService(cfg.GoogleIOS)
+	appleSignInService, err := applesignin.NewService(cfg.Apple)
 	if err != nil {
 		return logger, fmt.Errorf("apple signIn service creation failed, %w", err)
 	}
 
-	prometheusExporter := prometheusexporter.New()
+	oidcProviders, err := oidc.NewRegistry(cfg.OIDCProviders)
+	if err != nil {
+		return logger, fmt.Errorf("openID connect providers creation failed, %w", err)
+	}
+
+	prometheusExporter := prometheusexporter.New(cfg.Metrics)
+	if err := prometheusExporter.RegisterPoolStats(cfg.DB.Postgres().DBName, authDBPool); err != nil {
+		return logger, err
+	}
+
+	rabbitMQMetrics, err := rabbitmq.NewMetrics(prometheusExporter)
+	if err != nil {
+		return logger, err
+	}
+
 	userStatusMetric, err := prometheusExporter.NewServiceStatus(userRepoName)
 	if err != nil {
 		return logger, fmt.Errorf("user repo status metric is was not registered, %w", err)
@@ -100,9 +201,10 @@ func run() (*zap.Logger, error) {
 	}
 
 	var (
-		userService    = usr.NewService(userRepo, oAuthGoogleIOSService, appleSignInService, userStatusMetric)
+		userService    = usr.NewService(userRepo, avatarStorage, oAuthGoogleIOSService, appleSignInService, oidcProviders, userStatusMetric, cfg.Deletion.GracePeriod())
 		sessionService = session.NewService(sessionsRepo, sessionsStatusMetric)
-		authService    = authservice.New(userService, sessionService)
+		loginGuard     = loginguard.NewService(loginGuardRepo)
+		authService    = authservice.New(userService, sessionService, passkeyService, loginGuard)
 	)
 
 	mailBuilder, err := mailbuilder.New()
@@ -110,28 +212,40 @@ func run() (*zap.Logger, error) {
 		return nil, fmt.Errorf("mail builder creation failed, %w", err)
 	}
 
-	rabbitMQProducer, err := rabbitmq.NewProducer(mailqueue.QueueName, logger.With(zap.String(apiLogKey, rabbitMQLogKey)))
-	if err != nil {
-		return nil, fmt.Errorf("rabbitMQ producer creation failed, %w", err)
-	}
+	var (
+		rabbitMQProducer = rabbitmq.NewProducer(cfg.RabbitMQ, mailqueue.QueueName, logger.With(zap.String(apiLogKey, rabbitMQLogKey)))
+		eventsProducer   = rabbitmq.NewTopicProducer(cfg.RabbitMQ, eventbus.ExchangeName, logger.With(zap.String(apiLogKey, eventsProducerName)))
+		emailQueue       = mailqueue.NewEmailQueue(rabbitMQProducer)
+	)
 
-	emailQueue := mailqueue.NewEmailQueue(rabbitMQProducer)
+	rabbitMQProducer.SetMetrics(rabbitMQMetrics)
+	eventsProducer.SetMetrics(rabbitMQMetrics)
 
+	adminLogger := logger.With(zap.String(apiLogKey, adminAPIName))
 	logger = logger.With(zap.String(apiLogKey, apiName))
 	request.InitEmailSender(mailBuilder, emailQueue, logger)
+	request.InitEventSender(eventbus.NewPublisher(eventsProducer, eventsSource), logger)
 
-	var (
-		authAddr   = os.Getenv(authServerListenAddrKey)
-		authServer = api.NewServer(authAddr, authService, logger, prometheusExporter)
-	)
+	healthChecker := health.NewChecker()
+	healthChecker.Add(postgresCheckName, authService.Status)
+	healthChecker.Add(rabbitMQCheckName, rabbitmq.NewPinger(cfg.RabbitMQ).Ping)
 
-	ctx, cancel = context.WithCancel(context.Background())
+	authServer := api.NewServer(cfg.AuthServerAddress, authService, healthChecker, ratelimit.NewStore(cfg.RateLimit), logger, prometheusExporter)
+
+	ctx, cancel = runapi.OnSignal(context.Background(), cfg.Shutdown, healthChecker.SetShuttingDown)
 	defer cancel()
 
 	toRun := map[string]runapi.Runner{
 		apiName:                authServer,
 		prometheusExporterName: prometheusExporter,
 		rabbitMQName:           rabbitMQProducer,
+		eventsProducerName:     eventsProducer,
+	}
+
+	// Admin API is started only where it is configured, it must not be exposed to the internet.
+	if cfg.AdminServerAddress != "" {
+		adminService := admin.NewService(adminRepo, authService, mailBuilder, emailQueue, cfg.PublicURL)
+		toRun[adminAPIName] = adminapi.NewServer(cfg.AdminServerAddress, adminService, adminLogger)
 	}
 
 	logger.Info(fmt.Sprintf("Starting APIs: %s", runapi.RunnersList(toRun)))
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"github.com/zhuboris/never-expires/internal/reminder"
+	"github.com/zhuboris/never-expires/internal/reminder/apn"
+	"github.com/zhuboris/never-expires/internal/shared/appleconfig"
+	"github.com/zhuboris/never-expires/internal/shared/health"
+	"github.com/zhuboris/never-expires/internal/shared/prometheusexporter"
+	"github.com/zhuboris/never-expires/internal/shared/runapi"
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
+)
+
+type appConfig struct {
+	DB       reminder.DBConfig
+	Redis    apn.RedisConfig
+	Apple    appleconfig.Config
+	Metrics  prometheusexporter.Config
+	Health   health.Config
+	Tracing  tracing.Config
+	Shutdown runapi.ShutdownConfig
+}
@@ -9,11 +9,13 @@ import (
 
 	"go.uber.org/zap"
 
-	"github.com/zhuboris/never-expires/internal/reminder"
 	"github.com/zhuboris/never-expires/internal/reminder/apn"
+	"github.com/zhuboris/never-expires/internal/shared/config"
+	"github.com/zhuboris/never-expires/internal/shared/health"
 	"github.com/zhuboris/never-expires/internal/shared/postgresql"
 	"github.com/zhuboris/never-expires/internal/shared/prometheusexporter"
 	"github.com/zhuboris/never-expires/internal/shared/runapi"
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
 	"github.com/zhuboris/never-expires/internal/shared/zaplog"
 )
 
@@ -22,9 +24,15 @@ const allowedInitDurationForInit = 1 * time.Minute
 const (
 	apiName                = "apnSender"
 	prometheusExporterName = "prometheusExporter"
+	healthServerName       = "healthServer"
 	serviceLogKey          = "service"
 )
 
+const (
+	postgresCheckName = "postgres"
+	redisCheckName    = "redis"
+)
+
 func main() {
 	if logger, err := run(); err != nil {
 		handleError(logger, err)
@@ -37,44 +45,64 @@ func run() (*zap.Logger, error) {
 		return logger, err
 	}
 
-	config, err := reminder.DBConfig()
+	var cfg appConfig
+	if err := config.Load(&cfg); err != nil {
+		return logger, err
+	}
+
+	logger.Info("Configuration is loaded", zap.String("config", config.Redact(cfg)))
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing, "notification")
 	if err != nil {
 		return logger, err
 	}
 
+	defer shutdownTracing(context.Background())
+
 	ctx, cancel := context.WithTimeout(context.Background(), allowedInitDurationForInit)
 	defer cancel()
 
-	dbPool, err := postgresql.MakePool(ctx, config)
+	dbPool, err := postgresql.MakePool(ctx, cfg.DB.Postgres())
 	if err != nil {
 		return logger, err
 	}
 
 	apnsRepo := apn.NewPostgresqlRepository(dbPool)
-	prometheusExporter := prometheusexporter.New()
-	redisDB, err := apn.NewRedisDB()
-	if err != nil {
-		return nil, fmt.Errorf("redisDB init error: %w", err)
+	var (
+		prometheusExporter = prometheusexporter.New(cfg.Metrics)
+		redisDB            = apn.NewRedisDB(cfg.Redis)
+	)
+
+	if err := prometheusExporter.RegisterPoolStats(cfg.DB.Postgres().DBName, dbPool); err != nil {
+		return logger, err
 	}
 
-	apnsSender, err := apn.NewSenderService(apnsRepo, redisDB, logger.With(zap.String(serviceLogKey, "APNs_sender")), prometheusExporter)
+	apnsSender, err := apn.NewSenderService(cfg.Apple, apnsRepo, redisDB, logger.With(zap.String(serviceLogKey, "APNs_sender")), prometheusExporter)
 	if err != nil {
 		return logger, err
 	}
 
-	ctx, cancel = context.WithCancel(context.Background())
+	healthChecker := health.NewChecker()
+	healthChecker.Add(postgresCheckName, dbPool.Ping)
+	healthChecker.Add(redisCheckName, redisDB.Ping)
+
+	signalCtx, stop := runapi.OnSignal(context.Background(), cfg.Shutdown, healthChecker.SetShuttingDown)
+	defer stop()
+
+	ctx, cancel = context.WithCancel(signalCtx)
 	defer cancel()
 
 	toRun := map[string]runapi.Runner{
 		apiName:                apnsSender,
 		prometheusExporterName: prometheusExporter,
+		healthServerName:       health.NewServer(cfg.Health, healthChecker),
 	}
 
 	logger.Info(fmt.Sprintf("Starting APIs: %s", runapi.RunnersList(toRun)))
 	sendingError := runapi.AllAsync(ctx, cancel, toRun)
 
 	cleanupService := apn.NewInactiveTokensDeletingService(apnsRepo, redisDB, logger.With(zap.String(serviceLogKey, "APNs_bad_tokens_deleter")))
-	ctx, cancel = context.WithCancel(context.Background())
+	ctx, cancel = context.WithCancel(signalCtx)
 	defer cancel()
 
 	cleanupError := cleanupService.RunWithCtx(ctx)
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"github.com/zhuboris/never-expires/internal/id/tkn"
+	"github.com/zhuboris/never-expires/internal/reminder"
+	"github.com/zhuboris/never-expires/internal/shared/idempotency"
+	"github.com/zhuboris/never-expires/internal/shared/prometheusexporter"
+	"github.com/zhuboris/never-expires/internal/shared/rabbitmq"
+	"github.com/zhuboris/never-expires/internal/shared/ratelimit"
+	"github.com/zhuboris/never-expires/internal/shared/runapi"
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
+)
+
+type appConfig struct {
+	ServerAddress string `env:"SERVER_ADDRESS" required:"true"`
+
+	DB          reminder.DBConfig
+	JWT         tkn.Config
+	Metrics     prometheusexporter.Config
+	RabbitMQ    rabbitmq.Config
+	RateLimit   ratelimit.Config
+	Idempotency idempotency.Config
+	Tracing     tracing.Config
+	Shutdown    runapi.ShutdownConfig
+}
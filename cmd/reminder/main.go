@@ -10,32 +10,68 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/zhuboris/never-expires/internal/id/tkn"
 	"github.com/zhuboris/never-expires/internal/reminder"
 	"github.com/zhuboris/never-expires/internal/reminder/api"
 	"github.com/zhuboris/never-expires/internal/reminder/apn"
 	"github.com/zhuboris/never-expires/internal/reminder/item"
+	"github.com/zhuboris/never-expires/internal/reminder/itemexpiry"
+	"github.com/zhuboris/never-expires/internal/reminder/migrations"
+	"github.com/zhuboris/never-expires/internal/reminder/reminderusrdeleter"
 	"github.com/zhuboris/never-expires/internal/reminder/storage"
+	"github.com/zhuboris/never-expires/internal/shared/config"
+	"github.com/zhuboris/never-expires/internal/shared/eventbus"
+	"github.com/zhuboris/never-expires/internal/shared/health"
+	"github.com/zhuboris/never-expires/internal/shared/idempotency"
+	"github.com/zhuboris/never-expires/internal/shared/migration"
 	"github.com/zhuboris/never-expires/internal/shared/postgresql"
 	"github.com/zhuboris/never-expires/internal/shared/prometheusexporter"
+	"github.com/zhuboris/never-expires/internal/shared/rabbitmq"
+	"github.com/zhuboris/never-expires/internal/shared/ratelimit"
 	"github.com/zhuboris/never-expires/internal/shared/runapi"
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
 	"github.com/zhuboris/never-expires/internal/shared/zaplog"
 )
 
-const serverListenAddrKey = "SERVER_ADDRESS"
-
 const (
 	apiName                = "reminderAPI"
 	prometheusExporterName = "prometheusExporter"
+	eventsProducerName     = "eventsProducer"
+	eventsListenerName     = "eventsListener"
+	expiryAnnouncerName    = "expiryAnnouncer"
+	eventsSource           = "reminder"
+	serviceLogKey          = "service"
+	rabbitMQCheckName      = "rabbitMQ"
 )
 
 const allowedInitDurationForInit = 1 * time.Minute
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == migration.Command {
+		if err := runMigrationCommand(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
 	if logger, err := run(); err != nil {
 		handleError(logger, err)
 	}
 }
 
+func runMigrationCommand(args []string) error {
+	var dbConfig reminder.DBConfig
+	if err := config.Load(&dbConfig); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), allowedInitDurationForInit)
+	defer cancel()
+
+	return postgresql.RunMigrationCommand(ctx, dbConfig.Postgres(), migrations.FS, args)
+}
+
 func run() (*zap.Logger, error) {
 	const (
 		reminderRepoName = "reminderRepo"
@@ -49,15 +85,26 @@ func run() (*zap.Logger, error) {
 		return logger, err
 	}
 
-	config, err := reminder.DBConfig()
+	var cfg appConfig
+	if err := config.Load(&cfg); err != nil {
+		return logger, err
+	}
+
+	logger.Info("Configuration is loaded", zap.String("config", config.Redact(cfg)))
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing, "reminder")
 	if err != nil {
 		return logger, err
 	}
 
+	defer shutdownTracing(context.Background())
+
+	tkn.Init(cfg.JWT)
+
 	ctx, cancel := context.WithTimeout(context.Background(), allowedInitDurationForInit)
 	defer cancel()
 
-	reminderDBPool, err := postgresql.MakePool(ctx, config)
+	reminderDBPool, err := postgresql.MakeMigratedPool(ctx, cfg.DB.Postgres(), migrations.FS)
 	if err != nil {
 		return logger, err
 	}
@@ -68,9 +115,18 @@ func run() (*zap.Logger, error) {
 		apnsRepo     = apn.NewPostgresqlRepository(reminderDBPool)
 	)
 
-	logger = logger.With(zap.String("service", "reminder"))
+	logger = logger.With(zap.String(serviceLogKey, "reminder"))
+
+	prometheusExporter := prometheusexporter.New(cfg.Metrics)
+	if err := prometheusExporter.RegisterPoolStats(cfg.DB.Postgres().DBName, reminderDBPool); err != nil {
+		return logger, err
+	}
+
+	rabbitMQMetrics, err := rabbitmq.NewMetrics(prometheusExporter)
+	if err != nil {
+		return logger, err
+	}
 
-	prometheusExporter := prometheusexporter.New()
 	itemsStatusMetric, err := prometheusExporter.NewServiceStatus(itemsRepoName)
 	if err != nil {
 		return logger, fmt.Errorf("items repo status metric is was not registered, %w", err)
@@ -87,20 +143,56 @@ func run() (*zap.Logger, error) {
 	}
 
 	var (
-		serverAddr      = os.Getenv(serverListenAddrKey)
 		itemsService    = item.NewService(itemsRepo, itemsStatusMetric)
 		storagesService = storage.NewService(storagesRepo, storagesStatusMetric)
 		apnsService     = apn.NewDeviceService(apnsRepo, apnsStatusMetric)
 	)
 
-	server := api.NewServer(serverAddr, storagesService, itemsService, apnsService, logger, prometheusExporter)
+	healthChecker := health.NewChecker()
+	healthChecker.Add(itemsRepoName, itemsService.Status)
+	healthChecker.Add(storagesRepoName, storagesService.Status)
+	healthChecker.Add(apnsRepoName, apnsService.Status)
+	healthChecker.Add(rabbitMQCheckName, rabbitmq.NewPinger(cfg.RabbitMQ).Ping)
+
+	server := api.NewServer(cfg.ServerAddress, storagesService, itemsService, apnsService, healthChecker, ratelimit.NewStore(cfg.RateLimit), idempotency.NewStore(cfg.Idempotency), logger, prometheusExporter)
+
+	eventsProducer := rabbitmq.NewTopicProducer(cfg.RabbitMQ, eventbus.ExchangeName, logger.With(zap.String(serviceLogKey, eventsProducerName)))
+	eventsProducer.SetMetrics(rabbitMQMetrics)
+	expiryRepo, err := itemexpiry.NewPostgresqlRepository(reminderDBPool)
+	if err != nil {
+		return logger, err
+	}
+
+	processedEventsRepo, err := eventbus.NewPostgresqlRepository(reminderDBPool)
+	if err != nil {
+		return logger, err
+	}
+
+	userDeleterRepo, err := reminderusrdeleter.NewPostgresqlRepository(reminderDBPool)
+	if err != nil {
+		return logger, err
+	}
+
+	var (
+		eventsPublisher  = eventbus.NewPublisher(eventsProducer, eventsSource)
+		expiryAnnouncer  = itemexpiry.New(expiryRepo, eventsPublisher, logger.With(zap.String(serviceLogKey, expiryAnnouncerName)))
+		userDeleter      = reminderusrdeleter.New(userDeleterRepo, logger)
+		eventsSubscriber = eventbus.NewSubscriber(eventsSource, processedEventsRepo, logger.With(zap.String(serviceLogKey, eventsListenerName)))
+	)
+
+	eventsSubscriber.On(eventbus.UserDeletedType, eventbus.UserDeletedVersion, userDeleter.HandleUserDeleted)
+	eventsConsumer := rabbitmq.NewTopicConsumer(cfg.RabbitMQ, eventbus.ExchangeName, eventsSubscriber.QueueName(), eventsSubscriber.EventTypes(), logger.With(zap.String(serviceLogKey, eventsListenerName)))
+	eventsConsumer.SetMetrics(rabbitMQMetrics)
 
-	ctx, cancel = context.WithCancel(context.Background())
+	ctx, cancel = runapi.OnSignal(context.Background(), cfg.Shutdown, healthChecker.SetShuttingDown)
 	defer cancel()
 
 	toRun := map[string]runapi.Runner{
 		apiName:                server,
 		prometheusExporterName: prometheusExporter,
+		eventsProducerName:     eventsProducer,
+		eventsListenerName:     eventbus.NewListener(eventsSubscriber, eventsConsumer),
+		expiryAnnouncerName:    expiryAnnouncer,
 	}
 
 	logger.Info(fmt.Sprintf("Starting APIs: %s", runapi.RunnersList(toRun)))
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/id/usr/avatarfs"
+	"github.com/zhuboris/never-expires/internal/shared/appleconfig"
+	"github.com/zhuboris/never-expires/internal/shared/prometheusexporter"
+	"github.com/zhuboris/never-expires/internal/shared/rabbitmq"
+	"github.com/zhuboris/never-expires/internal/shared/runapi"
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
+)
+
+type appConfig struct {
+	UserDB   usr.DBConfig
+	Apple    appleconfig.Config
+	Avatars  avatarfs.Config
+	Metrics  prometheusexporter.Config
+	RabbitMQ rabbitmq.Config
+	Tracing  tracing.Config
+	Shutdown runapi.ShutdownConfig
+}
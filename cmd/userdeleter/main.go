@@ -3,27 +3,39 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"time"
 
 	"go.uber.org/zap"
 
-	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/id/usr/avatarfs"
+	"github.com/zhuboris/never-expires/internal/id/usr/deletionevents"
 	"github.com/zhuboris/never-expires/internal/id/usr/deletionnotifier"
-	"github.com/zhuboris/never-expires/internal/reminder"
-	"github.com/zhuboris/never-expires/internal/reminder/reminderusrdeleter"
+	"github.com/zhuboris/never-expires/internal/id/usr/idusrdeleter"
+	"github.com/zhuboris/never-expires/internal/id/usr/oauth/applesignin"
+	"github.com/zhuboris/never-expires/internal/shared/config"
+	"github.com/zhuboris/never-expires/internal/shared/eventbus"
 	"github.com/zhuboris/never-expires/internal/shared/postgresql"
+	"github.com/zhuboris/never-expires/internal/shared/prometheusexporter"
+	"github.com/zhuboris/never-expires/internal/shared/rabbitmq"
+	"github.com/zhuboris/never-expires/internal/shared/runapi"
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
 	"github.com/zhuboris/never-expires/internal/shared/zaplog"
 )
 
 const allowedInitDurationForInit = 1 * time.Minute
 
 const (
-	userRepoName      = "userRepo"
-	reminderRepoName  = "reminderRepo"
-	serviceNameLogKey = "service"
-	notifierName      = "notifier"
-	reminderName      = "reminder"
+	userRepoName           = "userRepo"
+	serviceNameLogKey      = "service"
+	notifierName           = "notifier"
+	idName                 = "id"
+	eventsName             = "events"
+	eventsProducerName     = "eventsProducer"
+	eventsSource           = "id"
+	reminderSubscriberName = "reminder"
+	prometheusExporterName = "prometheusExporter"
 )
 
 func main() {
@@ -38,23 +50,24 @@ func run() (*zap.Logger, error) {
 		return logger, err
 	}
 
-	userRepoConfig, err := usr.DBConfig()
-	if err != nil {
+	var cfg appConfig
+	if err := config.Load(&cfg); err != nil {
 		return logger, err
 	}
 
-	reminderRepoConfig, err := reminder.DBConfig()
+	logger.Info("Configuration is loaded", zap.String("config", config.Redact(cfg)))
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing, "userdeleter")
 	if err != nil {
 		return logger, err
 	}
 
-	var (
-		userPostgresqlConfig     = postgresql.NewNamedConfig(userRepoName, userRepoConfig)
-		reminderPostgresqlConfig = postgresql.NewNamedConfig(reminderRepoName, reminderRepoConfig)
-	)
+	defer shutdownTracing(context.Background())
+
+	userPostgresqlConfig := postgresql.NewNamedConfig(userRepoName, cfg.UserDB.Postgres())
 
 	ctx, cancel := context.WithTimeout(context.Background(), allowedInitDurationForInit)
-	pools, err := postgresql.MakePoolsAsync(ctx, cancel, userPostgresqlConfig, reminderPostgresqlConfig)
+	pools, err := postgresql.MakePoolsAsync(ctx, cancel, userPostgresqlConfig)
 	if err != nil {
 		return logger, err
 	}
@@ -64,21 +77,60 @@ func run() (*zap.Logger, error) {
 		return logger, err
 	}
 
-	reminderRepo, err := reminderusrdeleter.NewPostgresqlRepository(pools[reminderPostgresqlConfig])
+	idRepo, err := idusrdeleter.NewPostgresqlRepository(pools[userPostgresqlConfig])
+	if err != nil {
+		return logger, err
+	}
+
+	appleSignInService, err := applesignin.NewService(cfg.Apple)
+	if err != nil {
+		return logger, err
+	}
+
+	avatarStorage, err := avatarfs.New(cfg.Avatars)
+	if err != nil {
+		return logger, fmt.Errorf("avatar storage creation failed, %w", err)
+	}
+
+	var (
+		eventsProducer     = rabbitmq.NewTopicProducer(cfg.RabbitMQ, eventbus.ExchangeName, logger.With(zap.String(serviceNameLogKey, eventsProducerName)))
+		notifierLogger     = logger.With(zap.String(serviceNameLogKey, notifierName))
+		prometheusExporter = prometheusexporter.New(cfg.Metrics)
+	)
+
+	deletionMetrics, err := deletionnotifier.NewPrometheusMetrics(prometheusExporter, notifierLogger)
 	if err != nil {
 		return logger, err
 	}
 
+	rabbitMQMetrics, err := rabbitmq.NewMetrics(prometheusExporter)
+	if err != nil {
+		return logger, err
+	}
+
+	eventsProducer.SetMetrics(rabbitMQMetrics)
+	// Reminder data is deleted only by user.deleted events, its queue is declared here so no event is lost before the reminder service binds it.
+	eventsProducer.DeclareQueue(eventbus.QueueName(reminderSubscriberName), eventbus.UserDeletedType)
+
 	var (
-		deleteNotifier      = deletionnotifier.New(userRepo, logger.With(zap.String(serviceNameLogKey, notifierName)))
-		reminderUserDeleter = reminderusrdeleter.New(reminderRepo, logger.With(zap.String(serviceNameLogKey, reminderName)))
+		deleteNotifier    = deletionnotifier.New(userRepo, deletionMetrics, notifierLogger)
+		idUserDeleter     = idusrdeleter.New(idRepo, appleSignInService, avatarStorage, logger.With(zap.String(serviceNameLogKey, idName)))
+		deletionPublisher = deletionevents.New(eventbus.NewPublisher(eventsProducer, eventsSource))
 	)
 
-	deleteNotifier.RegisterSubscriber(reminderUserDeleter)
-	ctx, cancel = context.WithCancel(context.Background())
+	deleteNotifier.RegisterSubscriber(idName, idUserDeleter)
+	deleteNotifier.RegisterSubscriber(eventsName, deletionPublisher)
+	ctx, cancel = runapi.OnSignal(context.Background(), cfg.Shutdown)
 	defer cancel()
 
-	err = deleteNotifier.DeleteAll(ctx)
+	toRun := map[string]runapi.Runner{
+		notifierName:           deleteNotifier,
+		prometheusExporterName: prometheusExporter,
+		eventsProducerName:     eventsProducer,
+	}
+
+	logger.Info(fmt.Sprintf("Starting: %s", runapi.RunnersList(toRun)))
+	err = runapi.AllAsync(ctx, cancel, toRun)
 	return logger, err
 }
 
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/reminder"
+	"github.com/zhuboris/never-expires/internal/shared/rabbitmq"
+	"github.com/zhuboris/never-expires/internal/shared/runapi"
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
+)
+
+type appConfig struct {
+	DownloadURL string `env:"DATA_EXPORT_DOWNLOAD_URL" required:"true"`
+
+	UserDB     usr.DBConfig
+	ReminderDB reminder.DBConfig
+	RabbitMQ   rabbitmq.Config
+	Tracing    tracing.Config
+	Shutdown   runapi.ShutdownConfig
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zhuboris/never-expires/internal/id/mailing/mailbuilder"
+	"github.com/zhuboris/never-expires/internal/id/mailing/mailqueue"
+	"github.com/zhuboris/never-expires/internal/id/usr/exportnotifier"
+	"github.com/zhuboris/never-expires/internal/id/usr/idusrexporter"
+	"github.com/zhuboris/never-expires/internal/reminder/reminderusrexporter"
+	"github.com/zhuboris/never-expires/internal/shared/config"
+	"github.com/zhuboris/never-expires/internal/shared/postgresql"
+	"github.com/zhuboris/never-expires/internal/shared/rabbitmq"
+	"github.com/zhuboris/never-expires/internal/shared/runapi"
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
+	"github.com/zhuboris/never-expires/internal/shared/zaplog"
+)
+
+const allowedInitDurationForInit = 1 * time.Minute
+
+const (
+	userRepoName      = "userRepo"
+	reminderRepoName  = "reminderRepo"
+	serviceNameLogKey = "service"
+	notifierName      = "notifier"
+	rabbitMQName      = "rabbitMQ"
+)
+
+func main() {
+	if logger, err := run(); err != nil {
+		handleError(logger, err)
+	}
+}
+
+func run() (*zap.Logger, error) {
+	logger, err := zaplog.NewLogger()
+	if err != nil {
+		return logger, err
+	}
+
+	var cfg appConfig
+	if err := config.Load(&cfg); err != nil {
+		return logger, err
+	}
+
+	logger.Info("Configuration is loaded", zap.String("config", config.Redact(cfg)))
+
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing, "userexporter")
+	if err != nil {
+		return logger, err
+	}
+
+	defer shutdownTracing(context.Background())
+
+	var (
+		userPostgresqlConfig     = postgresql.NewNamedConfig(userRepoName, cfg.UserDB.Postgres())
+		reminderPostgresqlConfig = postgresql.NewNamedConfig(reminderRepoName, cfg.ReminderDB.Postgres())
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), allowedInitDurationForInit)
+	pools, err := postgresql.MakePoolsAsync(ctx, cancel, userPostgresqlConfig, reminderPostgresqlConfig)
+	if err != nil {
+		return logger, err
+	}
+
+	notifierRepo, err := exportnotifier.NewPostgresqlRepository(pools[userPostgresqlConfig])
+	if err != nil {
+		return logger, err
+	}
+
+	userRepo, err := idusrexporter.NewPostgresqlRepository(pools[userPostgresqlConfig])
+	if err != nil {
+		return logger, err
+	}
+
+	reminderRepo, err := reminderusrexporter.NewPostgresqlRepository(pools[reminderPostgresqlConfig])
+	if err != nil {
+		return logger, err
+	}
+
+	mailBuilder, err := mailbuilder.New()
+	if err != nil {
+		return logger, fmt.Errorf("mail builder creation failed, %w", err)
+	}
+
+	var (
+		rabbitMQProducer = rabbitmq.NewProducer(cfg.RabbitMQ, mailqueue.QueueName, logger.With(zap.String(serviceNameLogKey, rabbitMQName)))
+		emailQueue       = mailqueue.NewEmailQueue(rabbitMQProducer)
+		exportNotifier   = exportnotifier.New(notifierRepo, emailQueue, mailBuilder, cfg.DownloadURL, logger.With(zap.String(serviceNameLogKey, notifierName)))
+	)
+
+	exportNotifier.RegisterSubscriber(idusrexporter.New(userRepo))
+	exportNotifier.RegisterSubscriber(reminderusrexporter.New(reminderRepo))
+
+	ctx, cancel = runapi.OnSignal(context.Background(), cfg.Shutdown)
+	defer cancel()
+
+	toRun := map[string]runapi.Runner{
+		notifierName: exportNotifier,
+		rabbitMQName: rabbitMQProducer,
+	}
+
+	logger.Info(fmt.Sprintf("Starting: %s", runapi.RunnersList(toRun)))
+	err = runapi.AllAsync(ctx, cancel, toRun)
+	return logger, err
+}
+
+func handleError(logger *zap.Logger, err error) {
+	if errors.Is(err, zaplog.ErrFailedToMakeLogger) || logger == nil {
+		log.Fatal(err)
+	}
+
+	defer logger.Sync()
+	logger.Fatal("Exporter is shutdown", zap.Error(err))
+}
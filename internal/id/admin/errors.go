@@ -0,0 +1,8 @@
+package admin
+
+import "errors"
+
+var (
+	ErrForbidden           = errors.New("role does not allow this action")
+	ErrMissingUserSelector = errors.New("user id or email is required")
+)
@@ -0,0 +1,69 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Role string
+
+const (
+	RoleSupport Role = "support"
+	RoleAdmin   Role = "admin"
+)
+
+// allows reports if the role is enough for the required one, admin is allowed to do everything support can.
+func (r Role) allows(required Role) bool {
+	return r == RoleAdmin || r == required
+}
+
+type Action string
+
+const (
+	ActionLookupUser         Action = "user.lookup"
+	ActionViewIdentities     Action = "user.identities.view"
+	ActionViewSessions       Action = "user.sessions.view"
+	ActionForceLogout        Action = "user.logout"
+	ActionResendConfirmation Action = "user.confirmation_email.resend"
+	ActionConfirmEmail       Action = "user.email.confirm"
+	ActionDisable            Action = "user.disable"
+	ActionEnable             Action = "user.enable"
+	ActionDelete             Action = "user.delete"
+)
+
+type User struct {
+	ID               string     `json:"id"`
+	Username         string     `json:"username"`
+	Email            string     `json:"email"`
+	IsEmailConfirmed bool       `json:"is_email_confirmed"`
+	Language         string     `json:"language,omitempty"`
+	IsDisabled       bool       `json:"is_disabled"`
+	DeleteAfter      *time.Time `json:"delete_after,omitempty"`
+}
+
+type Identity struct {
+	Provider    string    `json:"provider"`
+	ConnectedAt time.Time `json:"connected_at"`
+}
+
+type Session struct {
+	ID        string    `json:"id"`
+	Device    string    `json:"device"`
+	StartTime time.Time `json:"start_time"`
+	IsActive  bool      `json:"is_active"`
+}
+
+type AuditRecord struct {
+	ActorID  pgtype.UUID
+	Action   Action
+	TargetID pgtype.UUID
+	Details  string
+	Error    string
+}
+
+// UserSelector finds user by id or by any of their emails, id is used if both are set.
+type UserSelector struct {
+	ID    pgtype.UUID
+	Email string
+}
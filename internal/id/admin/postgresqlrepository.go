@@ -0,0 +1,217 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zhuboris/never-expires/internal/shared/postgresql"
+)
+
+type PostgresqlRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresqlRepository(pool *pgxpool.Pool) (*PostgresqlRepository, error) {
+	if pool == nil {
+		return nil, postgresql.ErrPoolInitRequired
+	}
+
+	return &PostgresqlRepository{
+		pool: pool,
+	}, nil
+}
+
+func (r PostgresqlRepository) role(ctx context.Context, userID pgtype.UUID) (Role, error) {
+	const sql = `
+		SELECT role FROM admin_roles
+		WHERE user_id = $1;
+	`
+
+	var role Role
+	err := r.pool.QueryRow(ctx, sql, userID).
+		Scan(&role)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", errors.Join(postgresql.ErrNoMatches, err)
+	}
+
+	return role, postgresql.HandleQueryErr(err)
+}
+
+func (r PostgresqlRepository) user(ctx context.Context, selector UserSelector) (User, error) {
+	const sql = `
+		SELECT
+		    u.id,
+		    u.username,
+		    e.email,
+		    e.is_confirmed,
+		    COALESCE(p.language, ''),
+		    d.user_id IS NOT NULL,
+		    td.delete_after
+		FROM users u
+		JOIN emails e ON e.owner_id = u.id AND e.is_active = TRUE
+		LEFT JOIN user_profiles p ON p.user_id = u.id
+		LEFT JOIN disabled_users d ON d.user_id = u.id
+		LEFT JOIN users_to_delete td ON td.id = u.id
+		WHERE ($1::UUID IS NOT NULL AND u.id = $1)
+		OR ($1::UUID IS NULL AND u.id = (
+		    SELECT owner_id FROM emails
+		    WHERE email = lower($2)
+		));
+	`
+
+	var user User
+	err := r.pool.QueryRow(ctx, sql, selector.ID, selector.Email).
+		Scan(
+			&user.ID,
+			&user.Username,
+			&user.Email,
+			&user.IsEmailConfirmed,
+			&user.Language,
+			&user.IsDisabled,
+			&user.DeleteAfter,
+		)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user, errors.Join(postgresql.ErrNoMatches, err)
+	}
+
+	return user, postgresql.HandleQueryErr(err)
+}
+
+func (r PostgresqlRepository) identities(ctx context.Context, userID pgtype.UUID) ([]Identity, error) {
+	const sql = `
+		SELECT provider, connected_at FROM external_identities
+		WHERE user_id = $1
+		ORDER BY connected_at;
+	`
+
+	rows, err := r.pool.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, postgresql.HandleQueryErr(err)
+	}
+
+	var identities []Identity
+	for rows.Next() {
+		var identity Identity
+		if scanError := rows.Scan(&identity.Provider, &identity.ConnectedAt); scanError != nil {
+			err = errors.Join(scanError, err)
+			continue
+		}
+
+		identities = append(identities, identity)
+	}
+
+	return identities, postgresql.HandleQueryErr(errors.Join(err, rows.Err()))
+}
+
+func (r PostgresqlRepository) sessions(ctx context.Context, userID pgtype.UUID) ([]Session, error) {
+	const sql = `
+		SELECT id, device, start_time, is_active FROM sessions
+		WHERE user_id = $1
+		ORDER BY start_time DESC;
+	`
+
+	rows, err := r.pool.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, postgresql.HandleQueryErr(err)
+	}
+
+	var sessions []Session
+	for rows.Next() {
+		var session Session
+		if scanError := rows.Scan(&session.ID, &session.Device, &session.StartTime, &session.IsActive); scanError != nil {
+			err = errors.Join(scanError, err)
+			continue
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, postgresql.HandleQueryErr(errors.Join(err, rows.Err()))
+}
+
+func (r PostgresqlRepository) confirmEmail(ctx context.Context, userID pgtype.UUID) error {
+	const sql = `
+		UPDATE emails
+		SET is_confirmed = TRUE
+		WHERE owner_id = $1
+		AND is_active = TRUE;
+	`
+
+	result, err := r.pool.Exec(ctx, sql, userID)
+	if err != nil {
+		return postgresql.HandleQueryErr(err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return postgresql.ErrNoMatches
+	}
+
+	return nil
+}
+
+func (r PostgresqlRepository) disable(ctx context.Context, userID, disabledBy pgtype.UUID) error {
+	const sql = `
+		INSERT INTO disabled_users (user_id, disabled_by)
+		SELECT id, $2
+		FROM users
+		WHERE id = $1
+		ON CONFLICT (user_id) DO NOTHING;
+	`
+
+	_, err := r.pool.Exec(ctx, sql, userID, disabledBy)
+	if err != nil {
+		return postgresql.HandleQueryErr(err)
+	}
+
+	return r.checkExists(ctx, userID)
+}
+
+func (r PostgresqlRepository) enable(ctx context.Context, userID pgtype.UUID) error {
+	const sql = `
+		DELETE FROM disabled_users
+		WHERE user_id = $1;
+	`
+
+	_, err := r.pool.Exec(ctx, sql, userID)
+	if err != nil {
+		return postgresql.HandleQueryErr(err)
+	}
+
+	return r.checkExists(ctx, userID)
+}
+
+func (r PostgresqlRepository) addAuditRecord(ctx context.Context, record AuditRecord) error {
+	const sql = `
+		INSERT INTO admin_audit_log (actor_id, action, target_user_id, details, error)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''));
+	`
+
+	_, err := r.pool.Exec(ctx, sql, record.ActorID, record.Action, record.TargetID, record.Details, record.Error)
+	return postgresql.HandleQueryErr(err)
+}
+
+func (r PostgresqlRepository) checkExists(ctx context.Context, userID pgtype.UUID) error {
+	const sql = `
+		SELECT EXISTS (
+		    SELECT 1 FROM users
+		    WHERE id = $1
+		);
+	`
+
+	var exists bool
+	if err := r.pool.QueryRow(ctx, sql, userID).Scan(&exists); err != nil {
+		return postgresql.HandleQueryErr(err)
+	}
+
+	if !exists {
+		return postgresql.ErrNoMatches
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/zhuboris/never-expires/internal/id/api/endpoint"
+	"github.com/zhuboris/never-expires/internal/id/authservice"
+	"github.com/zhuboris/never-expires/internal/id/lang"
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/shared/postgresql"
+)
+
+const tokenQueryName = "token"
+
+type (
+	repository interface {
+		role(ctx context.Context, userID pgtype.UUID) (Role, error)
+		user(ctx context.Context, selector UserSelector) (User, error)
+		identities(ctx context.Context, userID pgtype.UUID) ([]Identity, error)
+		sessions(ctx context.Context, userID pgtype.UUID) ([]Session, error)
+		confirmEmail(ctx context.Context, userID pgtype.UUID) error
+		disable(ctx context.Context, userID, disabledBy pgtype.UUID) error
+		enable(ctx context.Context, userID pgtype.UUID) error
+		addAuditRecord(ctx context.Context, record AuditRecord) error
+	}
+	AuthService interface {
+		DeleteUser(ctx context.Context) authservice.DeleteUserResult
+		DeactivateAllSessions(ctx context.Context) error
+		AddEmailConfirmationToken(ctx context.Context, email string) (string, error)
+	}
+	emailMessages interface {
+		ConfirmEmail(recipient, url string, language lang.Language) ([]byte, error)
+		AccountDeletionScheduled(recipient, url string, deleteAfter time.Time, language lang.Language) ([]byte, error)
+	}
+	emailQueue interface {
+		Add(ctx context.Context, recipient string, msg []byte) error
+	}
+)
+
+// Service runs support actions on behalf of the user from context, every attempt is written to the audit log.
+type Service struct {
+	repo        repository
+	authService AuthService
+	messages    emailMessages
+	queue       emailQueue
+	publicURL   string
+}
+
+func NewService(repo repository, authService AuthService, messages emailMessages, queue emailQueue, publicURL string) *Service {
+	return &Service{
+		repo:        repo,
+		authService: authService,
+		messages:    messages,
+		queue:       queue,
+		publicURL:   publicURL,
+	}
+}
+
+func (s Service) User(ctx context.Context, selector UserSelector) (User, error) {
+	if !selector.ID.Valid && selector.Email == "" {
+		return User{}, ErrMissingUserSelector
+	}
+
+	var user User
+	err := s.audited(ctx, RoleSupport, ActionLookupUser, selector.ID, selector.Email, func() (err error) {
+		user, err = s.user(ctx, selector)
+		return err
+	})
+
+	return user, err
+}
+
+func (s Service) Identities(ctx context.Context, userID pgtype.UUID) ([]Identity, error) {
+	var identities []Identity
+	err := s.audited(ctx, RoleSupport, ActionViewIdentities, userID, "", func() (err error) {
+		identities, err = s.repo.identities(ctx, userID)
+		return err
+	})
+
+	return identities, err
+}
+
+func (s Service) Sessions(ctx context.Context, userID pgtype.UUID) ([]Session, error) {
+	var sessions []Session
+	err := s.audited(ctx, RoleSupport, ActionViewSessions, userID, "", func() (err error) {
+		sessions, err = s.repo.sessions(ctx, userID)
+		return err
+	})
+
+	return sessions, err
+}
+
+func (s Service) ForceLogout(ctx context.Context, userID pgtype.UUID) error {
+	return s.audited(ctx, RoleSupport, ActionForceLogout, userID, "", func() error {
+		return s.authService.DeactivateAllSessions(usr.WithUserID(ctx, userID))
+	})
+}
+
+func (s Service) ResendConfirmationEmail(ctx context.Context, userID pgtype.UUID) error {
+	return s.audited(ctx, RoleSupport, ActionResendConfirmation, userID, "", func() error {
+		user, err := s.user(ctx, UserSelector{ID: userID})
+		if err != nil {
+			return err
+		}
+
+		if user.IsEmailConfirmed {
+			return usr.ErrEmailAlreadyConfirmed
+		}
+
+		token, err := s.authService.AddEmailConfirmationToken(ctx, user.Email)
+		if err != nil {
+			return err
+		}
+
+		link, err := s.linkWithToken(endpoint.ConfirmEmail, token)
+		if err != nil {
+			return err
+		}
+
+		msg, err := s.messages.ConfirmEmail(user.Email, link, lang.FromLocaleIdentifier(user.Language))
+		if err != nil {
+			return err
+		}
+
+		return s.queue.Add(ctx, user.Email, msg)
+	})
+}
+
+func (s Service) ConfirmEmail(ctx context.Context, userID pgtype.UUID) error {
+	return s.audited(ctx, RoleSupport, ActionConfirmEmail, userID, "", func() error {
+		return s.withNotFound(s.repo.confirmEmail(ctx, userID))
+	})
+}
+
+func (s Service) Disable(ctx context.Context, userID pgtype.UUID) error {
+	actorID, err := usr.ID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.audited(ctx, RoleAdmin, ActionDisable, userID, "", func() error {
+		if err := s.withNotFound(s.repo.disable(ctx, userID, actorID)); err != nil {
+			return err
+		}
+
+		return s.authService.DeactivateAllSessions(usr.WithUserID(ctx, userID))
+	})
+}
+
+func (s Service) Enable(ctx context.Context, userID pgtype.UUID) error {
+	return s.audited(ctx, RoleAdmin, ActionEnable, userID, "", func() error {
+		return s.withNotFound(s.repo.enable(ctx, userID))
+	})
+}
+
+// Delete schedules deletion the same way as user does it, so the user gets email with restore link.
+func (s Service) Delete(ctx context.Context, userID pgtype.UUID) error {
+	return s.audited(ctx, RoleAdmin, ActionDelete, userID, "", func() error {
+		user, err := s.user(ctx, UserSelector{ID: userID})
+		if err != nil {
+			return err
+		}
+
+		result := s.authService.DeleteUser(usr.WithUserID(ctx, userID))
+		if err := result.Error(); err != nil {
+			return err
+		}
+
+		deletion := result.Deletion()
+		link, err := s.linkWithToken(endpoint.RestoreUser, deletion.RestoreToken.Value)
+		if err != nil {
+			return err
+		}
+
+		msg, err := s.messages.AccountDeletionScheduled(deletion.Email, link, deletion.DeleteAfter(), lang.FromLocaleIdentifier(user.Language))
+		if err != nil {
+			return err
+		}
+
+		return s.queue.Add(ctx, deletion.Email, msg)
+	})
+}
+
+func (s Service) audited(ctx context.Context, required Role, action Action, targetID pgtype.UUID, details string, do func() error) error {
+	actorID, err := usr.ID(ctx)
+	if err != nil {
+		return err
+	}
+
+	actionErr := s.authorize(ctx, actorID, required)
+	if actionErr == nil {
+		actionErr = do()
+	}
+
+	record := AuditRecord{
+		ActorID:  actorID,
+		Action:   action,
+		TargetID: targetID,
+		Details:  details,
+	}
+
+	if actionErr != nil {
+		record.Error = actionErr.Error()
+	}
+
+	if err := s.repo.addAuditRecord(ctx, record); err != nil {
+		return errors.Join(actionErr, err)
+	}
+
+	return actionErr
+}
+
+func (s Service) authorize(ctx context.Context, actorID pgtype.UUID, required Role) error {
+	role, err := s.repo.role(ctx, actorID)
+	if errors.Is(err, postgresql.ErrNoMatches) {
+		return errors.Join(ErrForbidden, err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !role.allows(required) {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+func (s Service) user(ctx context.Context, selector UserSelector) (User, error) {
+	user, err := s.repo.user(ctx, selector)
+	return user, s.withNotFound(err)
+}
+
+func (s Service) withNotFound(err error) error {
+	if errors.Is(err, postgresql.ErrNoMatches) {
+		return errors.Join(usr.ErrNotFound, err)
+	}
+
+	return err
+}
+
+func (s Service) linkWithToken(route, token string) (string, error) {
+	rawURL, err := url.JoinPath(s.publicURL, route)
+	if err != nil {
+		return "", err
+	}
+
+	link, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	query := link.Query()
+	query.Add(tokenQueryName, token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
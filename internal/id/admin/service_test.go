@@ -0,0 +1,156 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhuboris/never-expires/internal/id/lang"
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/shared/postgresql"
+)
+
+var (
+	actorID  = pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	targetID = pgtype.UUID{Bytes: [16]byte{2}, Valid: true}
+)
+
+func TestService_Disable(t *testing.T) {
+	errAudit := errors.New("audit is unavailable")
+
+	tests := []struct {
+		name        string
+		role        Role
+		roleErr     error
+		auditErr    error
+		wantDisable bool
+		wantErr     error
+	}{
+		{
+			name:        "admin disables account",
+			role:        RoleAdmin,
+			wantDisable: true,
+		},
+		{
+			name:    "support is not allowed",
+			role:    RoleSupport,
+			wantErr: ErrForbidden,
+		},
+		{
+			name:    "user without role is not allowed",
+			roleErr: postgresql.ErrNoMatches,
+			wantErr: ErrForbidden,
+		},
+		{
+			name:        "audit failure is returned",
+			role:        RoleAdmin,
+			auditErr:    errAudit,
+			wantDisable: true,
+			wantErr:     errAudit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := usr.WithUserID(context.Background(), actorID)
+			repo := NewMockrepository(t)
+			repo.EXPECT().role(ctx, actorID).Return(tt.role, tt.roleErr)
+			repo.EXPECT().addAuditRecord(ctx, mock.MatchedBy(func(record AuditRecord) bool {
+				return record.ActorID == actorID && record.TargetID == targetID && record.Action == ActionDisable
+			})).Return(tt.auditErr)
+
+			authService := NewMockAuthService(t)
+			if tt.wantDisable {
+				repo.EXPECT().disable(ctx, targetID, actorID).Return(nil)
+				authService.EXPECT().DeactivateAllSessions(mock.Anything).
+					RunAndReturn(func(ctx context.Context) error {
+						id, err := usr.ID(ctx)
+						require.NoError(t, err)
+						assert.Equal(t, targetID, id, "sessions of target user are deactivated")
+						return nil
+					})
+			}
+
+			err := NewService(repo, authService, nil, nil, "").Disable(ctx, targetID)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestService_ResendConfirmationEmail(t *testing.T) {
+	const (
+		email     = "user@test.com"
+		publicURL = "https://id.test.com"
+	)
+
+	tests := []struct {
+		name      string
+		confirmed bool
+		wantLink  string
+		wantErr   error
+	}{
+		{
+			name:     "email is sent in user language",
+			wantLink: publicURL + "/user/email/confirm?token=token",
+		},
+		{
+			name:      "confirmed email is refused",
+			confirmed: true,
+			wantErr:   usr.ErrEmailAlreadyConfirmed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := usr.WithUserID(context.Background(), actorID)
+			repo := NewMockrepository(t)
+			repo.EXPECT().role(ctx, actorID).Return(RoleSupport, nil)
+			repo.EXPECT().user(ctx, UserSelector{ID: targetID}).
+				Return(User{Email: email, IsEmailConfirmed: tt.confirmed, Language: "ru"}, nil)
+			repo.EXPECT().addAuditRecord(ctx, mock.Anything).Return(nil)
+
+			var (
+				authService = NewMockAuthService(t)
+				messages    = NewMockemailMessages(t)
+				queue       = NewMockemailQueue(t)
+			)
+
+			if tt.wantLink != "" {
+				authService.EXPECT().AddEmailConfirmationToken(ctx, email).Return("token", nil)
+				messages.EXPECT().ConfirmEmail(email, tt.wantLink, lang.Language("ru")).Return([]byte("msg"), nil)
+				queue.EXPECT().Add(ctx, email, []byte("msg")).Return(nil)
+			}
+
+			err := NewService(repo, authService, messages, queue, publicURL).ResendConfirmationEmail(ctx, targetID)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestService_User(t *testing.T) {
+	t.Run("selector is required", func(t *testing.T) {
+		ctx := usr.WithUserID(context.Background(), actorID)
+		_, err := NewService(NewMockrepository(t), nil, nil, nil, "").User(ctx, UserSelector{})
+		assert.ErrorIs(t, err, ErrMissingUserSelector)
+	})
+
+	t.Run("lookup by email is audited with the email", func(t *testing.T) {
+		ctx := usr.WithUserID(context.Background(), actorID)
+		selector := UserSelector{Email: "user@test.com"}
+
+		repo := NewMockrepository(t)
+		repo.EXPECT().role(ctx, actorID).Return(RoleSupport, nil)
+		repo.EXPECT().user(ctx, selector).Return(User{}, postgresql.ErrNoMatches)
+		repo.EXPECT().addAuditRecord(ctx, mock.MatchedBy(func(record AuditRecord) bool {
+			return record.Action == ActionLookupUser && record.Details == selector.Email && record.Error != ""
+		})).Return(nil)
+
+		_, err := NewService(repo, nil, nil, nil, "").User(ctx, selector)
+		assert.ErrorIs(t, err, usr.ErrNotFound)
+	})
+}
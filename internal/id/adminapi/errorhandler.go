@@ -0,0 +1,153 @@
+package adminapi
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zhuboris/never-expires/internal/id/admin"
+	"github.com/zhuboris/never-expires/internal/id/tkn"
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/shared/httpmux"
+	"github.com/zhuboris/never-expires/internal/shared/reqbody"
+	"github.com/zhuboris/never-expires/internal/shared/validation"
+)
+
+const StatusEmailAlreadyConfirmed httpmux.StatusCode = 2004
+
+func handleResponseErrors(err error) httpmux.RequestingResult {
+	const StatusClientClosedRequest = 499
+
+	if err == nil {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Info).
+			AddStatusCode(http.StatusOK).
+			AddResponseMessage(httpmux.StatusCode(http.StatusOK).SuccessMessage("successfully completed request")).
+			WithoutResponse().
+			Build()
+	}
+
+	if errors.Is(err, httpmux.ErrTimeout) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusRequestTimeout).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, httpmux.ErrCanceled) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(StatusClientClosedRequest).
+			WithoutResponse().
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, tkn.ErrUnauthorized) || errors.Is(err, httpmux.ErrUnauthorized) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnauthorized).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, admin.ErrForbidden) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusForbidden).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, usr.ErrNotFound) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusNotFound).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, reqbody.ErrBodyTooLarge) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusRequestEntityTooLarge).
+			AddInternalErrorCode(httpmux.StatusBodyTooLarge).
+			AddResponseMessage(httpmux.StatusBodyTooLarge.ErrorMessage(reqbody.ErrBodyTooLarge.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if fields, ok := validation.FieldErrors(err); ok {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(httpmux.StatusInvalidFields).
+			AddResponseMessage(httpmux.StatusInvalidFields.InvalidFieldsMessage(validation.ErrInvalidFields.Error(), fields)).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, ErrInvalidBody) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(httpmux.StatusInvalidJSONBody).
+			AddResponseMessage(httpmux.StatusInvalidJSONBody.ErrorMessage(ErrInvalidBody.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, ErrInvalidUserID) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(httpmux.StatusMissingParameter).
+			AddResponseMessage(httpmux.StatusMissingParameter.ErrorMessage(ErrInvalidUserID.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, admin.ErrMissingUserSelector) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(httpmux.StatusMissingParameter).
+			AddResponseMessage(httpmux.StatusMissingParameter.ErrorMessage(admin.ErrMissingUserSelector.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, usr.ErrEmailAlreadyConfirmed) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(StatusEmailAlreadyConfirmed).
+			AddResponseMessage(StatusEmailAlreadyConfirmed.ErrorMessage(usr.ErrEmailAlreadyConfirmed.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, httpmux.ErrInvalidMethod) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(httpmux.StatusUnexistingHTTPMethod).
+			AddResponseMessage(httpmux.StatusUnexistingHTTPMethod.ErrorMessage(httpmux.ErrInvalidMethod.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, httpmux.ErrMethodNotAllowed) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusMethodNotAllowed).
+			AddError(err).
+			Build()
+	}
+
+	return httpmux.NewRequestingResultBuilder().
+		SetType(httpmux.Error).
+		AddStatusCode(http.StatusInternalServerError).
+		AddError(err).
+		Build()
+}
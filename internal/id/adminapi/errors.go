@@ -0,0 +1,8 @@
+package adminapi
+
+import "errors"
+
+var (
+	ErrInvalidBody   = errors.New("invalid request body")
+	ErrInvalidUserID = errors.New("user id must be valid uuid")
+)
@@ -0,0 +1,132 @@
+package adminapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/zhuboris/never-expires/internal/id/admin"
+	"github.com/zhuboris/never-expires/internal/id/api/response"
+	"github.com/zhuboris/never-expires/internal/shared/httpmux"
+	"github.com/zhuboris/never-expires/internal/shared/reqbody"
+	"github.com/zhuboris/never-expires/internal/shared/uuidformat"
+)
+
+const (
+	idQueryName    = "id"
+	emailQueryName = "email"
+)
+
+type (
+	userAction func(ctx context.Context, userID pgtype.UUID) error
+	actionData struct {
+		UserID string `json:"user_id" validate:"required"`
+	}
+)
+
+func (s *Server) handleUser(w http.ResponseWriter, r *http.Request) error {
+	query := r.URL.Query()
+	selector := admin.UserSelector{
+		Email: query.Get(emailQueryName),
+	}
+
+	if rawID := query.Get(idQueryName); rawID != "" {
+		id, err := uuidformat.StrToPgtype(rawID)
+		if err != nil {
+			return errors.Join(ErrInvalidUserID, err)
+		}
+
+		selector.ID = id
+	}
+
+	return handleLookup(w, r, func(ctx context.Context) (admin.User, error) {
+		return s.service.User(ctx, selector)
+	})
+}
+
+func (s *Server) handleIdentities(w http.ResponseWriter, r *http.Request) error {
+	userID, err := userIDFromQuery(r)
+	if err != nil {
+		return err
+	}
+
+	return handleLookup(w, r, func(ctx context.Context) ([]admin.Identity, error) {
+		return s.service.Identities(ctx, userID)
+	})
+}
+
+func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) error {
+	userID, err := userIDFromQuery(r)
+	if err != nil {
+		return err
+	}
+
+	return handleLookup(w, r, func(ctx context.Context) ([]admin.Session, error) {
+		return s.service.Sessions(ctx, userID)
+	})
+}
+
+func (s *Server) handleAction(action userAction, successMessage string) func(w http.ResponseWriter, r *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		input := new(actionData)
+		if err := reqbody.Decode(input, r.Body); err != nil {
+			return errors.Join(ErrInvalidBody, err)
+		}
+
+		userID, err := uuidformat.StrToPgtype(input.UserID)
+		if err != nil {
+			return errors.Join(ErrInvalidUserID, err)
+		}
+
+		var (
+			ctx     = r.Context()
+			handler = func() error {
+				return action(ctx, userID)
+			}
+		)
+
+		if err := httpmux.HandleErrorFuncWithTimeout(ctx, handler); err != nil {
+			return err
+		}
+
+		response.WriteMessage(w, http.StatusOK, successMessage)
+		return nil
+	}
+}
+
+type lookupResult[T any] struct {
+	data T
+	err  error
+}
+
+func handleLookup[T any](w http.ResponseWriter, r *http.Request, lookup func(ctx context.Context) (T, error)) error {
+	var (
+		ctx     = r.Context()
+		handler = func() lookupResult[T] {
+			data, err := lookup(ctx)
+			return lookupResult[T]{data, err}
+		}
+	)
+
+	result, ctxError := httpmux.HandleWithTimeout(ctx, handler)
+	if result.err != nil || ctxError != nil {
+		return errors.Join(result.err, ctxError)
+	}
+
+	return response.WriteJSONData(w, http.StatusOK, result.data)
+}
+
+func userIDFromQuery(r *http.Request) (pgtype.UUID, error) {
+	rawID := r.URL.
+		Query().
+		Get(idQueryName)
+
+	id, err := uuidformat.StrToPgtype(rawID)
+	if err != nil {
+		return id, errors.Join(ErrInvalidUserID, err)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,89 @@
+package adminapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+
+	"github.com/zhuboris/never-expires/internal/id/admin"
+	"github.com/zhuboris/never-expires/internal/shared/httpmux"
+	"github.com/zhuboris/never-expires/internal/shared/runapi"
+)
+
+const (
+	routeUsers                 = "/admin/users"
+	routeUserIdentities        = "/admin/users/identities"
+	routeUserSessions          = "/admin/users/sessions"
+	routeUserLogout            = "/admin/users/logout"
+	routeSendConfirmationEmail = "/admin/users/email/send-confirmation"
+	routeConfirmEmail          = "/admin/users/email/confirm"
+	routeDisable               = "/admin/users/disable"
+	routeEnable                = "/admin/users/enable"
+	routeDelete                = "/admin/users/delete"
+)
+
+type Service interface {
+	User(ctx context.Context, selector admin.UserSelector) (admin.User, error)
+	Identities(ctx context.Context, userID pgtype.UUID) ([]admin.Identity, error)
+	Sessions(ctx context.Context, userID pgtype.UUID) ([]admin.Session, error)
+	ForceLogout(ctx context.Context, userID pgtype.UUID) error
+	ResendConfirmationEmail(ctx context.Context, userID pgtype.UUID) error
+	ConfirmEmail(ctx context.Context, userID pgtype.UUID) error
+	Disable(ctx context.Context, userID pgtype.UUID) error
+	Enable(ctx context.Context, userID pgtype.UUID) error
+	Delete(ctx context.Context, userID pgtype.UUID) error
+}
+
+// Server is support staff API, it must be reachable only from internal network.
+// Callers authenticate with usual access token and need a role from admin_roles table.
+type Server struct {
+	server        *http.Server
+	listenAddress string
+	service       Service
+	logger        *zap.Logger
+}
+
+func NewServer(address string, service Service, logger *zap.Logger) *Server {
+	return &Server{
+		listenAddress: address,
+		service:       service,
+		logger:        logger,
+	}
+}
+
+func (s *Server) RunWithCtx(ctx context.Context) error {
+	return runapi.WithContext(ctx, s.run, func(ctx context.Context) error {
+		return s.server.Shutdown(ctx)
+	})
+}
+
+func (s *Server) run() error {
+	const defaultTimeout = 10 * time.Second
+
+	mux := httpmux.NewMux(handleResponseErrors)
+	mux.SetLogger(s.logger)
+	mux.SetDefaultTimeout(defaultTimeout)
+
+	s.server = &http.Server{
+		Addr:    s.listenAddress,
+		Handler: mux,
+	}
+
+	mux.HandleGet(routeUsers, s.handleUser, httpmux.Authorize())
+	mux.HandleGet(routeUserIdentities, s.handleIdentities, httpmux.Authorize())
+	mux.HandleGet(routeUserSessions, s.handleSessions, httpmux.Authorize())
+	mux.HandlePost(routeUserLogout, s.handleAction(s.service.ForceLogout, "user is logged out"), httpmux.Authorize())
+	mux.HandlePost(routeSendConfirmationEmail, s.handleAction(s.service.ResendConfirmationEmail, "confirmation email is sent"), httpmux.Authorize())
+	mux.HandlePost(routeConfirmEmail, s.handleAction(s.service.ConfirmEmail, "email is confirmed"), httpmux.Authorize())
+	mux.HandlePost(routeDisable, s.handleAction(s.service.Disable, "account is disabled"), httpmux.Authorize())
+	mux.HandlePost(routeEnable, s.handleAction(s.service.Enable, "account is enabled"), httpmux.Authorize())
+	mux.HandlePost(routeDelete, s.handleAction(s.service.Delete, "account deletion is scheduled"), httpmux.Authorize())
+
+	s.logger.Info("Server is up")
+	err := s.server.ListenAndServe()
+	s.logger.Error("Server is shutdown", zap.Error(err))
+	return err
+}
@@ -11,35 +11,44 @@ import (
 
 	"github.com/zhuboris/never-expires/internal/id/api/endpoint"
 	"github.com/zhuboris/never-expires/internal/id/api/request"
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/shared/health"
 	"github.com/zhuboris/never-expires/internal/shared/httpmux"
 	"github.com/zhuboris/never-expires/internal/shared/prometheusexporter"
+	"github.com/zhuboris/never-expires/internal/shared/ratelimit"
 	"github.com/zhuboris/never-expires/internal/shared/runapi"
 )
 
 type requestCounterCreator interface {
 	NewRequestCounter() (*prometheusexporter.RequestCounter, error)
+	NewRequestDurationHistogram() (*prometheusexporter.RequestDurationHistogram, error)
+	NewThrottleCounter() (*prometheusexporter.ThrottleCounter, error)
 }
 
 type Server struct {
-	server        *http.Server
-	listenAddress string
-	authService   request.AuthService
-	logger        *zap.Logger
-	exporter      requestCounterCreator
+	server         *http.Server
+	listenAddress  string
+	authService    request.AuthService
+	healthChecker  *health.Checker
+	rateLimitStore ratelimit.Store
+	logger         *zap.Logger
+	exporter       requestCounterCreator
 }
 
-func NewServer(address string, authService request.AuthService, logger *zap.Logger, exporter requestCounterCreator) *Server {
+func NewServer(address string, authService request.AuthService, healthChecker *health.Checker, rateLimitStore ratelimit.Store, logger *zap.Logger, exporter requestCounterCreator) *Server {
 	return &Server{
-		listenAddress: address,
-		authService:   authService,
-		logger:        logger,
-		exporter:      exporter,
+		listenAddress:  address,
+		authService:    authService,
+		healthChecker:  healthChecker,
+		rateLimitStore: rateLimitStore,
+		logger:         logger,
+		exporter:       exporter,
 	}
 }
 
 func (s *Server) RunWithCtx(ctx context.Context) error {
-	return runapi.WithContext(ctx, s.run, func() error {
-		return s.server.Shutdown(context.Background())
+	return runapi.WithContext(ctx, s.run, func(ctx context.Context) error {
+		return s.server.Shutdown(ctx)
 	})
 }
 
@@ -50,6 +59,17 @@ func (s *Server) run() error {
 		registerTimeout         = 25 * time.Second
 	)
 
+	var (
+		loginLimit             = ratelimit.PerMinute(10, 20)
+		registerLimit          = ratelimit.PerHour(10, 5)
+		passkeyLimit           = ratelimit.PerMinute(10, 20)
+		loginCodeByEmailLimit  = ratelimit.PerHour(12, 3)
+		loginCodeByIPLimit     = ratelimit.PerHour(40, 10)
+		loginCodeVerifyLimit   = ratelimit.PerHour(120, 30)
+		resetEmailByEmailLimit = ratelimit.PerHour(3, 3)
+		resetEmailByIPLimit    = ratelimit.PerHour(10, 10)
+	)
+
 	mux, err := s.setupMux(defaultTimeout)
 	if err != nil {
 		return err
@@ -60,22 +80,39 @@ func (s *Server) run() error {
 		Handler: mux,
 	}
 
-	mux.HandlePost(endpoint.Login, s.handleLogin)
-	mux.HandlePost(endpoint.Register, s.handleRegister, httpmux.SetTimeout(registerTimeout))
+	mux.HandlePost(endpoint.Login, s.handleLogin, mux.RateLimit(loginLimit, httpmux.ByIP))
+	mux.HandlePost(endpoint.Register, s.handleRegister, httpmux.SetTimeout(registerTimeout), mux.RateLimit(registerLimit, httpmux.ByIP))
 	mux.HandlePost(endpoint.Refresh, s.handleRefresh)
 	mux.HandleDelete(endpoint.Logout, s.handleLogout)
 	mux.HandlePatch(endpoint.ChangePassword, s.handleUserPasswordChange, httpmux.Authorize())
 	mux.HandlePatch(endpoint.ChangeEmail, s.handleUserEmailChange, httpmux.Authorize())
+	mux.HandleGet(endpoint.ConfirmEmailChange, s.handleUserEmailChangeConfirm)
+	mux.HandleGet(endpoint.RevertEmailChange, s.handleUserEmailChangeRevert)
 	mux.HandlePatch(endpoint.ChangeUsername, s.handleUserUsernameChange, httpmux.Authorize())
 	mux.HandlePost(endpoint.SendConfirmationEmail, s.handleUserEmailSendConfirmation, httpmux.Authorize())
 	mux.HandleGet(endpoint.ConfirmEmail, s.handleUserEmailConfirm, httpmux.SetTimeout(confirmationMailTimeout))
-	mux.HandleFuncWithMiddlewares(endpoint.User, s.handleUser, []string{http.MethodGet, http.MethodDelete}, httpmux.Authorize())
-	mux.HandlePost(endpoint.SendPasswordResetEmail, s.handleUserPasswordSendResetEmail)
+	mux.HandleFuncWithMiddlewares(endpoint.User, s.handleUser, []string{http.MethodGet, http.MethodPatch, http.MethodDelete}, httpmux.Authorize())
+	mux.HandleFuncWithMiddlewares(endpoint.Avatar, s.handleUserAvatar, []string{http.MethodGet, http.MethodPut, http.MethodDelete}, httpmux.Authorize(), httpmux.MaxBodySize(usr.MaxAvatarSize))
+	mux.HandleGet(endpoint.RestoreUser, s.handleUserRestore)
+	mux.HandlePost(endpoint.SendPasswordResetEmail, s.handleUserPasswordSendResetEmail, mux.RateLimit(resetEmailByIPLimit, httpmux.ByIP), mux.RateLimit(resetEmailByEmailLimit, httpmux.ByEmail))
 	mux.HandleGet(endpoint.ResetPassword, s.handlePasswordRestore)
+	mux.HandlePost(endpoint.CompletePasswordReset, s.handlePasswordResetCompletion)
 	mux.HandlePost(endpoint.LoginGoogleIOs, s.handleLoginGoogleIOs)
 	mux.HandlePost(endpoint.LoginAppleIOs, s.handleLoginApple)
+	mux.HandlePost(endpoint.LoginEmailCode, s.handleLoginEmailCode, mux.RateLimit(loginCodeByIPLimit, httpmux.ByIP), mux.RateLimit(loginCodeByEmailLimit, httpmux.ByEmail))
+	mux.HandlePost(endpoint.LoginEmailCodeVerify, s.handleLoginEmailCodeVerify, mux.RateLimit(loginCodeVerifyLimit, httpmux.ByIP))
+	mux.HandlePost(endpoint.LoginPasskeyBegin, s.handleLoginPasskeyBegin, mux.RateLimit(passkeyLimit, httpmux.ByIP))
+	mux.HandlePost(endpoint.LoginPasskeyFinish, s.handleLoginPasskeyFinish, mux.RateLimit(passkeyLimit, httpmux.ByIP))
+	mux.HandlePost(endpoint.RegisterPasskeyBegin, s.handleRegisterPasskeyBegin, httpmux.Authorize(), mux.RateLimit(passkeyLimit, httpmux.ByIP))
+	mux.HandlePost(endpoint.RegisterPasskeyFinish, s.handleRegisterPasskeyFinish, httpmux.Authorize())
+	mux.HandleGet(endpoint.LoginOIDC, s.handleOIDCProviders)
+	mux.HandlePost(endpoint.LoginOIDCWithParam, s.handleLoginOIDC, mux.RateLimit(loginLimit, httpmux.ByIP))
+	mux.HandleGet(endpoint.UserIdentities, s.handleUserIdentities, httpmux.Authorize())
+	mux.HandleFuncWithMiddlewares(endpoint.UserIdentitiesWithParam, s.handleUserIdentitiesByProvider, []string{http.MethodPost, http.MethodDelete}, httpmux.Authorize())
+	mux.HandlePost(endpoint.DataExport, s.handleDataExport, httpmux.Authorize())
+	mux.HandleGet(endpoint.DataExportDownload, s.handleDataExportDownload)
 
-	mux.HandleStatus(s.authService)
+	mux.HandleHealth(s.healthChecker)
 	mux.HandleSwaggerBySpecification("./api/id/swagger.yml")
 
 	s.logger.Info("Server is up")
@@ -95,6 +132,21 @@ func (s *Server) setupMux(defaultTimeout time.Duration) (*httpmux.Mux, error) {
 	}
 
 	mux.SetRequestCounter(counter)
+
+	durationHistogram, err := s.exporter.NewRequestDurationHistogram()
+	if err != nil {
+		return nil, fmt.Errorf("error init request duration histogram: %w", err)
+	}
+
+	mux.SetRequestDurationHistogram(durationHistogram)
+
+	throttleCounter, err := s.exporter.NewThrottleCounter()
+	if err != nil {
+		return nil, fmt.Errorf("error init throttle counter: %w", err)
+	}
+
+	mux.SetThrottleCounter(throttleCounter)
+	mux.SetRateLimitStore(s.rateLimitStore)
 	return mux, nil
 }
 
@@ -122,6 +174,14 @@ func (s *Server) handleUserEmailChange(w http.ResponseWriter, r *http.Request) e
 	return request.NewChangeMailRequest(s.authService).Handle(w, r)
 }
 
+func (s *Server) handleUserEmailChangeConfirm(w http.ResponseWriter, r *http.Request) error {
+	return request.NewConfirmEmailChangeRequest(s.authService).Handle(w, r)
+}
+
+func (s *Server) handleUserEmailChangeRevert(w http.ResponseWriter, r *http.Request) error {
+	return request.NewRevertEmailChangeRequest(s.authService).Handle(w, r)
+}
+
 func (s *Server) handleUserEmailSendConfirmation(w http.ResponseWriter, r *http.Request) error {
 	return request.NewSendConfirmationEmailRequest(s.authService).Handle(w, r)
 }
@@ -138,6 +198,8 @@ func (s *Server) handleUser(w http.ResponseWriter, r *http.Request) error {
 	switch r.Method {
 	case http.MethodGet:
 		return request.NewGetUserRequest(s.authService).Handle(w, r)
+	case http.MethodPatch:
+		return request.NewUpdateProfileRequest(s.authService).Handle(w, r)
 	case http.MethodDelete:
 		return request.NewDeleteUserRequest(s.authService).Handle(w, r)
 	default:
@@ -145,12 +207,33 @@ func (s *Server) handleUser(w http.ResponseWriter, r *http.Request) error {
 	}
 }
 
+func (s *Server) handleUserAvatar(w http.ResponseWriter, r *http.Request) error {
+	switch r.Method {
+	case http.MethodGet:
+		return request.NewGetAvatarRequest(s.authService).Handle(w, r)
+	case http.MethodPut:
+		return request.NewUploadAvatarRequest(s.authService).Handle(w, r)
+	case http.MethodDelete:
+		return request.NewDeleteAvatarRequest(s.authService).Handle(w, r)
+	default:
+		return errors.New("server allowed method that is not supported")
+	}
+}
+
+func (s *Server) handleUserRestore(w http.ResponseWriter, r *http.Request) error {
+	return request.NewRestoreUserRequest(s.authService).Handle(w, r)
+}
+
 func (s *Server) handleUserPasswordSendResetEmail(w http.ResponseWriter, r *http.Request) error {
 	return request.NewSendResetPasswordEmailRequest(s.authService).Handle(w, r)
 }
 
 func (s *Server) handlePasswordRestore(w http.ResponseWriter, r *http.Request) error {
-	return request.NewResetPasswordRequest(s.authService).Handle(w, r)
+	return request.NewResetPasswordRequest().Handle(w, r)
+}
+
+func (s *Server) handlePasswordResetCompletion(w http.ResponseWriter, r *http.Request) error {
+	return request.NewCompletePasswordResetRequest(s.authService).Handle(w, r)
 }
 
 func (s *Server) handleLoginGoogleIOs(w http.ResponseWriter, r *http.Request) error {
@@ -160,3 +243,58 @@ func (s *Server) handleLoginGoogleIOs(w http.ResponseWriter, r *http.Request) er
 func (s *Server) handleLoginApple(w http.ResponseWriter, r *http.Request) error {
 	return request.NewLoginWithAppleRequest(s.authService).Handle(w, r)
 }
+
+func (s *Server) handleLoginEmailCode(w http.ResponseWriter, r *http.Request) error {
+	return request.NewSendLoginCodeRequest(s.authService).Handle(w, r)
+}
+
+func (s *Server) handleLoginEmailCodeVerify(w http.ResponseWriter, r *http.Request) error {
+	return request.NewLoginWithEmailCodeRequest(s.authService).Handle(w, r)
+}
+
+func (s *Server) handleLoginPasskeyBegin(w http.ResponseWriter, r *http.Request) error {
+	return request.NewBeginPasskeyLoginRequest(s.authService).Handle(w, r)
+}
+
+func (s *Server) handleLoginPasskeyFinish(w http.ResponseWriter, r *http.Request) error {
+	return request.NewLoginWithPasskeyRequest(s.authService).Handle(w, r)
+}
+
+func (s *Server) handleRegisterPasskeyBegin(w http.ResponseWriter, r *http.Request) error {
+	return request.NewBeginPasskeyRegistrationRequest(s.authService).Handle(w, r)
+}
+
+func (s *Server) handleRegisterPasskeyFinish(w http.ResponseWriter, r *http.Request) error {
+	return request.NewFinishPasskeyRegistrationRequest(s.authService).Handle(w, r)
+}
+
+func (s *Server) handleOIDCProviders(w http.ResponseWriter, r *http.Request) error {
+	return request.NewOIDCProvidersRequest(s.authService).Handle(w, r)
+}
+
+func (s *Server) handleLoginOIDC(w http.ResponseWriter, r *http.Request) error {
+	return request.NewLoginWithOIDCRequest(s.authService).Handle(w, r)
+}
+
+func (s *Server) handleUserIdentities(w http.ResponseWriter, r *http.Request) error {
+	return request.NewGetIdentitiesRequest(s.authService).Handle(w, r)
+}
+
+func (s *Server) handleUserIdentitiesByProvider(w http.ResponseWriter, r *http.Request) error {
+	switch r.Method {
+	case http.MethodPost:
+		return request.NewLinkIdentityRequest(s.authService).Handle(w, r)
+	case http.MethodDelete:
+		return request.NewUnlinkIdentityRequest(s.authService).Handle(w, r)
+	default:
+		return errors.New("server allowed method that is not supported")
+	}
+}
+
+func (s *Server) handleDataExport(w http.ResponseWriter, r *http.Request) error {
+	return request.NewRequestDataExportRequest(s.authService).Handle(w, r)
+}
+
+func (s *Server) handleDataExportDownload(w http.ResponseWriter, r *http.Request) error {
+	return request.NewDownloadDataExportRequest(s.authService).Handle(w, r)
+}
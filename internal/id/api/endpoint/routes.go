@@ -1,18 +1,35 @@
 package endpoint
 
 const (
-	Login                  = "/login"
-	Logout                 = "/logout"
-	Register               = "/register"
-	Refresh                = "/refresh"
-	User                   = "/user"
-	ChangePassword         = "/user/password/change"
-	ResetPassword          = "/user/password/reset"
-	ChangeEmail            = "/user/email/change"
-	ChangeUsername         = "/user/username/change"
-	ConfirmEmail           = "/user/email/confirm"
-	SendConfirmationEmail  = "/user/email/send-confirmation"
-	SendPasswordResetEmail = "/user/password/send-reset-email"
-	LoginGoogleIOs         = "/login/google/ios"
-	LoginAppleIOs          = "/login/apple/ios"
+	Login                   = "/login"
+	Logout                  = "/logout"
+	Register                = "/register"
+	Refresh                 = "/refresh"
+	User                    = "/user"
+	RestoreUser             = "/user/restore"
+	ChangePassword          = "/user/password/change"
+	ResetPassword           = "/user/password/reset"
+	CompletePasswordReset   = "/user/password/reset/complete"
+	ChangeEmail             = "/user/email/change"
+	ConfirmEmailChange      = "/user/email/change/confirm"
+	RevertEmailChange       = "/user/email/change/revert"
+	ChangeUsername          = "/user/username/change"
+	ConfirmEmail            = "/user/email/confirm"
+	SendConfirmationEmail   = "/user/email/send-confirmation"
+	SendPasswordResetEmail  = "/user/password/send-reset-email"
+	LoginGoogleIOs          = "/login/google/ios"
+	LoginAppleIOs           = "/login/apple/ios"
+	LoginEmailCode          = "/login/email-code"
+	LoginEmailCodeVerify    = "/login/email-code/verify"
+	LoginPasskeyBegin       = "/login/passkey/begin"
+	LoginPasskeyFinish      = "/login/passkey/finish"
+	RegisterPasskeyBegin    = "/user/passkey/register/begin"
+	RegisterPasskeyFinish   = "/user/passkey/register/finish"
+	LoginOIDC               = "/login/oidc"
+	LoginOIDCWithParam      = "/login/oidc/"
+	UserIdentities          = "/user/identities"
+	UserIdentitiesWithParam = "/user/identities/"
+	DataExport              = "/user/export"
+	DataExportDownload      = "/user/export/download"
+	Avatar                  = "/user/avatar"
 )
@@ -3,14 +3,21 @@ package api
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/zhuboris/never-expires/internal/id/api/request"
 	"github.com/zhuboris/never-expires/internal/id/authservice"
+	"github.com/zhuboris/never-expires/internal/id/lang"
+	"github.com/zhuboris/never-expires/internal/id/loginguard"
+	"github.com/zhuboris/never-expires/internal/id/passkey"
 	"github.com/zhuboris/never-expires/internal/id/pw"
 	"github.com/zhuboris/never-expires/internal/id/tkn"
 	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/id/usr/oauth/oidc"
 	"github.com/zhuboris/never-expires/internal/shared/httpmux"
+	"github.com/zhuboris/never-expires/internal/shared/reqbody"
 	"github.com/zhuboris/never-expires/internal/shared/servicechecker"
+	"github.com/zhuboris/never-expires/internal/shared/validation"
 )
 
 const (
@@ -22,10 +29,28 @@ const (
 	StatusEmailIsChangedOrNotConfirmed httpmux.StatusCode = 2006
 	StatusEmailIsNotBelongToAnyUser    httpmux.StatusCode = 2007
 	StatusUserNotFound                 httpmux.StatusCode = 2008
+	StatusTooManyRequests              httpmux.StatusCode = 2009
+	StatusLoginCodeRefused             httpmux.StatusCode = 2010
+	StatusPasskeyRefused               httpmux.StatusCode = 2011
+	StatusIdentityAlreadyLinked        httpmux.StatusCode = 2012
+	StatusLastLoginMethod              httpmux.StatusCode = 2013
+	StatusLoginThrottled               httpmux.StatusCode = 2014
+	StatusAccountDisabled              httpmux.StatusCode = 2015
 	StatusInvalidEmail                 httpmux.StatusCode = 3001
 	StatusInsecurePassword             httpmux.StatusCode = 3002
+	StatusInvalidLanguage              httpmux.StatusCode = 3003
+	StatusInvalidTimeZone              httpmux.StatusCode = 3004
+	StatusUnsupportedAvatar            httpmux.StatusCode = 3005
+	StatusAvatarTooLarge               httpmux.StatusCode = 3006
 )
 
+// insecurePasswordMessage names the failed policy rule, so clients can highlight it without parsing the message.
+type insecurePasswordMessage struct {
+	httpmux.ErrorMessage
+
+	Rule pw.Rule `json:"rule"`
+}
+
 func handleResponseErrors(err error) httpmux.RequestingResult {
 	const StatusClientClosedRequest = 499
 
@@ -55,6 +80,15 @@ func handleResponseErrors(err error) httpmux.RequestingResult {
 			Build()
 	}
 
+	if errors.Is(err, usr.ErrRestoreRefused) || errors.Is(err, usr.ErrEmailChangeRefused) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Info).
+			AddStatusCode(http.StatusFound).
+			WithoutResponse().
+			AddError(err).
+			Build()
+	}
+
 	if errors.Is(err, tkn.ErrForbidden) {
 		return httpmux.NewRequestingResultBuilder().
 			SetType(httpmux.Error).
@@ -71,6 +105,22 @@ func handleResponseErrors(err error) httpmux.RequestingResult {
 			Build()
 	}
 
+	if errors.Is(err, oidc.ErrUnknownProvider) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusNotFound).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, usr.ErrIdentityNotLinked) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusNotFound).
+			AddError(err).
+			Build()
+	}
+
 	if errors.Is(err, httpmux.ErrInvalidMethod) {
 		return httpmux.NewRequestingResultBuilder().
 			SetType(httpmux.Error).
@@ -99,6 +149,31 @@ func handleResponseErrors(err error) httpmux.RequestingResult {
 			Build()
 	}
 
+	if errors.Is(err, loginguard.ErrThrottled) || errors.Is(err, authservice.ErrAccountLockedOut) {
+		builder := httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusTooManyRequests).
+			AddInternalErrorCode(StatusLoginThrottled).
+			AddResponseMessage(StatusLoginThrottled.ErrorMessage(loginguard.ErrThrottled.Error())).
+			AddError(err)
+
+		if errThrottled := new(loginguard.ThrottledError); errors.As(err, errThrottled) {
+			builder.AddHeader("Retry-After", strconv.Itoa(int(errThrottled.RetryAfter.Seconds())))
+		}
+
+		return builder.Build()
+	}
+
+	if errors.Is(err, usr.ErrAccountDisabled) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusForbidden).
+			AddInternalErrorCode(StatusAccountDisabled).
+			AddResponseMessage(StatusAccountDisabled.ErrorMessage(usr.ErrAccountDisabled.Error())).
+			AddError(err).
+			Build()
+	}
+
 	if errors.Is(err, authservice.ErrWrongLoginData) {
 		return httpmux.NewRequestingResultBuilder().
 			SetType(httpmux.Error).
@@ -119,6 +194,26 @@ func handleResponseErrors(err error) httpmux.RequestingResult {
 			Build()
 	}
 
+	if errors.Is(err, reqbody.ErrBodyTooLarge) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusRequestEntityTooLarge).
+			AddInternalErrorCode(httpmux.StatusBodyTooLarge).
+			AddResponseMessage(httpmux.StatusBodyTooLarge.ErrorMessage(reqbody.ErrBodyTooLarge.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if fields, ok := validation.FieldErrors(err); ok {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(httpmux.StatusInvalidFields).
+			AddResponseMessage(httpmux.StatusInvalidFields.InvalidFieldsMessage(validation.ErrInvalidFields.Error(), fields)).
+			AddError(err).
+			Build()
+	}
+
 	if errors.Is(err, request.ErrInvalidBody) {
 		return httpmux.NewRequestingResultBuilder().
 			SetType(httpmux.Error).
@@ -189,12 +284,25 @@ func handleResponseErrors(err error) httpmux.RequestingResult {
 			Build()
 	}
 
+	if errors.Is(err, usr.ErrEmailNotConfirmed) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(StatusEmailIsNotConfirmed).
+			AddResponseMessage(StatusEmailIsNotConfirmed.ErrorMessage(usr.ErrEmailNotConfirmed.Error())).
+			AddError(err).
+			Build()
+	}
+
 	if errInvalidPassword := new(pw.InsecurePasswordError); errors.As(err, errInvalidPassword) {
 		return httpmux.NewRequestingResultBuilder().
 			SetType(httpmux.Error).
 			AddStatusCode(http.StatusUnprocessableEntity).
 			AddInternalErrorCode(StatusInsecurePassword).
-			AddResponseMessage(StatusInsecurePassword.ErrorMessage(errInvalidPassword.Reason())).
+			AddResponseMessage(insecurePasswordMessage{
+				ErrorMessage: *StatusInsecurePassword.ErrorMessage(errInvalidPassword.Reason()),
+				Rule:         errInvalidPassword.Rule(),
+			}).
 			AddError(err).
 			Build()
 	}
@@ -209,6 +317,104 @@ func handleResponseErrors(err error) httpmux.RequestingResult {
 			Build()
 	}
 
+	if errors.Is(err, httpmux.ErrTooManyRequests) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusTooManyRequests).
+			AddInternalErrorCode(StatusTooManyRequests).
+			AddResponseMessage(StatusTooManyRequests.ErrorMessage(httpmux.ErrTooManyRequests.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, usr.ErrLoginCodeRefused) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(StatusLoginCodeRefused).
+			AddResponseMessage(StatusLoginCodeRefused.ErrorMessage(usr.ErrLoginCodeRefused.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, passkey.ErrCeremonyNotFound) || errors.Is(err, passkey.ErrVerificationFailed) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(StatusPasskeyRefused).
+			AddResponseMessage(StatusPasskeyRefused.ErrorMessage(passkey.ErrVerificationFailed.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, usr.ErrIdentityAlreadyLinked) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(StatusIdentityAlreadyLinked).
+			AddResponseMessage(StatusIdentityAlreadyLinked.ErrorMessage(usr.ErrIdentityAlreadyLinked.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, usr.ErrLastLoginMethod) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(StatusLastLoginMethod).
+			AddResponseMessage(StatusLastLoginMethod.ErrorMessage(usr.ErrLastLoginMethod.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, usr.ErrAvatarNotFound) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusNotFound).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, lang.ErrInvalidLanguage) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(StatusInvalidLanguage).
+			AddResponseMessage(StatusInvalidLanguage.ErrorMessage(lang.ErrInvalidLanguage.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, usr.ErrInvalidTimeZone) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(StatusInvalidTimeZone).
+			AddResponseMessage(StatusInvalidTimeZone.ErrorMessage(usr.ErrInvalidTimeZone.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, usr.ErrUnsupportedAvatar) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnsupportedMediaType).
+			AddInternalErrorCode(StatusUnsupportedAvatar).
+			AddResponseMessage(StatusUnsupportedAvatar.ErrorMessage(usr.ErrUnsupportedAvatar.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, usr.ErrAvatarTooLarge) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusRequestEntityTooLarge).
+			AddInternalErrorCode(StatusAvatarTooLarge).
+			AddResponseMessage(StatusAvatarTooLarge.ErrorMessage(usr.ErrAvatarTooLarge.Error())).
+			AddError(err).
+			Build()
+	}
+
 	if errors.Is(err, usr.ErrEmailAlreadyConfirmed) {
 		return httpmux.NewRequestingResultBuilder().
 			SetType(httpmux.Error).
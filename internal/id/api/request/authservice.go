@@ -6,33 +6,57 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/zhuboris/never-expires/internal/id/authservice"
+	"github.com/zhuboris/never-expires/internal/id/passkey"
 	"github.com/zhuboris/never-expires/internal/id/session"
+	"github.com/zhuboris/never-expires/internal/id/usr"
 	"github.com/zhuboris/never-expires/internal/id/usr/oauth"
+	"github.com/zhuboris/never-expires/internal/id/usr/oauth/oidc"
 )
 
 type AuthService interface {
 	UserFromGoogleIDToken(ctx context.Context, idToken oauth.Token) (oauth.User, error)
 	UserFromAppleTokenCode(ctx context.Context, idToken oauth.Token) (oauth.User, error)
+	UserFromOIDCToken(ctx context.Context, providerName string, idToken oauth.Token) (oauth.User, error)
+	OIDCProviders() []oidc.ProviderInfo
+	ExternalIdentities(ctx context.Context) ([]usr.ExternalIdentity, error)
+	LinkExternalIdentity(ctx context.Context, data authservice.LinkIdentityData) error
+	UnlinkExternalIdentity(ctx context.Context, provider string) error
 	AuthorizedUser(ctx context.Context) authservice.GettingUserResult
-	DeleteUser(ctx context.Context) error
-	UpdateMail(ctx context.Context, data authservice.ChangeMailData) error
+	UpdateProfile(ctx context.Context, update usr.ProfileUpdate) authservice.GettingUserResult
+	Avatar(ctx context.Context) (usr.Avatar, error)
+	UpdateAvatar(ctx context.Context, data []byte) error
+	DeleteAvatar(ctx context.Context) error
+	DeleteUser(ctx context.Context) authservice.DeleteUserResult
+	RestoreUser(ctx context.Context, token string) error
+	RequestDataExport(ctx context.Context, language string) error
+	DataExportArchive(ctx context.Context, token string) ([]byte, error)
+	UpdateMail(ctx context.Context, data authservice.ChangeMailData) authservice.EmailChangeResult
+	ConfirmEmailChange(ctx context.Context, token string) authservice.AppliedEmailChangeResult
+	RevertEmailChange(ctx context.Context, token string) authservice.AppliedEmailChangeResult
 	ChangePassword(ctx context.Context, data authservice.ChangePasswordData) error
 	ChangeUsername(ctx context.Context, input authservice.ChangeUsernameData) error
 	Login(ctx context.Context, data authservice.LoginData) authservice.LoginResult
+	LoginWithEmailCode(ctx context.Context, data authservice.LoginWithEmailCodeData) authservice.LoginResult
+	BeginPasskeyLogin(ctx context.Context) (passkey.Ceremony, error)
+	LoginWithPasskey(ctx context.Context, data authservice.LoginWithPasskeyData) authservice.LoginResult
+	BeginPasskeyRegistration(ctx context.Context) (passkey.Ceremony, error)
+	FinishPasskeyRegistration(ctx context.Context, data authservice.PasskeyCeremonyData) error
 	CreateSession(ctx context.Context, userID pgtype.UUID, userDevice string) (authservice.AuthData, error)
 	Logout(ctx context.Context, data authservice.LogoutData) error
 	AllowRefreshingJWT(ctx context.Context, currentSession session.Session) error
 	DeactivateSession(ctx context.Context, sessionID pgtype.UUID) error
 	ConfirmEmail(ctx context.Context, token string) error
-	ResetPassword(ctx context.Context, token string) authservice.ResetPasswordResult
+	ResetPassword(ctx context.Context, data authservice.ResetPasswordData) authservice.ResetPasswordResult
 	Register(ctx context.Context, data authservice.RegisterData) authservice.RegisterResult
 	IsDeviceNewWhenUserHadSessionsBefore(ctx context.Context, currentSession session.Session) (bool, error)
 	AddEmailConfirmationToken(ctx context.Context, email string) (string, error)
 	AddPasswordResetToken(ctx context.Context, email string) (string, error)
+	AddEmailLoginCode(ctx context.Context, email string) (usr.LoginCode, error)
 	CheckIfUserExists(ctx context.Context, emailAddress string) error
 	IsConfirmed(ctx context.Context, email string) (bool, error)
 	LoginWithOAuth(ctx context.Context, data authservice.LoginWithOAuthData, option authservice.OAuthOption) authservice.LoginResult
 	Status(ctx context.Context) error
 	WithGoogle() authservice.OAuthOption
 	WithApple() authservice.OAuthOption
+	WithOIDC(providerName string) authservice.OAuthOption
 }
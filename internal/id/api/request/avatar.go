@@ -0,0 +1,117 @@
+package request
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/zhuboris/never-expires/internal/id/api/response"
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/shared/httpmux"
+)
+
+type avatarResult struct {
+	avatar usr.Avatar
+	err    error
+}
+
+type GetAvatarRequest struct {
+	authService AuthService
+}
+
+func NewGetAvatarRequest(authService AuthService) *GetAvatarRequest {
+	return &GetAvatarRequest{
+		authService: authService,
+	}
+}
+
+func (req GetAvatarRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	var (
+		ctx     = r.Context()
+		handler = func() avatarResult {
+			avatar, err := req.authService.Avatar(ctx)
+			return avatarResult{avatar, err}
+		}
+	)
+
+	result, ctxError := httpmux.HandleWithTimeout(ctx, handler)
+	if result.err != nil || ctxError != nil {
+		return errors.Join(result.err, ctxError)
+	}
+
+	w.Header().Set("Content-Type", result.avatar.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(result.avatar.Data)))
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	_, err := w.Write(result.avatar.Data)
+	return err
+}
+
+type UploadAvatarRequest struct {
+	authService AuthService
+}
+
+func NewUploadAvatarRequest(authService AuthService) *UploadAvatarRequest {
+	return &UploadAvatarRequest{
+		authService: authService,
+	}
+}
+
+// Handle expects image itself as request body, reading stops right after the size limit is exceeded.
+func (req UploadAvatarRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, usr.MaxAvatarSize))
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		return errors.Join(usr.ErrAvatarTooLarge, err)
+	}
+
+	if err != nil {
+		return errors.Join(ErrInvalidBody, err)
+	}
+
+	if len(data) == 0 {
+		return ErrMissingRequiredField
+	}
+
+	var (
+		ctx     = r.Context()
+		handler = func() error {
+			return req.authService.UpdateAvatar(ctx, data)
+		}
+	)
+
+	if err := httpmux.HandleErrorFuncWithTimeout(ctx, handler); err != nil {
+		return err
+	}
+
+	response.WriteMessage(w, http.StatusOK, "avatar updated")
+	return nil
+}
+
+type DeleteAvatarRequest struct {
+	authService AuthService
+}
+
+func NewDeleteAvatarRequest(authService AuthService) *DeleteAvatarRequest {
+	return &DeleteAvatarRequest{
+		authService: authService,
+	}
+}
+
+func (req DeleteAvatarRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	var (
+		ctx     = r.Context()
+		handler = func() error {
+			return req.authService.DeleteAvatar(ctx)
+		}
+	)
+
+	if err := httpmux.HandleErrorFuncWithTimeout(ctx, handler); err != nil {
+		return err
+	}
+
+	response.WriteMessage(w, http.StatusOK, "avatar deleted")
+	return nil
+}
@@ -1,12 +1,13 @@
 package request
 
 import (
-	"context"
 	"errors"
 	"net/http"
 
+	"github.com/zhuboris/never-expires/internal/id/api/endpoint"
 	"github.com/zhuboris/never-expires/internal/id/api/response"
 	"github.com/zhuboris/never-expires/internal/id/authservice"
+	"github.com/zhuboris/never-expires/internal/id/usr"
 	"github.com/zhuboris/never-expires/internal/shared/httpmux"
 	"github.com/zhuboris/never-expires/internal/shared/reqbody"
 )
@@ -27,34 +28,39 @@ func (req ChangeMailRequest) Handle(w http.ResponseWriter, r *http.Request) erro
 		return errors.Join(ErrInvalidBody, err)
 	}
 
-	if input.IsMissingRequiredField() {
-		return ErrMissingRequiredField
-	}
-
 	var (
 		ctx     = r.Context()
-		handler = func() error {
+		handler = func() authservice.EmailChangeResult {
 			return req.authService.UpdateMail(ctx, *input)
 		}
 	)
 
-	if err := httpmux.HandleErrorFuncWithTimeout(ctx, handler); err != nil {
+	result, ctxError := httpmux.HandleWithTimeout(ctx, handler)
+	if err := errors.Join(result.Error(), ctxError); err != nil {
 		return err
 	}
 
-	response.WriteMessage(w, http.StatusOK, "email changed")
-	
-	return req.sendNotificationEmail(ctx, r, input.NewEmail)
+	response.WriteMessage(w, http.StatusAccepted, "confirmation is sent to the new email")
+	return req.sendEmails(r, result.Change())
 }
 
-func (req ChangeMailRequest) sendNotificationEmail(ctx context.Context, r *http.Request, sendTo string) error {
-	url, err := confirmEmailURL(ctx, r, req.authService, sendTo)
+func (req ChangeMailRequest) sendEmails(r *http.Request, change usr.EmailChange) error {
+	confirmURL, err := urlWithToken(r, endpoint.ConfirmEmailChange, change.ConfirmToken.Value)
 	if err != nil {
 		return err
 	}
 
-	sendingCtx, cancel := ctxWithTimeoutToSendMail()
-	msg := emailSender.confirmEmailOnChangeMessage(sendTo, url)
-	go emailSender.addToQueue(sendingCtx, cancel, r, sendTo, msg)
+	revertURL, err := urlWithToken(r, endpoint.RevertEmailChange, change.RevertToken.Value)
+	if err != nil {
+		return err
+	}
+
+	sendingCtx, cancel := ctxWithTimeoutToSendMail(r)
+	msg := emailSender.confirmEmailOnChangeMessage(change.NewEmail, confirmURL)
+	go emailSender.addToQueue(sendingCtx, cancel, r, change.NewEmail, msg)
+
+	sendingCtx, cancel = ctxWithTimeoutToSendMail(r)
+	msg = emailSender.emailChangeRequestedMessage(change.OldEmail, change.NewEmail, revertURL, change.RevertToken.ExpirationTime)
+	go emailSender.addToQueue(sendingCtx, cancel, r, change.OldEmail, msg)
 	return nil
 }
@@ -35,10 +35,6 @@ func (req ChangePasswordRequest) Handle(w http.ResponseWriter, r *http.Request)
 		return errors.Join(ErrInvalidBody, err)
 	}
 
-	if input.IsMissingRequiredField() {
-		return ErrMissingRequiredField
-	}
-
 	var (
 		ctx             = r.Context()
 		changePwHandler = func() error {
@@ -96,7 +92,7 @@ func (req ChangePasswordRequest) notifyAboutChangeWithEmail(ctx context.Context,
 		return err
 	}
 
-	sendingCtx, cancel := ctxWithTimeoutToSendMail()
+	sendingCtx, cancel := ctxWithTimeoutToSendMail(r)
 	msg := emailSender.passwordIsChangedMessage(sendTo)
 
 	go emailSender.addToQueue(sendingCtx, cancel, r, sendTo, msg)
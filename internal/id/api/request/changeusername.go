@@ -26,10 +26,6 @@ func (req ChangeUsernameRequest) Handle(w http.ResponseWriter, r *http.Request)
 		return errors.Join(ErrInvalidBody, err)
 	}
 
-	if input.IsMissingRequiredField() {
-		return ErrMissingRequiredField
-	}
-
 	var (
 		ctx     = r.Context()
 		handler = func() error {
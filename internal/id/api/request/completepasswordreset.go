@@ -0,0 +1,56 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zhuboris/never-expires/internal/id/api/response"
+	"github.com/zhuboris/never-expires/internal/id/authservice"
+	"github.com/zhuboris/never-expires/internal/shared/httpmux"
+	"github.com/zhuboris/never-expires/internal/shared/reqbody"
+)
+
+type CompletePasswordResetRequest struct {
+	authService AuthService
+}
+
+func NewCompletePasswordResetRequest(authService AuthService) *CompletePasswordResetRequest {
+	return &CompletePasswordResetRequest{
+		authService: authService,
+	}
+}
+
+func (req CompletePasswordResetRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	input := new(authservice.ResetPasswordData)
+	if err := reqbody.Decode(input, r.Body); err != nil {
+		return errors.Join(ErrInvalidBody, err)
+	}
+
+	var (
+		ctx     = r.Context()
+		handler = func() authservice.ResetPasswordResult {
+			return req.authService.ResetPassword(ctx, *input)
+		}
+	)
+
+	result, ctxError := httpmux.HandleWithTimeout(ctx, handler)
+	if err := result.Error(); err != nil || ctxError != nil {
+		return errors.Join(err, ctxError)
+	}
+
+	domain := "." + httpmux.RemoveSubdomain(r.Host)
+	deleteAllAuthCookies(domain, w)
+
+	response.WriteMessage(w, http.StatusOK, "password is changed")
+	req.notifyAboutChangeWithEmail(r, result.Address())
+	return nil
+}
+
+func (req CompletePasswordResetRequest) notifyAboutChangeWithEmail(r *http.Request, sendTo string) {
+	var (
+		sendingCtx, cancel = ctxWithTimeoutToSendMail(r)
+		msg                = emailSender.passwordIsChangedMessage(sendTo)
+	)
+
+	go emailSender.addToQueue(sendingCtx, cancel, r, sendTo, msg)
+}
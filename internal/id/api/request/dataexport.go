@@ -0,0 +1,85 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/zhuboris/never-expires/internal/id/api/response"
+	"github.com/zhuboris/never-expires/internal/shared/httpmux"
+)
+
+type dataExportResult struct {
+	archive []byte
+	err     error
+}
+
+type RequestDataExportRequest struct {
+	authService AuthService
+}
+
+func NewRequestDataExportRequest(authService AuthService) *RequestDataExportRequest {
+	return &RequestDataExportRequest{
+		authService: authService,
+	}
+}
+
+func (req RequestDataExportRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	var (
+		ctx      = r.Context()
+		language = emailSender.emailResponseLanguage(r)
+		handler  = func() error {
+			return req.authService.RequestDataExport(ctx, string(language))
+		}
+	)
+
+	if err := httpmux.HandleErrorFuncWithTimeout(ctx, handler); err != nil {
+		return err
+	}
+
+	response.WriteMessage(w, http.StatusAccepted, "data export is requested, download link will be sent by email")
+	return nil
+}
+
+type DownloadDataExportRequest struct {
+	authService AuthService
+}
+
+func NewDownloadDataExportRequest(authService AuthService) *DownloadDataExportRequest {
+	return &DownloadDataExportRequest{
+		authService: authService,
+	}
+}
+
+func (req DownloadDataExportRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	const archiveName = "never-expires-data.zip"
+
+	token := r.URL.
+		Query().
+		Get(tokenQueryName)
+
+	if token == "" {
+		return ErrMissingRequiredField
+	}
+
+	var (
+		ctx     = r.Context()
+		handler = func() dataExportResult {
+			archive, err := req.authService.DataExportArchive(ctx, token)
+			return dataExportResult{archive, err}
+		}
+	)
+
+	result, ctxError := httpmux.HandleWithTimeout(ctx, handler)
+	if result.err != nil || ctxError != nil {
+		return errors.Join(result.err, ctxError)
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+archiveName+`"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(result.archive)))
+	w.WriteHeader(http.StatusOK)
+
+	_, err := w.Write(result.archive)
+	return err
+}
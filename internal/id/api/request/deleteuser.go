@@ -1,8 +1,12 @@
 package request
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/zhuboris/never-expires/internal/id/api/endpoint"
+	"github.com/zhuboris/never-expires/internal/id/authservice"
+	"github.com/zhuboris/never-expires/internal/id/usr"
 	"github.com/zhuboris/never-expires/internal/shared/httpmux"
 )
 
@@ -19,12 +23,13 @@ func NewDeleteUserRequest(authService AuthService) *DeleteUserRequest {
 func (req DeleteUserRequest) Handle(w http.ResponseWriter, r *http.Request) error {
 	var (
 		ctx     = r.Context()
-		handler = func() error {
+		handler = func() authservice.DeleteUserResult {
 			return req.authService.DeleteUser(ctx)
 		}
 	)
 
-	if err := httpmux.HandleErrorFuncWithTimeout(ctx, handler); err != nil {
+	result, ctxError := httpmux.HandleWithTimeout(ctx, handler)
+	if err := errors.Join(result.Error(), ctxError); err != nil {
 		return err
 	}
 
@@ -32,5 +37,21 @@ func (req DeleteUserRequest) Handle(w http.ResponseWriter, r *http.Request) erro
 	deleteAllAuthCookies(domain, w)
 
 	w.WriteHeader(http.StatusNoContent)
+	return req.sendEmail(r, result.Deletion())
+}
+
+func (req DeleteUserRequest) sendEmail(r *http.Request, deletion usr.ScheduledDeletion) error {
+	if deletion.Email == "" {
+		return nil
+	}
+
+	url, err := urlWithToken(r, endpoint.RestoreUser, deletion.RestoreToken.Value)
+	if err != nil {
+		return err
+	}
+
+	sendingCtx, cancel := ctxWithTimeoutToSendMail(r)
+	msg := emailSender.accountDeletionMessage(deletion.Email, url, deletion.DeleteAfter())
+	go emailSender.addToQueue(sendingCtx, cancel, r, deletion.Email, msg)
 	return nil
 }
@@ -0,0 +1,112 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+
+	"github.com/zhuboris/never-expires/internal/id/authservice"
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/shared/eventbus"
+	"github.com/zhuboris/never-expires/internal/shared/httpmux"
+)
+
+type emailChangeAction func(ctx context.Context, token string) authservice.AppliedEmailChangeResult
+
+type ConfirmEmailChangeRequest struct {
+	authService AuthService
+}
+
+func NewConfirmEmailChangeRequest(authService AuthService) *ConfirmEmailChangeRequest {
+	return &ConfirmEmailChangeRequest{
+		authService: authService,
+	}
+}
+
+func (req ConfirmEmailChangeRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	const confirmed = "confirmed"
+
+	return handleEmailChangeLink(w, r, req.authService.ConfirmEmailChange, confirmed)
+}
+
+type RevertEmailChangeRequest struct {
+	authService AuthService
+}
+
+func NewRevertEmailChangeRequest(authService AuthService) *RevertEmailChangeRequest {
+	return &RevertEmailChangeRequest{
+		authService: authService,
+	}
+}
+
+func (req RevertEmailChangeRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	const reverted = "reverted"
+
+	return handleEmailChangeLink(w, r, req.authService.RevertEmailChange, reverted)
+}
+
+func handleEmailChangeLink(w http.ResponseWriter, r *http.Request, action emailChangeAction, successStatus string) error {
+	token := r.URL.
+		Query().
+		Get(tokenQueryName)
+
+	var (
+		ctx     = r.Context()
+		handler = func() authservice.AppliedEmailChangeResult {
+			return action(ctx, token)
+		}
+	)
+
+	result, ctxError := httpmux.HandleWithTimeout(ctx, handler)
+	changeError := errors.Join(result.Error(), ctxError)
+	if changeError == nil {
+		publishEmailChanged(r.Context(), result.Change())
+	}
+
+	redirectionErr := redirectToEmailChangeStatusPage(w, r, changeError, successStatus)
+	return errors.Join(changeError, redirectionErr)
+}
+
+func publishEmailChanged(ctx context.Context, change usr.AppliedEmailChange) {
+	if !change.IsSwitched {
+		return
+	}
+
+	event := eventbus.UserEmailChanged{
+		UserID: uuid.UUID(change.UserID.Bytes).String(),
+		Email:  change.Email,
+	}
+
+	go eventSender.publish(ctx, event)
+}
+
+func redirectToEmailChangeStatusPage(w http.ResponseWriter, r *http.Request, changeError error, successStatus string) error {
+	const (
+		route          = "/email-change-status"
+		statusQueryKey = "status"
+		failure        = "failure"
+	)
+
+	status := successStatus
+	switch {
+	case errors.Is(changeError, usr.ErrEmailChangeRefused):
+		status = failure
+	case changeError != nil:
+		return changeError
+	}
+
+	redirectURL, err := url.Parse("https://" + httpmux.RemoveSubdomain(r.Host) + route)
+	if err != nil {
+		return err
+	}
+
+	query := redirectURL.Query()
+	query.Set(statusQueryKey, status)
+	redirectURL.RawQuery = query.Encode()
+
+	http.Redirect(w, r, redirectURL.String(), http.StatusFound)
+	return nil
+}
@@ -3,6 +3,7 @@ package request
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -18,9 +19,14 @@ type (
 		ConfirmEmailOnChange(recipient, url string, language lang.Language) ([]byte, error)
 		ResetPassword(recipient, url string, language lang.Language) ([]byte, error)
 		PasswordIsChanged(recipient string, language lang.Language) ([]byte, error)
+		AccountLocked(recipient string, language lang.Language) ([]byte, error)
+		AccountDeletionScheduled(recipient, url string, deleteAfter time.Time, language lang.Language) ([]byte, error)
+		EmailChangeRequested(recipient, newEmail, url string, revertUntil time.Time, language lang.Language) ([]byte, error)
 		OAuthAccountConnected(recipient string, language lang.Language, connectionType oauth.Type) ([]byte, error)
-		NewPassword(recipient, password string, language lang.Language) ([]byte, error)
+		ExternalAccountConnected(recipient string, language lang.Language, providerName string) ([]byte, error)
+		ExternalAccountDisconnected(recipient string, language lang.Language, providerName string) ([]byte, error)
 		NewDeviceLogin(recipient string, data mailbuilder.NotificationData, language lang.Language) ([]byte, error)
+		LoginCode(recipient, code, url string, language lang.Language) ([]byte, error)
 		Register(recipient string, language lang.Language, option mailbuilder.RegisterTemplateOption) ([]byte, error)
 		WithConfirmationButton(buttonURL string) mailbuilder.RegisterTemplateOption
 		WithoutConfirmationButton() mailbuilder.RegisterTemplateOption
@@ -80,15 +86,27 @@ func (s EmailSender) resetPasswordMessage(recipient, url string) messageFunc {
 	}
 }
 
-func (s EmailSender) newPasswordMessage(recipient, password string) messageFunc {
+func (s EmailSender) passwordIsChangedMessage(recipient string) messageFunc {
 	return func(language lang.Language) ([]byte, error) {
-		return s.messages.NewPassword(recipient, password, language)
+		return s.messages.PasswordIsChanged(recipient, language)
 	}
 }
 
-func (s EmailSender) passwordIsChangedMessage(recipient string) messageFunc {
+func (s EmailSender) accountLockedMessage(recipient string) messageFunc {
 	return func(language lang.Language) ([]byte, error) {
-		return s.messages.PasswordIsChanged(recipient, language)
+		return s.messages.AccountLocked(recipient, language)
+	}
+}
+
+func (s EmailSender) accountDeletionMessage(recipient, url string, deleteAfter time.Time) messageFunc {
+	return func(language lang.Language) ([]byte, error) {
+		return s.messages.AccountDeletionScheduled(recipient, url, deleteAfter, language)
+	}
+}
+
+func (s EmailSender) emailChangeRequestedMessage(recipient, newEmail, url string, revertUntil time.Time) messageFunc {
+	return func(language lang.Language) ([]byte, error) {
+		return s.messages.EmailChangeRequested(recipient, newEmail, url, revertUntil, language)
 	}
 }
 
@@ -98,12 +116,30 @@ func (s EmailSender) oAuthConnectionMessage(recipient string, connectionType oau
 	}
 }
 
+func (s EmailSender) externalAccountConnectionMessage(recipient, providerName string) messageFunc {
+	return func(language lang.Language) ([]byte, error) {
+		return s.messages.ExternalAccountConnected(recipient, language, providerName)
+	}
+}
+
+func (s EmailSender) externalAccountDisconnectionMessage(recipient, providerName string) messageFunc {
+	return func(language lang.Language) ([]byte, error) {
+		return s.messages.ExternalAccountDisconnected(recipient, language, providerName)
+	}
+}
+
 func (s EmailSender) newDeviceLoginMessage(recipient string, data mailbuilder.NotificationData) messageFunc {
 	return func(language lang.Language) ([]byte, error) {
 		return s.messages.NewDeviceLogin(recipient, data, language)
 	}
 }
 
+func (s EmailSender) loginCodeMessage(recipient, code, url string) messageFunc {
+	return func(language lang.Language) ([]byte, error) {
+		return s.messages.LoginCode(recipient, code, url, language)
+	}
+}
+
 func (s EmailSender) emailResponseLanguage(r *http.Request) lang.Language {
 	const headerWithLocaleName = "Accept-Language"
 
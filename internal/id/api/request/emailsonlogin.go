@@ -3,9 +3,7 @@ package request
 import (
 	"context"
 	"errors"
-	"net"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/mileusna/useragent"
@@ -14,6 +12,7 @@ import (
 	"github.com/zhuboris/never-expires/internal/id/session"
 	"github.com/zhuboris/never-expires/internal/id/usr"
 	"github.com/zhuboris/never-expires/internal/id/usr/oauth"
+	"github.com/zhuboris/never-expires/internal/shared/httpmux"
 )
 
 type successLoginData struct {
@@ -24,21 +23,21 @@ type successLoginData struct {
 	service    AuthService
 }
 
-func sendEmailOnOAuthLogin(resultType oauth.LoginResultType, loginInfo successLoginData, oAuthType oauth.Type) error {
+func sendEmailOnOAuthLogin(resultType oauth.LoginResultType, loginInfo successLoginData, connectionMsg messageFunc) error {
 	switch resultType {
 	case oauth.Register:
 		return sendRegisterWithOAuthNotification(loginInfo)
 	case oauth.Login:
 		return sendNewDeviceNotifyIfNeeded(loginInfo)
 	case oauth.Connect:
-		return sendOAuthConnectionNotification(loginInfo, oAuthType)
+		return sendOAuthConnectionNotification(loginInfo, connectionMsg)
 	default:
 		return nil
 	}
 }
 
 func sendRegisterWithOAuthNotification(loginData successLoginData) error {
-	sendingCtx, cancel := ctxWithTimeoutToSendMail()
+	sendingCtx, cancel := ctxWithTimeoutToSendMail(loginData.request)
 
 	option, err := oAuthRegisterTemplateOption(sendingCtx, loginData)
 	if err != nil {
@@ -52,7 +51,7 @@ func sendRegisterWithOAuthNotification(loginData successLoginData) error {
 }
 
 func sendNewDeviceNotifyIfNeeded(info successLoginData) error {
-	sendingCtx, cancel := ctxWithTimeoutToSendMail()
+	sendingCtx, cancel := ctxWithTimeoutToSendMail(info.request)
 
 	if needToNotify, err := needToNotifyAboutLoginFromNewDevice(sendingCtx, info, info.service); !needToNotify || err != nil {
 		cancel()
@@ -61,7 +60,7 @@ func sendNewDeviceNotifyIfNeeded(info successLoginData) error {
 
 	var (
 		userAgent    = useragent.Parse(info.request.UserAgent())
-		notification = mailbuilder.NewNotificationData(userAgent, tryFindIP(info.request), info.loginTime)
+		notification = mailbuilder.NewNotificationData(userAgent, httpmux.ClientIP(info.request), info.loginTime)
 		msg          = emailSender.newDeviceLoginMessage(info.user.Email, notification)
 	)
 
@@ -69,10 +68,9 @@ func sendNewDeviceNotifyIfNeeded(info successLoginData) error {
 	return nil
 }
 
-func sendOAuthConnectionNotification(loginData successLoginData, oAuthType oauth.Type) error {
-	sendingCtx, cancel := ctxWithTimeoutToSendMail()
+func sendOAuthConnectionNotification(loginData successLoginData, msg messageFunc) error {
+	sendingCtx, cancel := ctxWithTimeoutToSendMail(loginData.request)
 
-	msg := emailSender.oAuthConnectionMessage(loginData.user.Email, oAuthType)
 	go emailSender.addToQueue(sendingCtx, cancel, loginData.request, loginData.user.Email, msg)
 	return nil
 }
@@ -108,25 +106,7 @@ func needToNotifyAboutLoginFromNewDevice(ctx context.Context, info successLoginD
 	return true, nil
 }
 
-func tryFindIP(r *http.Request) string {
-	if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
-		return realIP
-	}
-
-	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
-		ips := strings.Split(forwarded, ",")
-		return strings.TrimSpace(ips[0])
-	}
-
-	ip, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		return ""
-	}
-
-	parsedIP := net.ParseIP(ip)
-	if parsedIP == nil {
-		return ""
-	}
-
-	return parsedIP.String()
+func notifyAboutLockout(r *http.Request, email string) {
+	sendingCtx, cancel := ctxWithTimeoutToSendMail(r)
+	go emailSender.addToQueue(sendingCtx, cancel, r, email, emailSender.accountLockedMessage(email))
 }
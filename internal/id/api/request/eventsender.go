@@ -0,0 +1,54 @@
+package request
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/zhuboris/never-expires/internal/shared/eventbus"
+)
+
+type domainEventPublisher interface {
+	Publish(ctx context.Context, event eventbus.Event) error
+}
+
+var eventSenderOnce sync.Once
+var eventSender *EventSender
+
+func InitEventSender(publisher domainEventPublisher, logger *zap.Logger) {
+	eventSenderOnce.Do(func() {
+		eventSender = &EventSender{
+			publisher: publisher,
+			logger:    logger,
+		}
+	})
+}
+
+type EventSender struct {
+	publisher domainEventPublisher
+	logger    *zap.Logger
+}
+
+func (s EventSender) publish(ctx context.Context, event eventbus.Event) {
+	const publishingTimeoutValue = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), publishingTimeoutValue)
+	defer cancel()
+
+	err := s.publisher.Publish(ctx, event)
+	s.logPublishing(event, err)
+}
+
+func (s EventSender) logPublishing(event eventbus.Event, err error) {
+	msg := "Event published"
+	logLvl := zapcore.InfoLevel
+	if err != nil {
+		msg = "Failed to publish event"
+		logLvl = zapcore.ErrorLevel
+	}
+
+	s.logger.Log(logLvl, msg, zap.String("eventType", event.EventType()), zap.Error(err))
+}
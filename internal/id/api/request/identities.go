@@ -0,0 +1,174 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/zhuboris/never-expires/internal/id/api/endpoint"
+	"github.com/zhuboris/never-expires/internal/id/api/response"
+	"github.com/zhuboris/never-expires/internal/id/authservice"
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/id/usr/oauth"
+	"github.com/zhuboris/never-expires/internal/shared/httpmux"
+	"github.com/zhuboris/never-expires/internal/shared/reqbody"
+)
+
+type (
+	identitiesResult struct {
+		identities []usr.ExternalIdentity
+		err        error
+	}
+	identitiesResponse struct {
+		Identities []usr.ExternalIdentity `json:"identities"`
+	}
+)
+
+type GetIdentitiesRequest struct {
+	authService AuthService
+}
+
+func NewGetIdentitiesRequest(authService AuthService) *GetIdentitiesRequest {
+	return &GetIdentitiesRequest{
+		authService: authService,
+	}
+}
+
+func (req GetIdentitiesRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	var (
+		ctx     = r.Context()
+		handler = func() identitiesResult {
+			identities, err := req.authService.ExternalIdentities(ctx)
+			return identitiesResult{identities, err}
+		}
+	)
+
+	result, ctxError := httpmux.HandleWithTimeout(ctx, handler)
+	if result.err != nil || ctxError != nil {
+		return errors.Join(result.err, ctxError)
+	}
+
+	return response.WriteJSONData(w, http.StatusOK, identitiesResponse{result.identities})
+}
+
+type LinkIdentityRequest struct {
+	authService AuthService
+}
+
+func NewLinkIdentityRequest(authService AuthService) *LinkIdentityRequest {
+	return &LinkIdentityRequest{
+		authService: authService,
+	}
+}
+
+func (req LinkIdentityRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	provider, err := identityProviderFromPath(r.URL.Path)
+	if err != nil {
+		return err
+	}
+
+	input := authservice.NewLinkIdentityData(provider)
+	if err := reqbody.Decode(&input, r.Body); err != nil {
+		return errors.Join(ErrInvalidBody, err)
+	}
+
+	var (
+		ctx     = r.Context()
+		handler = func() error {
+			return req.authService.LinkExternalIdentity(ctx, input)
+		}
+	)
+
+	if err := httpmux.HandleErrorFuncWithTimeout(ctx, handler); err != nil {
+		return err
+	}
+
+	response.WriteMessage(w, http.StatusCreated, "external account linked")
+	return notifyAboutIdentityChange(ctx, r, req.authService, func(recipient string) messageFunc {
+		return identityConnectionMessage(req.authService, recipient, provider)
+	})
+}
+
+type UnlinkIdentityRequest struct {
+	authService AuthService
+}
+
+func NewUnlinkIdentityRequest(authService AuthService) *UnlinkIdentityRequest {
+	return &UnlinkIdentityRequest{
+		authService: authService,
+	}
+}
+
+func (req UnlinkIdentityRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	provider, err := identityProviderFromPath(r.URL.Path)
+	if err != nil {
+		return err
+	}
+
+	var (
+		ctx     = r.Context()
+		handler = func() error {
+			return req.authService.UnlinkExternalIdentity(ctx, provider)
+		}
+	)
+
+	if err := httpmux.HandleErrorFuncWithTimeout(ctx, handler); err != nil {
+		return err
+	}
+
+	response.WriteMessage(w, http.StatusOK, "external account unlinked")
+	return notifyAboutIdentityChange(ctx, r, req.authService, func(recipient string) messageFunc {
+		return emailSender.externalAccountDisconnectionMessage(recipient, providerDisplayName(req.authService, provider))
+	})
+}
+
+func identityProviderFromPath(path string) (string, error) {
+	provider := strings.TrimPrefix(path, endpoint.UserIdentitiesWithParam)
+	if provider == "" {
+		return "", ErrMissingRequiredField
+	}
+
+	return provider, nil
+}
+
+func notifyAboutIdentityChange(ctx context.Context, r *http.Request, authService AuthService, makeMessage func(recipient string) messageFunc) error {
+	getUserResult := authService.AuthorizedUser(ctx)
+	if err := getUserResult.Error(); err != nil {
+		return err
+	}
+
+	sendTo := getUserResult.UserData().Email
+	sendingCtx, cancel := ctxWithTimeoutToSendMail(r)
+
+	go emailSender.addToQueue(sendingCtx, cancel, r, sendTo, makeMessage(sendTo))
+	return nil
+}
+
+func identityConnectionMessage(authService AuthService, recipient, provider string) messageFunc {
+	switch provider {
+	case oauth.GoogleProviderName:
+		return emailSender.oAuthConnectionMessage(recipient, oauth.GoogleAccount)
+	case oauth.AppleProviderName:
+		return emailSender.oAuthConnectionMessage(recipient, oauth.AppleID)
+	default:
+		return emailSender.externalAccountConnectionMessage(recipient, providerDisplayName(authService, provider))
+	}
+}
+
+func providerDisplayName(authService AuthService, provider string) string {
+	switch provider {
+	case oauth.GoogleProviderName:
+		return "Google"
+	case oauth.AppleProviderName:
+		return "Apple"
+	}
+
+	for _, info := range authService.OIDCProviders() {
+		if info.Name == provider {
+			return info.DisplayName
+		}
+	}
+
+	return provider
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/zhuboris/never-expires/internal/id/api/request/device"
 	"github.com/zhuboris/never-expires/internal/id/authservice"
+	"github.com/zhuboris/never-expires/internal/shared/httpmux"
 	"github.com/zhuboris/never-expires/internal/shared/reqbody"
 )
 
@@ -32,17 +33,13 @@ func (req LoginRequest) Handle(w http.ResponseWriter, r *http.Request) error {
 	var (
 		startTime  = time.Now()
 		deviceInfo = device.Info(r)
-		input      = authservice.NewLoginData(deviceInfo)
+		input      = authservice.NewLoginData(deviceInfo, httpmux.ClientIP(r))
 	)
 
 	if err := reqbody.Decode(&input, r.Body); err != nil {
 		return errors.Join(ErrInvalidBody, err)
 	}
 
-	if input.IsMissingRequiredField() {
-		return ErrMissingRequiredField
-	}
-
 	var (
 		ctx     = r.Context()
 		handler = func() authservice.LoginResult {
@@ -51,6 +48,10 @@ func (req LoginRequest) Handle(w http.ResponseWriter, r *http.Request) error {
 	)
 
 	result, err := handleLogin(ctx, handler, req.authService, w, r)
+	if errors.Is(err, authservice.ErrExistingAccountLockedOut) {
+		notifyAboutLockout(r, input.Email)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -57,5 +57,6 @@ func (req LoginWithAppleRequest) Handle(w http.ResponseWriter, r *http.Request)
 		service:    req.authService,
 	}
 
-	return sendEmailOnOAuthLogin(result.OAuthResultType(), loginData, oauth.AppleID)
+	connectionMsg := emailSender.oAuthConnectionMessage(loginData.user.Email, oauth.AppleID)
+	return sendEmailOnOAuthLogin(result.OAuthResultType(), loginData, connectionMsg)
 }
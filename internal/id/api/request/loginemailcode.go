@@ -0,0 +1,62 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/zhuboris/never-expires/internal/id/api/request/device"
+	"github.com/zhuboris/never-expires/internal/id/authservice"
+	"github.com/zhuboris/never-expires/internal/shared/reqbody"
+)
+
+type LoginWithEmailCodeRequest struct {
+	authService AuthService
+}
+
+func NewLoginWithEmailCodeRequest(authService AuthService) *LoginWithEmailCodeRequest {
+	return &LoginWithEmailCodeRequest{
+		authService: authService,
+	}
+}
+
+func (req LoginWithEmailCodeRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	var (
+		startTime = time.Now()
+		input     = authservice.NewLoginWithEmailCodeData(device.Info(r))
+	)
+
+	if err := req.decodeInput(&input, r); err != nil {
+		return err
+	}
+
+	var (
+		ctx     = r.Context()
+		handler = func() authservice.LoginResult {
+			return req.authService.LoginWithEmailCode(ctx, input)
+		}
+	)
+
+	result, err := handleLogin(ctx, handler, req.authService, w, r)
+	if err != nil {
+		return err
+	}
+
+	loginData := successLoginData{
+		user:       result.UserData(),
+		newSession: result.AuthData().Session(),
+		loginTime:  startTime,
+		request:    r,
+		service:    req.authService,
+	}
+
+	return sendNewDeviceNotifyIfNeeded(loginData)
+}
+
+func (req LoginWithEmailCodeRequest) decodeInput(input *authservice.LoginWithEmailCodeData, r *http.Request) error {
+	if err := reqbody.Decode(input, r.Body); err != nil {
+		return errors.Join(ErrInvalidBody, err)
+	}
+
+	return nil
+}
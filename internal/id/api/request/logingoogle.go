@@ -57,5 +57,6 @@ func (req LoginWithGoogleRequest) Handle(w http.ResponseWriter, r *http.Request)
 		service:    req.authService,
 	}
 
-	return sendEmailOnOAuthLogin(result.OAuthResultType(), loginData, oauth.GoogleAccount)
+	connectionMsg := emailSender.oAuthConnectionMessage(loginData.user.Email, oauth.GoogleAccount)
+	return sendEmailOnOAuthLogin(result.OAuthResultType(), loginData, connectionMsg)
 }
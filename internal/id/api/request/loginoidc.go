@@ -0,0 +1,81 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zhuboris/never-expires/internal/id/api/endpoint"
+	"github.com/zhuboris/never-expires/internal/id/api/request/device"
+	"github.com/zhuboris/never-expires/internal/id/authservice"
+	"github.com/zhuboris/never-expires/internal/id/usr/oauth"
+	"github.com/zhuboris/never-expires/internal/id/usr/oauth/oidc"
+	"github.com/zhuboris/never-expires/internal/shared/reqbody"
+)
+
+type LoginWithOIDCRequest struct {
+	authService AuthService
+}
+
+func NewLoginWithOIDCRequest(authService AuthService) *LoginWithOIDCRequest {
+	return &LoginWithOIDCRequest{
+		authService: authService,
+	}
+}
+
+func (req LoginWithOIDCRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	startTime := time.Now()
+
+	provider, err := oidcProviderFromPath(req.authService, r.URL.Path, endpoint.LoginOIDCWithParam)
+	if err != nil {
+		return err
+	}
+
+	var body oauth.Token
+	if err := reqbody.Decode(&body, r.Body); err != nil {
+		return errors.Join(ErrInvalidBody, err)
+	}
+
+	if body.IsMissingIDToken() {
+		return ErrMissingRequiredField
+	}
+
+	ctx := r.Context()
+	oidcUser, err := req.authService.UserFromOIDCToken(ctx, provider.Name, body)
+	if err != nil {
+		return err
+	}
+
+	oidcLoginData := authservice.NewLoginWithOAuthData(oidcUser, device.Info(r))
+	handler := func() authservice.LoginResult {
+		return req.authService.LoginWithOAuth(ctx, oidcLoginData, req.authService.WithOIDC(provider.Name))
+	}
+
+	result, err := handleLogin(ctx, handler, req.authService, w, r)
+	if err != nil {
+		return err
+	}
+
+	loginData := successLoginData{
+		user:       result.UserData(),
+		newSession: result.AuthData().Session(),
+		loginTime:  startTime,
+		request:    r,
+		service:    req.authService,
+	}
+
+	connectionMsg := emailSender.externalAccountConnectionMessage(loginData.user.Email, provider.DisplayName)
+	return sendEmailOnOAuthLogin(result.OAuthResultType(), loginData, connectionMsg)
+}
+
+func oidcProviderFromPath(authService AuthService, path, route string) (oidc.ProviderInfo, error) {
+	name := strings.TrimPrefix(path, route)
+	for _, provider := range authService.OIDCProviders() {
+		if provider.Name == name {
+			return provider, nil
+		}
+	}
+
+	return oidc.ProviderInfo{}, oidc.ErrUnknownProvider
+}
@@ -0,0 +1,83 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/zhuboris/never-expires/internal/id/api/request/device"
+	"github.com/zhuboris/never-expires/internal/id/api/response"
+	"github.com/zhuboris/never-expires/internal/id/authservice"
+	"github.com/zhuboris/never-expires/internal/shared/httpmux"
+	"github.com/zhuboris/never-expires/internal/shared/reqbody"
+)
+
+type BeginPasskeyLoginRequest struct {
+	authService AuthService
+}
+
+func NewBeginPasskeyLoginRequest(authService AuthService) *BeginPasskeyLoginRequest {
+	return &BeginPasskeyLoginRequest{
+		authService: authService,
+	}
+}
+
+func (req BeginPasskeyLoginRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	var (
+		ctx     = r.Context()
+		handler = func() ceremonyResult {
+			ceremony, err := req.authService.BeginPasskeyLogin(ctx)
+			return ceremonyResult{ceremony, err}
+		}
+	)
+
+	result, ctxError := httpmux.HandleWithTimeout(ctx, handler)
+	if result.err != nil || ctxError != nil {
+		return errors.Join(result.err, ctxError)
+	}
+
+	return response.WriteJSONData(w, http.StatusOK, result.ceremony)
+}
+
+type LoginWithPasskeyRequest struct {
+	authService AuthService
+}
+
+func NewLoginWithPasskeyRequest(authService AuthService) *LoginWithPasskeyRequest {
+	return &LoginWithPasskeyRequest{
+		authService: authService,
+	}
+}
+
+func (req LoginWithPasskeyRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	var (
+		startTime = time.Now()
+		input     = authservice.NewLoginWithPasskeyData(device.Info(r))
+	)
+
+	if err := reqbody.Decode(&input, r.Body); err != nil {
+		return errors.Join(ErrInvalidBody, err)
+	}
+
+	var (
+		ctx     = r.Context()
+		handler = func() authservice.LoginResult {
+			return req.authService.LoginWithPasskey(ctx, input)
+		}
+	)
+
+	result, err := handleLogin(ctx, handler, req.authService, w, r)
+	if err != nil {
+		return err
+	}
+
+	loginData := successLoginData{
+		user:       result.UserData(),
+		newSession: result.AuthData().Session(),
+		loginTime:  startTime,
+		request:    r,
+		service:    req.authService,
+	}
+
+	return sendNewDeviceNotifyIfNeeded(loginData)
+}
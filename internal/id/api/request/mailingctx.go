@@ -2,11 +2,13 @@ package request
 
 import (
 	"context"
+	"net/http"
 	"time"
 )
 
-func ctxWithTimeoutToSendMail() (context.Context, context.CancelFunc) {
+// ctxWithTimeoutToSendMail is not canceled with the request, but keeps its values, so the email is in the same trace.
+func ctxWithTimeoutToSendMail(r *http.Request) (context.Context, context.CancelFunc) {
 	const sendingTimeoutValue = 2 * time.Minute
 
-	return context.WithTimeout(context.Background(), sendingTimeoutValue)
+	return context.WithTimeout(context.WithoutCancel(r.Context()), sendingTimeoutValue)
 }
@@ -0,0 +1,30 @@
+package request
+
+import (
+	"net/http"
+
+	"github.com/zhuboris/never-expires/internal/id/api/response"
+	"github.com/zhuboris/never-expires/internal/id/usr/oauth/oidc"
+)
+
+type OIDCProvidersRequest struct {
+	authService AuthService
+}
+
+type oidcProvidersResult struct {
+	Providers []oidc.ProviderInfo `json:"providers"`
+}
+
+func NewOIDCProvidersRequest(authService AuthService) *OIDCProvidersRequest {
+	return &OIDCProvidersRequest{
+		authService: authService,
+	}
+}
+
+func (req OIDCProvidersRequest) Handle(w http.ResponseWriter, _ *http.Request) error {
+	result := oidcProvidersResult{
+		Providers: req.authService.OIDCProviders(),
+	}
+
+	return response.WriteJSONData(w, http.StatusOK, result)
+}
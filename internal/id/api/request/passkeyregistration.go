@@ -0,0 +1,75 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zhuboris/never-expires/internal/id/api/response"
+	"github.com/zhuboris/never-expires/internal/id/authservice"
+	"github.com/zhuboris/never-expires/internal/id/passkey"
+	"github.com/zhuboris/never-expires/internal/shared/httpmux"
+	"github.com/zhuboris/never-expires/internal/shared/reqbody"
+)
+
+type ceremonyResult struct {
+	ceremony passkey.Ceremony
+	err      error
+}
+
+type BeginPasskeyRegistrationRequest struct {
+	authService AuthService
+}
+
+func NewBeginPasskeyRegistrationRequest(authService AuthService) *BeginPasskeyRegistrationRequest {
+	return &BeginPasskeyRegistrationRequest{
+		authService: authService,
+	}
+}
+
+func (req BeginPasskeyRegistrationRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	var (
+		ctx     = r.Context()
+		handler = func() ceremonyResult {
+			ceremony, err := req.authService.BeginPasskeyRegistration(ctx)
+			return ceremonyResult{ceremony, err}
+		}
+	)
+
+	result, ctxError := httpmux.HandleWithTimeout(ctx, handler)
+	if result.err != nil || ctxError != nil {
+		return errors.Join(result.err, ctxError)
+	}
+
+	return response.WriteJSONData(w, http.StatusOK, result.ceremony)
+}
+
+type FinishPasskeyRegistrationRequest struct {
+	authService AuthService
+}
+
+func NewFinishPasskeyRegistrationRequest(authService AuthService) *FinishPasskeyRegistrationRequest {
+	return &FinishPasskeyRegistrationRequest{
+		authService: authService,
+	}
+}
+
+func (req FinishPasskeyRegistrationRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	input := new(authservice.PasskeyCeremonyData)
+	if err := reqbody.Decode(input, r.Body); err != nil {
+		return errors.Join(ErrInvalidBody, err)
+	}
+
+	var (
+		ctx     = r.Context()
+		handler = func() error {
+			return req.authService.FinishPasskeyRegistration(ctx, *input)
+		}
+	)
+
+	if err := httpmux.HandleErrorFuncWithTimeout(ctx, handler); err != nil {
+		return err
+	}
+
+	response.WriteMessage(w, http.StatusCreated, "passkey registered")
+	return nil
+}
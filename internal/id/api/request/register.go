@@ -27,10 +27,6 @@ func (req RegisterRequest) Handle(w http.ResponseWriter, r *http.Request) error
 		return errors.Join(ErrInvalidBody, err)
 	}
 
-	if input.IsMissingRequiredField() {
-		return ErrMissingRequiredField
-	}
-
 	var (
 		ctx     = r.Context()
 		handler = func() authservice.RegisterResult {
@@ -59,7 +55,7 @@ func (req RegisterRequest) sendNotifyEmail(ctx context.Context, r *http.Request,
 	}
 
 	var (
-		sendingCtx, cancel = ctxWithTimeoutToSendMail()
+		sendingCtx, cancel = ctxWithTimeoutToSendMail(r)
 		msg                = emailSender.registerMessage(sendTo, emailSender.registerWithConfirmationButton(url))
 	)
 
@@ -0,0 +1,66 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/shared/httpmux"
+)
+
+type RestoreUserRequest struct {
+	authService AuthService
+}
+
+func NewRestoreUserRequest(authService AuthService) *RestoreUserRequest {
+	return &RestoreUserRequest{
+		authService: authService,
+	}
+}
+
+func (req RestoreUserRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	token := r.URL.
+		Query().
+		Get(tokenQueryName)
+
+	var (
+		ctx     = r.Context()
+		handler = func() error {
+			return req.authService.RestoreUser(ctx, token)
+		}
+	)
+
+	restoreError := httpmux.HandleErrorFuncWithTimeout(ctx, handler)
+	redirectionErr := req.redirectToStatusPage(w, r, restoreError)
+	return errors.Join(restoreError, redirectionErr)
+}
+
+func (req RestoreUserRequest) redirectToStatusPage(w http.ResponseWriter, r *http.Request, restoreError error) error {
+	const (
+		route          = "/restoration-status"
+		statusQueryKey = "status"
+		success        = "success"
+		failure        = "failure"
+	)
+
+	status := success
+	switch {
+	case errors.Is(restoreError, usr.ErrRestoreRefused):
+		status = failure
+	case restoreError != nil:
+		return restoreError
+	}
+
+	redirectURL, err := url.Parse("https://" + httpmux.RemoveSubdomain(r.Host) + route)
+	if err != nil {
+		return err
+	}
+
+	query := redirectURL.Query()
+	query.Set(statusQueryKey, status)
+	redirectURL.RawQuery = query.Encode()
+
+	http.Redirect(w, r, redirectURL.String(), http.StatusFound)
+	return nil
+}
@@ -49,7 +49,7 @@ func (req SendConfirmationEmailRequest) sendEmail(ctx context.Context, r *http.R
 		return err
 	}
 
-	sendingCtx, cancel := ctxWithTimeoutToSendMail()
+	sendingCtx, cancel := ctxWithTimeoutToSendMail(r)
 	msg := emailSender.confirmEmailMessage(sendTo, url)
 	go emailSender.addToQueue(sendingCtx, cancel, r, sendTo, msg)
 	return nil
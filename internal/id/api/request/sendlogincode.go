@@ -0,0 +1,77 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zhuboris/never-expires/internal/id/api/response"
+	"github.com/zhuboris/never-expires/internal/id/authservice"
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/shared/httpmux"
+	"github.com/zhuboris/never-expires/internal/shared/reqbody"
+)
+
+type SendLoginCodeRequest struct {
+	authService AuthService
+}
+
+func NewSendLoginCodeRequest(authService AuthService) *SendLoginCodeRequest {
+	return &SendLoginCodeRequest{
+		authService: authService,
+	}
+}
+
+func (req SendLoginCodeRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	input := new(authservice.SendLoginCodeData)
+	if err := reqbody.Decode(input, r.Body); err != nil {
+		return errors.Join(ErrInvalidBody, err)
+	}
+
+	var (
+		ctx     = r.Context()
+		handler = func() loginCodeResult {
+			code, err := req.authService.AddEmailLoginCode(ctx, input.Email)
+			return loginCodeResult{code, err}
+		}
+	)
+
+	result, ctxError := httpmux.HandleWithTimeout(ctx, handler)
+	isUnknownEmail := errors.Is(result.err, authservice.ErrMissingEmailAddress)
+	if (result.err != nil && !isUnknownEmail) || ctxError != nil {
+		return errors.Join(result.err, ctxError)
+	}
+
+	// Unknown email gets the same response as existing one, so the endpoint cannot be used to find registered emails.
+	response.WriteMessage(w, http.StatusAccepted, "request to send email accepted")
+	if isUnknownEmail {
+		return nil
+	}
+
+	return req.sendEmail(r, input.Email, result.code)
+}
+
+func (req SendLoginCodeRequest) sendEmail(r *http.Request, sendTo string, code usr.LoginCode) error {
+	url, err := req.loginLinkURL(r.Host, code.Value)
+	if err != nil {
+		return err
+	}
+
+	sendingCtx, cancel := ctxWithTimeoutToSendMail(r)
+	msg := emailSender.loginCodeMessage(sendTo, code.Code, url)
+	go emailSender.addToQueue(sendingCtx, cancel, r, sendTo, msg)
+	return nil
+}
+
+// loginLinkURL leads to the page on the main domain, so the link is opened by the app as universal link.
+// In a browser the page posts the token itself, opening the link never logs in by GET.
+func (req SendLoginCodeRequest) loginLinkURL(host, token string) (string, error) {
+	const route = "/login-link/"
+
+	urlRaw := "https://" + httpmux.RemoveSubdomain(host) + route
+	return addTokenToURL(urlRaw, token)
+}
+
+type loginCodeResult struct {
+	code usr.LoginCode
+	err  error
+}
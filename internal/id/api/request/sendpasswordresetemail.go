@@ -30,10 +30,6 @@ func (req SendResetPasswordEmailRequest) Handle(w http.ResponseWriter, r *http.R
 		return errors.Join(ErrInvalidBody, err)
 	}
 
-	if input.IsMissingRequiredField() {
-		return ErrMissingRequiredField
-	}
-
 	var (
 		ctx     = r.Context()
 		handler = func() error {
@@ -71,7 +67,7 @@ func (req SendResetPasswordEmailRequest) sendEmail(ctx context.Context, r *http.
 		return err
 	}
 
-	sendingCtx, cancel := ctxWithTimeoutToSendMail()
+	sendingCtx, cancel := ctxWithTimeoutToSendMail(r)
 	msg := emailSender.resetPasswordMessage(sendTo, url)
 	go emailSender.addToQueue(sendingCtx, cancel, r, sendTo, msg)
 	return nil
@@ -0,0 +1,47 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/zhuboris/never-expires/internal/id/api/response"
+	"github.com/zhuboris/never-expires/internal/id/authservice"
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/shared/httpmux"
+	"github.com/zhuboris/never-expires/internal/shared/reqbody"
+)
+
+type UpdateProfileRequest struct {
+	authService AuthService
+}
+
+func NewUpdateProfileRequest(authService AuthService) *UpdateProfileRequest {
+	return &UpdateProfileRequest{
+		authService: authService,
+	}
+}
+
+func (req UpdateProfileRequest) Handle(w http.ResponseWriter, r *http.Request) error {
+	input := new(usr.ProfileUpdate)
+	if err := reqbody.Decode(input, r.Body); err != nil {
+		return errors.Join(ErrInvalidBody, err)
+	}
+
+	if input.IsEmpty() {
+		return ErrMissingRequiredField
+	}
+
+	var (
+		ctx     = r.Context()
+		handler = func() authservice.GettingUserResult {
+			return req.authService.UpdateProfile(ctx, *input)
+		}
+	)
+
+	result, ctxError := httpmux.HandleWithTimeout(ctx, handler)
+	if resultError := result.Error(); resultError != nil || ctxError != nil {
+		return errors.Join(resultError, ctxError)
+	}
+
+	return response.WriteJSONData(w, http.StatusOK, result.UserData())
+}
@@ -5,6 +5,11 @@ import "errors"
 var (
 	ErrWrongLoginData      = errors.New("email or password is incorrect")
 	ErrAlreadyRegistered   = errors.New("email already registered")
+	ErrAccountLockedOut    = errors.New("account is temporarily locked out after too many failed login attempts")
 	ErrMissingEmailAddress = errors.New("email address does not belong to any user")
 	errMissingUserDevice   = errors.New("login data must contain any information about user's device")
 )
+
+// ErrExistingAccountLockedOut is joined to ErrAccountLockedOut only when the email belongs to an account,
+// the response is the same for unknown emails, but only real owners are notified about the lockout.
+var ErrExistingAccountLockedOut = errors.New("existing account is locked out")
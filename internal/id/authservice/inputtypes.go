@@ -1,6 +1,8 @@
 package authservice
 
 import (
+	"encoding/json"
+
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/zhuboris/never-expires/internal/id/usr/oauth"
@@ -8,32 +10,64 @@ import (
 
 type (
 	ChangeMailData struct {
-		NewEmail string `json:"new_email"`
-		Password string `json:"password"`
+		NewEmail string `json:"new_email" validate:"required,email"`
+		Password string `json:"password" validate:"required"`
 	}
 	ChangePasswordData struct {
 		Current string `json:"current_password"`
-		New     string `json:"new_password"`
+		New     string `json:"new_password" validate:"required"`
 	}
 	ChangeUsernameData struct {
-		New string `json:"new_username"`
+		New string `json:"new_username" validate:"required"`
 	}
 	LoginData struct {
-		Email      string `json:"email"`
-		Password   string `json:"password"`
+		Email      string `json:"email" validate:"required"`
+		Password   string `json:"password" validate:"required"`
+		userDevice string
+		userIP     string
+	}
+	SendLoginCodeData struct {
+		Email string `json:"email" validate:"required"`
+	}
+	LoginWithEmailCodeData struct {
+		Email      string `json:"email" validate:"required_without=token"`
+		Code       string `json:"code" validate:"required_without=token"`
+		Token      string `json:"token"`
+		userDevice string
+	}
+	PasskeyCeremonyData struct {
+		CeremonyID string          `json:"ceremony_id" validate:"required"`
+		Credential json.RawMessage `json:"credential" validate:"required"`
+	}
+	LoginWithPasskeyData struct {
+		PasskeyCeremonyData
+
 		userDevice string
 	}
 	LoginWithOAuthData struct {
 		user       oauth.User
 		userDevice string
 	}
+	// LinkIdentityData repeats fields of oauth.Token to require auth code only for Apple, it is exchanged for refresh token of the user.
+	LinkIdentityData struct {
+		AuthCode string `json:"auth_code" validate:"required_if=provider apple"`
+		IDToken  struct {
+			TokenString string `json:"token_string" validate:"required"`
+		} `json:"id_token"`
+
+		provider string
+	}
 	RegisterData struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
+		Email    string `json:"email" validate:"required,email"`
+		Password string `json:"password" validate:"required"`
 		Username string `json:"username"`
 	}
 	SendRestorePasswordEmailData struct {
-		Email string `json:"email"`
+		Email string `json:"email" validate:"required"`
+	}
+	ResetPasswordData struct {
+		Token       string `json:"token" validate:"required"`
+		NewPassword string `json:"new_password" validate:"required"`
 	}
 	RefreshJWTData struct {
 		RefreshToken string `json:"refresh_token"`
@@ -44,44 +78,46 @@ type (
 	}
 )
 
-func NewLoginData(device string) LoginData {
+func NewLoginData(device, ip string) LoginData {
 	return LoginData{
 		userDevice: device,
+		userIP:     ip,
 	}
 }
 
-func NewLoginWithOAuthData(user oauth.User, device string) LoginWithOAuthData {
-	return LoginWithOAuthData{
-		user:       user,
+func NewLoginWithEmailCodeData(device string) LoginWithEmailCodeData {
+	return LoginWithEmailCodeData{
 		userDevice: device,
 	}
 }
 
-func NewLogoutData(sessionID pgtype.UUID) LogoutData {
-	return LogoutData{
-		sessionID: sessionID,
+func NewLoginWithPasskeyData(device string) LoginWithPasskeyData {
+	return LoginWithPasskeyData{
+		userDevice: device,
 	}
 }
 
-func (d ChangeMailData) IsMissingRequiredField() bool {
-	return d.NewEmail == "" || d.Password == ""
-}
-
-func (d ChangePasswordData) IsMissingRequiredField() bool {
-	return d.New == ""
+func NewLoginWithOAuthData(user oauth.User, device string) LoginWithOAuthData {
+	return LoginWithOAuthData{
+		user:       user,
+		userDevice: device,
+	}
 }
 
-func (d ChangeUsernameData) IsMissingRequiredField() bool {
-	return d.New == ""
+func NewLinkIdentityData(provider string) LinkIdentityData {
+	return LinkIdentityData{
+		provider: provider,
+	}
 }
 
-func (d LoginData) IsMissingRequiredField() bool {
-	return d.Email == "" || d.Password == ""
+func NewLogoutData(sessionID pgtype.UUID) LogoutData {
+	return LogoutData{
+		sessionID: sessionID,
+	}
 }
 
-func (d RegisterData) IsMissingRequiredField() bool {
-	return d.Email == "" || d.Password == ""
-}
-func (d SendRestorePasswordEmailData) IsMissingRequiredField() bool {
-	return d.Email == ""
+func (d LinkIdentityData) token() oauth.Token {
+	token := oauth.Token{AuthCode: d.AuthCode}
+	token.IDToken.TokenString = d.IDToken.TokenString
+	return token
 }
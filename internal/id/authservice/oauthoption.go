@@ -20,3 +20,9 @@ func (s AuthService) WithApple() OAuthOption {
 		return s.userService.LoginWithApple(ctx, user)
 	}
 }
+
+func (s AuthService) WithOIDC(providerName string) OAuthOption {
+	return func(ctx context.Context, user oauth.User) (*usr.User, oauth.LoginResultType, error) {
+		return s.userService.LoginWithOIDC(ctx, providerName, user)
+	}
+}
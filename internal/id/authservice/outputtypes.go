@@ -46,14 +46,12 @@ func (r RegisterResult) Error() error {
 
 type ResetPasswordResult struct {
 	emailAddress string
-	newPassword  string
 	err          error
 }
 
-func newResetPasswordResult(emailAddress, newPassword string, err error) ResetPasswordResult {
+func newResetPasswordResult(emailAddress string, err error) ResetPasswordResult {
 	return ResetPasswordResult{
 		emailAddress: emailAddress,
-		newPassword:  newPassword,
 		err:          err,
 	}
 }
@@ -62,10 +60,66 @@ func (r ResetPasswordResult) Address() string {
 	return r.emailAddress
 }
 
-func (r ResetPasswordResult) NewPassword() string {
-	return r.newPassword
+func (r ResetPasswordResult) Error() error {
+	return r.err
 }
 
-func (r ResetPasswordResult) Error() error {
+type DeleteUserResult struct {
+	deletion usr.ScheduledDeletion
+	err      error
+}
+
+func newDeleteUserResult(deletion usr.ScheduledDeletion, err error) DeleteUserResult {
+	return DeleteUserResult{
+		deletion: deletion,
+		err:      err,
+	}
+}
+
+func (r DeleteUserResult) Deletion() usr.ScheduledDeletion {
+	return r.deletion
+}
+
+func (r DeleteUserResult) Error() error {
+	return r.err
+}
+
+type EmailChangeResult struct {
+	change usr.EmailChange
+	err    error
+}
+
+func newEmailChangeResult(change usr.EmailChange, err error) EmailChangeResult {
+	return EmailChangeResult{
+		change: change,
+		err:    err,
+	}
+}
+
+func (r EmailChangeResult) Change() usr.EmailChange {
+	return r.change
+}
+
+func (r EmailChangeResult) Error() error {
+	return r.err
+}
+
+type AppliedEmailChangeResult struct {
+	change usr.AppliedEmailChange
+	err    error
+}
+
+func newAppliedEmailChangeResult(change usr.AppliedEmailChange, err error) AppliedEmailChangeResult {
+	return AppliedEmailChangeResult{
+		change: change,
+		err:    err,
+	}
+}
+
+func (r AppliedEmailChangeResult) Change() usr.AppliedEmailChange {
+	return r.change
+}
+
+func (r AppliedEmailChangeResult) Error() error {
 	return r.err
 }
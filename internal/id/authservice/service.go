@@ -1,42 +1,69 @@
 package authservice
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/zhuboris/never-expires/internal/id/passkey"
 	"github.com/zhuboris/never-expires/internal/id/pw"
 	"github.com/zhuboris/never-expires/internal/id/session"
 	"github.com/zhuboris/never-expires/internal/id/tkn"
 	"github.com/zhuboris/never-expires/internal/id/usr"
 	"github.com/zhuboris/never-expires/internal/id/usr/oauth"
+	"github.com/zhuboris/never-expires/internal/id/usr/oauth/oidc"
 	"github.com/zhuboris/never-expires/internal/shared/postgresql"
+	"github.com/zhuboris/never-expires/internal/shared/uuidformat"
 )
 
 type (
 	UserService interface {
 		Register(ctx context.Context, user usr.User) (*usr.User, error)
 		PublicDataByUserCtx(ctx context.Context) (*usr.PublicData, error)
+		UpdateProfile(ctx context.Context, update usr.ProfileUpdate) (*usr.PublicData, error)
+		Avatar(ctx context.Context) (usr.Avatar, error)
+		UpdateAvatar(ctx context.Context, data []byte) error
+		DeleteAvatar(ctx context.Context) error
 		UserByEmail(ctx context.Context, email string) (*usr.User, error)
-		Delete(ctx context.Context) error
+		UserByID(ctx context.Context, id pgtype.UUID) (*usr.User, error)
+		Delete(ctx context.Context) (usr.ScheduledDeletion, error)
+		Restore(ctx context.Context, restoreToken string) error
+		CancelDeletion(ctx context.Context, id pgtype.UUID) error
+		CheckNotDisabled(ctx context.Context, id pgtype.UUID) error
 		CheckPassword(ctx context.Context, toCheck string) error
+		RehashPassword(ctx context.Context, userID pgtype.UUID, verified string) error
 		Contains(ctx context.Context, email string) error
 		UpdatePassword(ctx context.Context, new string) error
-		UpdateEmail(ctx context.Context, new string) error
+		RequestEmailChange(ctx context.Context, new string) (usr.EmailChange, error)
+		ConfirmEmailChange(ctx context.Context, confirmToken string) (usr.AppliedEmailChange, error)
+		RevertEmailChange(ctx context.Context, revertToken string) (usr.AppliedEmailChange, error)
 		UpdateUsername(ctx context.Context, new string) error
 		ConfirmEmail(ctx context.Context, token string) error
-		ResetPassword(ctx context.Context, validationToken string) (user *usr.User, newPassword string, err error)
+		ResetPassword(ctx context.Context, validationToken, newPassword string) (*usr.User, error)
 		IsConfirmed(ctx context.Context, email string) (bool, error)
 		UserFromGoogleIDToken(ctx context.Context, idToken oauth.Token) (oauth.User, error)
 		UserFromAppleTokenCode(ctx context.Context, idToken oauth.Token) (oauth.User, error)
 		LoginWithOAuthGoogle(ctx context.Context, user oauth.User) (*usr.User, oauth.LoginResultType, error)
 		LoginWithApple(ctx context.Context, user oauth.User) (*usr.User, oauth.LoginResultType, error)
-		TryRevokeAppleAccount(ctx context.Context) error
+		UserFromOIDCToken(ctx context.Context, providerName string, idToken oauth.Token) (oauth.User, error)
+		OIDCProviders() []oidc.ProviderInfo
+		LoginWithOIDC(ctx context.Context, providerName string, user oauth.User) (*usr.User, oauth.LoginResultType, error)
+		UserFromExternalToken(ctx context.Context, providerName string, idToken oauth.Token) (oauth.User, error)
+		ExternalIdentities(ctx context.Context) ([]usr.ExternalIdentity, error)
+		LinkExternalIdentity(ctx context.Context, providerName string, user oauth.User) error
+		UnlinkExternalIdentity(ctx context.Context, providerName string) error
+		RequestDataExport(ctx context.Context, language string) error
+		DataExportArchive(ctx context.Context, token string) ([]byte, error)
 		AddEmailConfirmationToken(ctx context.Context, email string, tokenLifetime time.Duration) (string, error)
 		AddPasswordResetToken(ctx context.Context, email string, tokenLifetime time.Duration) (string, error)
+		AddEmailLoginCode(ctx context.Context, email string, codeLifetime time.Duration) (usr.LoginCode, error)
+		UserByEmailLoginCode(ctx context.Context, email, code string) (*usr.User, error)
+		UserByEmailLoginToken(ctx context.Context, token string) (*usr.User, error)
 		Status(ctx context.Context) error
 	}
 	SessionService interface {
@@ -47,17 +74,32 @@ type (
 		IsDeviceNewWhenUserHadSessionsBefore(ctx context.Context, session session.Session) (bool, error)
 		Status(ctx context.Context) error
 	}
+	PasskeyService interface {
+		BeginRegistration(ctx context.Context, owner passkey.Owner) (passkey.Ceremony, error)
+		FinishRegistration(ctx context.Context, ownerID, ceremonyID pgtype.UUID, response io.Reader) error
+		BeginLogin(ctx context.Context) (passkey.Ceremony, error)
+		FinishLogin(ctx context.Context, ceremonyID pgtype.UUID, response io.Reader) (pgtype.UUID, error)
+	}
+	LoginGuard interface {
+		Check(ctx context.Context, email, ip string) error
+		RegisterFailure(ctx context.Context, email, ip string) (isAccountLockedOut bool, err error)
+		RegisterSuccess(ctx context.Context, email string) error
+	}
 )
 
 type AuthService struct {
 	userService    UserService
 	sessionService SessionService
+	passkeyService PasskeyService
+	loginGuard     LoginGuard
 }
 
-func New(userService UserService, sessionService SessionService) *AuthService {
+func New(userService UserService, sessionService SessionService, passkeyService PasskeyService, loginGuard LoginGuard) *AuthService {
 	return &AuthService{
 		userService:    userService,
 		sessionService: sessionService,
+		passkeyService: passkeyService,
+		loginGuard:     loginGuard,
 	}
 }
 
@@ -69,35 +111,107 @@ func (s AuthService) UserFromAppleTokenCode(ctx context.Context, idToken oauth.T
 	return s.userService.UserFromAppleTokenCode(ctx, idToken)
 }
 
+func (s AuthService) UserFromOIDCToken(ctx context.Context, providerName string, idToken oauth.Token) (oauth.User, error) {
+	return s.userService.UserFromOIDCToken(ctx, providerName, idToken)
+}
+
+func (s AuthService) OIDCProviders() []oidc.ProviderInfo {
+	return s.userService.OIDCProviders()
+}
+
+func (s AuthService) ExternalIdentities(ctx context.Context) ([]usr.ExternalIdentity, error) {
+	return s.userService.ExternalIdentities(ctx)
+}
+
+func (s AuthService) LinkExternalIdentity(ctx context.Context, data LinkIdentityData) error {
+	user, err := s.userService.UserFromExternalToken(ctx, data.provider, data.token())
+	if err != nil {
+		return err
+	}
+
+	return s.userService.LinkExternalIdentity(ctx, data.provider, user)
+}
+
+func (s AuthService) UnlinkExternalIdentity(ctx context.Context, provider string) error {
+	return s.userService.UnlinkExternalIdentity(ctx, provider)
+}
+
+func (s AuthService) RequestDataExport(ctx context.Context, language string) error {
+	return s.userService.RequestDataExport(ctx, language)
+}
+
+func (s AuthService) DataExportArchive(ctx context.Context, token string) ([]byte, error) {
+	return s.userService.DataExportArchive(ctx, token)
+}
+
 func (s AuthService) AuthorizedUser(ctx context.Context) GettingUserResult {
 	user, err := s.userService.PublicDataByUserCtx(ctx)
 	return newGettingUserResult(user, err)
 }
 
-func (s AuthService) DeleteUser(ctx context.Context) error {
-	err := s.userService.TryRevokeAppleAccount(ctx)
+func (s AuthService) UpdateProfile(ctx context.Context, update usr.ProfileUpdate) GettingUserResult {
+	user, err := s.userService.UpdateProfile(ctx, update)
+	return newGettingUserResult(user, err)
+}
+
+func (s AuthService) Avatar(ctx context.Context) (usr.Avatar, error) {
+	return s.userService.Avatar(ctx)
+}
+
+func (s AuthService) UpdateAvatar(ctx context.Context, data []byte) error {
+	return s.userService.UpdateAvatar(ctx, data)
+}
+
+func (s AuthService) DeleteAvatar(ctx context.Context) error {
+	return s.userService.DeleteAvatar(ctx)
+}
+
+func (s AuthService) DeleteUser(ctx context.Context) DeleteUserResult {
+	deletion, err := s.userService.Delete(ctx)
 	if err != nil {
-		return err
+		return newDeleteUserResult(usr.ScheduledDeletion{}, err)
 	}
 
-	return s.userService.Delete(ctx)
+	err = s.sessionService.DeactivateAll(ctx)
+	return newDeleteUserResult(deletion, err)
 }
 
-func (s AuthService) UpdateMail(ctx context.Context, data ChangeMailData) error {
-	if err := s.userService.CheckPassword(ctx, data.Password); err != nil {
-		return err
-	}
+func (s AuthService) RestoreUser(ctx context.Context, token string) error {
+	return s.userService.Restore(ctx, token)
+}
 
-	if err := s.sessionService.DeactivateAll(ctx); err != nil {
-		return err
+func (s AuthService) UpdateMail(ctx context.Context, data ChangeMailData) EmailChangeResult {
+	if err := s.userService.CheckPassword(ctx, data.Password); err != nil {
+		return newEmailChangeResult(usr.EmailChange{}, err)
 	}
 
-	err := s.userService.UpdateEmail(ctx, data.NewEmail)
+	change, err := s.userService.RequestEmailChange(ctx, data.NewEmail)
 	if errors.Is(err, postgresql.ErrAddedDuplicateOfUnique) {
 		err = errors.Join(ErrAlreadyRegistered, err)
 	}
 
-	return err
+	return newEmailChangeResult(change, err)
+}
+
+func (s AuthService) ConfirmEmailChange(ctx context.Context, token string) AppliedEmailChangeResult {
+	change, err := s.userService.ConfirmEmailChange(ctx, token)
+	if err != nil {
+		return newAppliedEmailChangeResult(usr.AppliedEmailChange{}, err)
+	}
+
+	err = s.sessionService.DeactivateAll(usr.WithUserID(ctx, change.UserID))
+	return newAppliedEmailChangeResult(change, err)
+}
+
+// RevertEmailChange logs out all sessions, because the change could be made by someone who got the password.
+func (s AuthService) RevertEmailChange(ctx context.Context, token string) AppliedEmailChangeResult {
+	change, err := s.userService.RevertEmailChange(ctx, token)
+	if err != nil {
+		return newAppliedEmailChangeResult(usr.AppliedEmailChange{}, err)
+	}
+
+	err = s.sessionService.DeactivateAll(usr.WithUserID(ctx, change.UserID))
+	return newAppliedEmailChangeResult(change, err)
 }
 
 func (s AuthService) ChangePassword(ctx context.Context, data ChangePasswordData) error {
@@ -121,25 +235,58 @@ func (s AuthService) Login(ctx context.Context, data LoginData) LoginResult {
 		return newErrorLoginResult(errMissingUserDevice)
 	}
 
+	if err := s.loginGuard.Check(ctx, data.Email, data.userIP); err != nil {
+		return newErrorLoginResult(err)
+	}
+
 	user, err := s.userService.UserByEmail(ctx, data.Email)
 	if err != nil {
-		return newErrorLoginResult(errors.Join(ErrWrongLoginData, err))
+		return newErrorLoginResult(errors.Join(ErrWrongLoginData, err, s.registerLoginFailure(ctx, data, false)))
 	}
 
 	if err := pw.Check(data.Password, user.Password); err != nil {
-		return newErrorLoginResult(errors.Join(ErrWrongLoginData, err))
+		return newErrorLoginResult(errors.Join(ErrWrongLoginData, err, s.registerLoginFailure(ctx, data, true)))
+	}
+
+	if err := s.loginGuard.RegisterSuccess(ctx, data.Email); err != nil {
+		return newErrorLoginResult(err)
+	}
+
+	if pw.NeedsRehash(user.Password) {
+		// Failed upgrade does not prevent login, the hash is upgraded on any next successful login.
+		_ = s.userService.RehashPassword(ctx, user.ID, data.Password)
 	}
 
 	authData, err := s.CreateSession(ctx, user.ID, data.userDevice)
 	return newLoginResult(user, authData, err)
 }
 
+func (s AuthService) registerLoginFailure(ctx context.Context, data LoginData, isAccountExists bool) error {
+	isLockedOut, err := s.loginGuard.RegisterFailure(ctx, data.Email, data.userIP)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case isLockedOut && isAccountExists:
+		return errors.Join(ErrAccountLockedOut, ErrExistingAccountLockedOut)
+	case isLockedOut:
+		return ErrAccountLockedOut
+	default:
+		return nil
+	}
+}
+
 func (s AuthService) LoginWithOAuth(ctx context.Context, data LoginWithOAuthData, loginOptionFunc OAuthOption) LoginResult {
 	if data.userDevice == "" {
 		return newErrorLoginResult(errMissingUserDevice)
 	}
 
 	user, resultType, err := loginOptionFunc(ctx, data.user)
+	if errors.Is(err, postgresql.ErrAddedDuplicateOfUnique) {
+		err = errors.Join(ErrAlreadyRegistered, err)
+	}
+
 	if err != nil {
 		return newErrorLoginResult(err)
 	}
@@ -148,6 +295,92 @@ func (s AuthService) LoginWithOAuth(ctx context.Context, data LoginWithOAuthData
 	return newLoginWithOAuthResult(user, authData, resultType, err)
 }
 
+func (s AuthService) LoginWithEmailCode(ctx context.Context, data LoginWithEmailCodeData) LoginResult {
+	if data.userDevice == "" {
+		return newErrorLoginResult(errMissingUserDevice)
+	}
+
+	var (
+		user *usr.User
+		err  error
+	)
+
+	if data.Token != "" {
+		user, err = s.userService.UserByEmailLoginToken(ctx, data.Token)
+	} else {
+		user, err = s.userService.UserByEmailLoginCode(ctx, data.Email, data.Code)
+	}
+
+	if err != nil {
+		return newErrorLoginResult(err)
+	}
+
+	authData, err := s.CreateSession(ctx, user.ID, data.userDevice)
+	return newLoginResult(user, authData, err)
+}
+
+func (s AuthService) BeginPasskeyLogin(ctx context.Context) (passkey.Ceremony, error) {
+	return s.passkeyService.BeginLogin(ctx)
+}
+
+func (s AuthService) LoginWithPasskey(ctx context.Context, data LoginWithPasskeyData) LoginResult {
+	if data.userDevice == "" {
+		return newErrorLoginResult(errMissingUserDevice)
+	}
+
+	ceremonyID, err := uuidformat.StrToPgtype(data.CeremonyID)
+	if err != nil {
+		return newErrorLoginResult(errors.Join(passkey.ErrCeremonyNotFound, err))
+	}
+
+	userID, err := s.passkeyService.FinishLogin(ctx, ceremonyID, bytes.NewReader(data.Credential))
+	if err != nil {
+		return newErrorLoginResult(err)
+	}
+
+	user, err := s.userService.UserByID(ctx, userID)
+	if err != nil {
+		return newErrorLoginResult(err)
+	}
+
+	authData, err := s.CreateSession(ctx, user.ID, data.userDevice)
+	return newLoginResult(user, authData, err)
+}
+
+func (s AuthService) BeginPasskeyRegistration(ctx context.Context) (passkey.Ceremony, error) {
+	userID, err := usr.ID(ctx)
+	if err != nil {
+		return passkey.Ceremony{}, err
+	}
+
+	user, err := s.userService.PublicDataByUserCtx(ctx)
+	if err != nil {
+		return passkey.Ceremony{}, err
+	}
+
+	owner := passkey.Owner{
+		ID:          userID,
+		Name:        user.Email,
+		DisplayName: user.Username,
+	}
+
+	return s.passkeyService.BeginRegistration(ctx, owner)
+}
+
+func (s AuthService) FinishPasskeyRegistration(ctx context.Context, data PasskeyCeremonyData) error {
+	userID, err := usr.ID(ctx)
+	if err != nil {
+		return err
+	}
+
+	ceremonyID, err := uuidformat.StrToPgtype(data.CeremonyID)
+	if err != nil {
+		return errors.Join(passkey.ErrCeremonyNotFound, err)
+	}
+
+	return s.passkeyService.FinishRegistration(ctx, userID, ceremonyID, bytes.NewReader(data.Credential))
+}
+
 func (s AuthService) CreateSession(ctx context.Context, userID pgtype.UUID, userDevice string) (AuthData, error) {
 	if !userID.Valid {
 		return AuthData{}, ErrWrongLoginData
@@ -157,6 +390,15 @@ func (s AuthService) CreateSession(ctx context.Context, userID pgtype.UUID, user
 		return AuthData{}, errMissingUserDevice
 	}
 
+	if err := s.userService.CheckNotDisabled(ctx, userID); err != nil {
+		return AuthData{}, err
+	}
+
+	// Any login during grace period restores account scheduled for deletion.
+	if err := s.userService.CancelDeletion(ctx, userID); err != nil {
+		return AuthData{}, err
+	}
+
 	authToken, err := tkn.CreateJWT(userID, AuthLifetime)
 	if err != nil {
 		return AuthData{}, err
@@ -198,6 +440,10 @@ func (s AuthService) AllowRefreshingJWT(ctx context.Context, currentSession sess
 	return nil
 }
 
+func (s AuthService) DeactivateAllSessions(ctx context.Context) error {
+	return s.sessionService.DeactivateAll(ctx)
+}
+
 func (s AuthService) DeactivateSession(ctx context.Context, sessionID pgtype.UUID) error {
 	return s.sessionService.Deactivate(ctx, sessionID)
 }
@@ -206,18 +452,19 @@ func (s AuthService) ConfirmEmail(ctx context.Context, token string) error {
 	return s.userService.ConfirmEmail(ctx, token)
 }
 
-func (s AuthService) ResetPassword(ctx context.Context, token string) ResetPasswordResult {
-	user, newPassword, err := s.userService.ResetPassword(ctx, token)
+func (s AuthService) ResetPassword(ctx context.Context, data ResetPasswordData) ResetPasswordResult {
+	user, err := s.userService.ResetPassword(ctx, data.Token, data.NewPassword)
 	if err != nil {
-		return newResetPasswordResult("", "", err)
+		return newResetPasswordResult("", err)
 	}
 
 	ctx = usr.WithUserID(ctx, user.ID)
 	if err := s.sessionService.DeactivateAll(ctx); err != nil {
-		return newResetPasswordResult("", "", err)
+		return newResetPasswordResult("", err)
 	}
 
-	return newResetPasswordResult(user.Email, newPassword, err)
+	err = s.loginGuard.RegisterSuccess(ctx, user.Email)
+	return newResetPasswordResult(user.Email, err)
 }
 
 func (s AuthService) IsConfirmed(ctx context.Context, email string) (bool, error) {
@@ -263,6 +510,17 @@ func (s AuthService) AddPasswordResetToken(ctx context.Context, email string) (s
 	return s.userService.AddPasswordResetToken(ctx, email, lifetime)
 }
 
+func (s AuthService) AddEmailLoginCode(ctx context.Context, email string) (usr.LoginCode, error) {
+	const lifetime = 10 * time.Minute
+
+	code, err := s.userService.AddEmailLoginCode(ctx, email, lifetime)
+	if errors.Is(err, usr.ErrNotFound) {
+		err = errors.Join(ErrMissingEmailAddress, err)
+	}
+
+	return code, err
+}
+
 func (s AuthService) Status(ctx context.Context) error {
 	userErr := s.userService.Status(ctx)
 	sessionsErr := s.sessionService.Status(ctx)
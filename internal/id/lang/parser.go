@@ -1,9 +1,15 @@
 package lang
 
-import "golang.org/x/text/language"
+import (
+	"errors"
+
+	"golang.org/x/text/language"
+)
 
 const defaultLanguage = "en"
 
+var ErrInvalidLanguage = errors.New("invalid language identifier")
+
 type Language string
 
 func FromLocaleIdentifier(input string) Language {
@@ -19,3 +25,18 @@ func FromLocaleIdentifier(input string) Language {
 	baseLang, _ := tag.Base()
 	return Language(baseLang.String())
 }
+
+// Parse returns base language of the identifier, unlike FromLocaleIdentifier it refuses input instead of falling back.
+func Parse(input string) (Language, error) {
+	tag, err := language.Parse(input)
+	if err != nil {
+		return "", errors.Join(ErrInvalidLanguage, err)
+	}
+
+	baseLang, confidence := tag.Base()
+	if confidence != language.Exact {
+		return "", ErrInvalidLanguage
+	}
+
+	return Language(baseLang.String()), nil
+}
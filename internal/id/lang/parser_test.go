@@ -0,0 +1,50 @@
+package lang
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Language
+		wantErr error
+	}{
+		{
+			name:  "base language",
+			input: "ru",
+			want:  "ru",
+		},
+		{
+			name:  "locale with region",
+			input: "en-GB",
+			want:  "en",
+		},
+		{
+			name:    "empty",
+			input:   "",
+			wantErr: ErrInvalidLanguage,
+		},
+		{
+			name:    "undefined language",
+			input:   "und",
+			wantErr: ErrInvalidLanguage,
+		},
+		{
+			name:    "not a language tag",
+			input:   "english",
+			wantErr: ErrInvalidLanguage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package loginguard
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrThrottled = errors.New("too many failed login attempts")
+
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e ThrottledError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrThrottled, e.RetryAfter)
+}
+
+func (e ThrottledError) Unwrap() error {
+	return ErrThrottled
+}
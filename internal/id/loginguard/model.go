@@ -0,0 +1,27 @@
+package loginguard
+
+import "strings"
+
+const (
+	accountScope = "account"
+	ipScope      = "ip"
+)
+
+type counter struct {
+	scope   string
+	subject string
+}
+
+func accountCounter(email string) counter {
+	return counter{
+		scope:   accountScope,
+		subject: strings.ToLower(strings.TrimSpace(email)),
+	}
+}
+
+func ipCounter(ip string) counter {
+	return counter{
+		scope:   ipScope,
+		subject: ip,
+	}
+}
@@ -0,0 +1,50 @@
+package loginguard
+
+import "time"
+
+type policy struct {
+	freeAttempts    int
+	lockoutAfter    int
+	baseDelay       time.Duration
+	maxDelay        time.Duration
+	lockoutDuration time.Duration
+	forgetAfter     time.Duration
+}
+
+var (
+	accountPolicy = policy{
+		freeAttempts:    3,
+		lockoutAfter:    10,
+		baseDelay:       time.Second,
+		maxDelay:        5 * time.Minute,
+		lockoutDuration: 30 * time.Minute,
+		forgetAfter:     24 * time.Hour,
+	}
+	ipPolicy = policy{
+		freeAttempts:    20,
+		lockoutAfter:    100,
+		baseDelay:       time.Second,
+		maxDelay:        5 * time.Minute,
+		lockoutDuration: time.Hour,
+		forgetAfter:     time.Hour,
+	}
+)
+
+// delay returns for how long next attempts are blocked after given count of failures in a row.
+// Once failures reach lockoutAfter the counter is locked out and should be started from zero.
+func (p policy) delay(failures int) (delay time.Duration, isLockout bool) {
+	if failures >= p.lockoutAfter {
+		return p.lockoutDuration, true
+	}
+
+	if failures <= p.freeAttempts {
+		return 0, false
+	}
+
+	delay = p.baseDelay
+	for i := p.freeAttempts + 1; i < failures && delay < p.maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, p.maxDelay), false
+}
@@ -0,0 +1,60 @@
+package loginguard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_delay(t *testing.T) {
+	p := policy{
+		freeAttempts:    3,
+		lockoutAfter:    10,
+		baseDelay:       time.Second,
+		maxDelay:        30 * time.Second,
+		lockoutDuration: time.Hour,
+	}
+
+	tests := []struct {
+		name          string
+		failures      int
+		expectedDelay time.Duration
+		wantLockout   bool
+	}{
+		{
+			name:     "free attempt",
+			failures: 3,
+		},
+		{
+			name:          "first delayed attempt",
+			failures:      4,
+			expectedDelay: time.Second,
+		},
+		{
+			name:          "delay grows exponentially",
+			failures:      6,
+			expectedDelay: 4 * time.Second,
+		},
+		{
+			name:          "delay is capped",
+			failures:      9,
+			expectedDelay: 30 * time.Second,
+		},
+		{
+			name:          "lockout",
+			failures:      10,
+			expectedDelay: time.Hour,
+			wantLockout:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, isLockout := p.delay(tt.failures)
+
+			assert.Equal(t, tt.expectedDelay, delay)
+			assert.Equal(t, tt.wantLockout, isLockout)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package loginguard
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zhuboris/never-expires/internal/shared/postgresql"
+)
+
+type PostgresqlRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresqlRepository(pool *pgxpool.Pool) (*PostgresqlRepository, error) {
+	if pool == nil {
+		return nil, postgresql.ErrPoolInitRequired
+	}
+
+	return &PostgresqlRepository{
+		pool: pool,
+	}, nil
+}
+
+func (r PostgresqlRepository) Ping(ctx context.Context) error {
+	return r.pool.Ping(ctx)
+}
+
+func (r PostgresqlRepository) blockedUntil(ctx context.Context, counters ...counter) (time.Time, error) {
+	const sql = `
+		SELECT MAX(blocked_until) FROM login_failures
+		WHERE (scope, subject) IN (SELECT * FROM UNNEST($1::VARCHAR[], $2::TEXT[]));
+	`
+
+	scopes := make([]string, 0, len(counters))
+	subjects := make([]string, 0, len(counters))
+	for _, c := range counters {
+		scopes = append(scopes, c.scope)
+		subjects = append(subjects, c.subject)
+	}
+
+	var until pgtype.Timestamptz
+	err := r.pool.QueryRow(ctx, sql, scopes, subjects).
+		Scan(&until)
+	if err != nil {
+		return time.Time{}, postgresql.HandleQueryErr(err)
+	}
+
+	return until.Time, nil
+}
+
+func (r PostgresqlRepository) addFailure(ctx context.Context, c counter, forgetAfter time.Duration) (int, error) {
+	const sql = `
+		INSERT INTO login_failures (scope, subject, failures, last_failed_at)
+		VALUES ($1, $2, 1, CURRENT_TIMESTAMP)
+		ON CONFLICT (scope, subject) DO UPDATE
+		SET failures = CASE
+		        WHEN login_failures.last_failed_at < CURRENT_TIMESTAMP - $3 * INTERVAL '1 second' THEN 1
+		        ELSE login_failures.failures + 1
+		    END,
+		    last_failed_at = CURRENT_TIMESTAMP
+
+		RETURNING failures;
+	`
+
+	var failures int
+	err := r.pool.QueryRow(ctx, sql, c.scope, c.subject, forgetAfter.Seconds()).
+		Scan(&failures)
+
+	return failures, postgresql.HandleQueryErr(err)
+}
+
+func (r PostgresqlRepository) block(ctx context.Context, c counter, until time.Time, resetFailures bool) error {
+	const sql = `
+		UPDATE login_failures
+		SET blocked_until = $3,
+		    failures = CASE WHEN $4 THEN 0 ELSE failures END
+		WHERE scope = $1
+		AND subject = $2;
+	`
+
+	_, err := r.pool.Exec(ctx, sql, c.scope, c.subject, until, resetFailures)
+	return postgresql.HandleQueryErr(err)
+}
+
+func (r PostgresqlRepository) reset(ctx context.Context, c counter) error {
+	const sql = `
+		DELETE FROM login_failures
+		WHERE scope = $1
+		AND subject = $2;
+	`
+
+	_, err := r.pool.Exec(ctx, sql, c.scope, c.subject)
+	return postgresql.HandleQueryErr(err)
+}
@@ -0,0 +1,81 @@
+package loginguard
+
+import (
+	"context"
+	"time"
+)
+
+type repository interface {
+	blockedUntil(ctx context.Context, counters ...counter) (time.Time, error)
+	addFailure(ctx context.Context, c counter, forgetAfter time.Duration) (int, error)
+	block(ctx context.Context, c counter, until time.Time, resetFailures bool) error
+	reset(ctx context.Context, c counter) error
+	Ping(ctx context.Context) error
+}
+
+type Service struct {
+	repo repository
+	now  func() time.Time
+}
+
+func NewService(repo repository) *Service {
+	return &Service{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// Check returns ThrottledError if login attempts for the email or from the ip are blocked at the moment.
+func (s Service) Check(ctx context.Context, email, ip string) error {
+	until, err := s.repo.blockedUntil(ctx, s.counters(email, ip)...)
+	if err != nil {
+		return err
+	}
+
+	if retryAfter := until.Sub(s.now()); retryAfter > 0 {
+		return ThrottledError{
+			RetryAfter: (retryAfter + time.Second - 1).Truncate(time.Second),
+		}
+	}
+
+	return nil
+}
+
+// RegisterFailure counts failed attempt and reports if it made the account locked out.
+func (s Service) RegisterFailure(ctx context.Context, email, ip string) (isAccountLockedOut bool, err error) {
+	isAccountLockedOut, err = s.registerFailure(ctx, accountCounter(email), accountPolicy)
+	if err != nil || ip == "" {
+		return isAccountLockedOut, err
+	}
+
+	_, err = s.registerFailure(ctx, ipCounter(ip), ipPolicy)
+	return isAccountLockedOut, err
+}
+
+// RegisterSuccess resets account counter. Counter of ip is kept to not let reset it with own valid account.
+func (s Service) RegisterSuccess(ctx context.Context, email string) error {
+	return s.repo.reset(ctx, accountCounter(email))
+}
+
+func (s Service) registerFailure(ctx context.Context, c counter, p policy) (isLockout bool, err error) {
+	failures, err := s.repo.addFailure(ctx, c, p.forgetAfter)
+	if err != nil {
+		return false, err
+	}
+
+	delay, isLockout := p.delay(failures)
+	if delay == 0 {
+		return false, nil
+	}
+
+	return isLockout, s.repo.block(ctx, c, s.now().Add(delay), isLockout)
+}
+
+func (s Service) counters(email, ip string) []counter {
+	counters := []counter{accountCounter(email)}
+	if ip != "" {
+		counters = append(counters, ipCounter(ip))
+	}
+
+	return counters
+}
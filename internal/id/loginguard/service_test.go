@@ -0,0 +1,150 @@
+package loginguard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testEmail = "User@Test.com"
+	testIP    = "127.0.0.1"
+)
+
+func TestService_Check(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		blockedUntil       time.Time
+		expectedRetryAfter time.Duration
+	}{
+		{
+			name: "never blocked",
+		},
+		{
+			name:         "block is expired",
+			blockedUntil: now.Add(-time.Second),
+		},
+		{
+			name:               "blocked",
+			blockedUntil:       now.Add(1500 * time.Millisecond),
+			expectedRetryAfter: 2 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockrepository(t)
+			repo.EXPECT().
+				blockedUntil(mock.Anything, accountCounter(testEmail), ipCounter(testIP)).
+				Return(tt.blockedUntil, nil)
+
+			service := arrangeService(repo, now)
+			err := service.Check(context.Background(), testEmail, testIP)
+
+			if tt.expectedRetryAfter == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrThrottled)
+			errThrottled := new(ThrottledError)
+			require.ErrorAs(t, err, errThrottled)
+			assert.Equal(t, tt.expectedRetryAfter, errThrottled.RetryAfter)
+		})
+	}
+}
+
+func TestService_RegisterFailure(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		accountFailures int
+		ipFailures      int
+		wantLockout     bool
+		arrangeBlocks   func(repo *Mockrepository)
+	}{
+		{
+			name:            "free attempt",
+			accountFailures: 1,
+			ipFailures:      1,
+			arrangeBlocks:   func(repo *Mockrepository) {},
+		},
+		{
+			name:            "account is delayed",
+			accountFailures: accountPolicy.freeAttempts + 1,
+			ipFailures:      1,
+			arrangeBlocks: func(repo *Mockrepository) {
+				repo.EXPECT().
+					block(mock.Anything, accountCounter(testEmail), now.Add(accountPolicy.baseDelay), false).
+					Return(nil)
+			},
+		},
+		{
+			name:            "account is locked out",
+			accountFailures: accountPolicy.lockoutAfter,
+			ipFailures:      1,
+			wantLockout:     true,
+			arrangeBlocks: func(repo *Mockrepository) {
+				repo.EXPECT().
+					block(mock.Anything, accountCounter(testEmail), now.Add(accountPolicy.lockoutDuration), true).
+					Return(nil)
+			},
+		},
+		{
+			name:            "ip is locked out",
+			accountFailures: 1,
+			ipFailures:      ipPolicy.lockoutAfter,
+			arrangeBlocks: func(repo *Mockrepository) {
+				repo.EXPECT().
+					block(mock.Anything, ipCounter(testIP), now.Add(ipPolicy.lockoutDuration), true).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockrepository(t)
+			repo.EXPECT().
+				addFailure(mock.Anything, accountCounter(testEmail), accountPolicy.forgetAfter).
+				Return(tt.accountFailures, nil)
+			repo.EXPECT().
+				addFailure(mock.Anything, ipCounter(testIP), ipPolicy.forgetAfter).
+				Return(tt.ipFailures, nil)
+			tt.arrangeBlocks(repo)
+
+			service := arrangeService(repo, now)
+			isLockedOut, err := service.RegisterFailure(context.Background(), testEmail, testIP)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLockout, isLockedOut)
+		})
+	}
+}
+
+func TestService_RegisterSuccess(t *testing.T) {
+	repo := NewMockrepository(t)
+	repo.EXPECT().
+		reset(mock.Anything, counter{scope: accountScope, subject: "user@test.com"}).
+		Return(nil)
+
+	err := NewService(repo).RegisterSuccess(context.Background(), testEmail)
+
+	assert.NoError(t, err)
+}
+
+func arrangeService(repo repository, now time.Time) *Service {
+	service := NewService(repo)
+	service.now = func() time.Time {
+		return now
+	}
+
+	return service
+}
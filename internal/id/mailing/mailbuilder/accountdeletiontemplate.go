@@ -0,0 +1,38 @@
+package mailbuilder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zhuboris/never-expires/internal/id/lang"
+)
+
+type accountDeletionTemplateInput struct {
+	Subject         string
+	Header          string
+	Body            string
+	ClickSuggestion string
+	Button          string
+	Link            string
+	Annotation      string
+}
+
+func (b Builder) newAccountDeletionTemplateInput(link string, deleteAfter time.Time, language lang.Language) (accountDeletionTemplateInput, error) {
+	const dateLayout = "02.01.2006"
+
+	content := b.localesDict.AccountDeletion
+	input, err := b.newEmailWithButtonTemplateInput(content, language)
+	if err != nil {
+		return accountDeletionTemplateInput{}, err
+	}
+
+	return accountDeletionTemplateInput{
+		Subject:         input.subject,
+		Header:          input.header,
+		Body:            fmt.Sprintf(input.body, deleteAfter.UTC().Format(dateLayout)),
+		ClickSuggestion: input.clickSuggestion,
+		Button:          input.button,
+		Link:            link,
+		Annotation:      input.annotation,
+	}, nil
+}
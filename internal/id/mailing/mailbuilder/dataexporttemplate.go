@@ -0,0 +1,31 @@
+package mailbuilder
+
+import "github.com/zhuboris/never-expires/internal/id/lang"
+
+type dataExportTemplateInput struct {
+	Subject         string
+	Header          string
+	Body            string
+	ClickSuggestion string
+	Button          string
+	Link            string
+	Annotation      string
+}
+
+func (b Builder) newDataExportTemplateInput(link string, language lang.Language) (dataExportTemplateInput, error) {
+	content := b.localesDict.DataExport
+	input, err := b.newEmailWithButtonTemplateInput(content, language)
+	if err != nil {
+		return dataExportTemplateInput{}, err
+	}
+
+	return dataExportTemplateInput{
+		Subject:         input.subject,
+		Header:          input.header,
+		Body:            input.body,
+		ClickSuggestion: input.clickSuggestion,
+		Button:          input.button,
+		Link:            link,
+		Annotation:      input.annotation,
+	}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"html/template"
+	"time"
 
 	"github.com/zhuboris/never-expires/internal/id/lang"
 	"github.com/zhuboris/never-expires/internal/id/usr/oauth"
@@ -71,6 +72,33 @@ func (b Builder) ResetPassword(recipient, url string, language lang.Language) ([
 	return makeEmailFromTemplate(recipient, input.Subject, input, b.templates.emailWithButton)
 }
 
+func (b Builder) DataExportReady(recipient, url string, language lang.Language) ([]byte, error) {
+	input, err := b.newDataExportTemplateInput(url, language)
+	if err != nil {
+		return nil, err
+	}
+
+	return makeEmailFromTemplate(recipient, input.Subject, input, b.templates.emailWithButton)
+}
+
+func (b Builder) AccountDeletionScheduled(recipient, url string, deleteAfter time.Time, language lang.Language) ([]byte, error) {
+	input, err := b.newAccountDeletionTemplateInput(url, deleteAfter, language)
+	if err != nil {
+		return nil, err
+	}
+
+	return makeEmailFromTemplate(recipient, input.Subject, input, b.templates.emailWithButton)
+}
+
+func (b Builder) EmailChangeRequested(recipient, newEmail, url string, revertUntil time.Time, language lang.Language) ([]byte, error) {
+	input, err := b.newEmailChangeRequestedTemplateInput(newEmail, url, revertUntil, language)
+	if err != nil {
+		return nil, err
+	}
+
+	return makeEmailFromTemplate(recipient, input.Subject, input, b.templates.emailWithButton)
+}
+
 func (b Builder) PasswordIsChanged(recipient string, language lang.Language) ([]byte, error) {
 	input, err := b.newChangedPasswordTemplateInput(language)
 	if err != nil {
@@ -80,6 +108,15 @@ func (b Builder) PasswordIsChanged(recipient string, language lang.Language) ([]
 	return makeEmailFromTemplate(recipient, input.Subject, input, b.templates.messageEmail)
 }
 
+func (b Builder) AccountLocked(recipient string, language lang.Language) ([]byte, error) {
+	input, err := b.newLockedAccountTemplateInput(language)
+	if err != nil {
+		return nil, err
+	}
+
+	return makeEmailFromTemplate(recipient, input.Subject, input, b.templates.messageEmail)
+}
+
 func (b Builder) OAuthAccountConnected(recipient string, language lang.Language, connectionType oauth.Type) ([]byte, error) {
 	input, err := b.newOauthConnectionTemplateInputTemplateInput(language, connectionType)
 	if err != nil {
@@ -89,13 +126,22 @@ func (b Builder) OAuthAccountConnected(recipient string, language lang.Language,
 	return makeEmailFromTemplate(recipient, input.Subject, input, b.templates.messageEmail)
 }
 
-func (b Builder) NewPassword(recipient, password string, language lang.Language) ([]byte, error) {
-	input, err := b.newNewPasswordTemplateInput(password, language)
+func (b Builder) ExternalAccountConnected(recipient string, language lang.Language, providerName string) ([]byte, error) {
+	input, err := b.newExternalConnectionTemplateInput(language, providerName)
 	if err != nil {
 		return nil, err
 	}
 
-	return makeEmailFromTemplate(recipient, input.Subject, input, b.templates.newPasswordEmail)
+	return makeEmailFromTemplate(recipient, input.Subject, input, b.templates.messageEmail)
+}
+
+func (b Builder) ExternalAccountDisconnected(recipient string, language lang.Language, providerName string) ([]byte, error) {
+	input, err := b.newExternalDisconnectionTemplateInput(language, providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	return makeEmailFromTemplate(recipient, input.Subject, input, b.templates.messageEmail)
 }
 
 func (b Builder) NewDeviceLogin(recipient string, data NotificationData, language lang.Language) ([]byte, error) {
@@ -107,6 +153,15 @@ func (b Builder) NewDeviceLogin(recipient string, data NotificationData, languag
 	return makeEmailFromTemplate(recipient, input.Subject, input, b.templates.newDeviceEmail)
 }
 
+func (b Builder) LoginCode(recipient, code, url string, language lang.Language) ([]byte, error) {
+	input, err := b.newLoginCodeTemplateInput(code, url, language)
+	if err != nil {
+		return nil, err
+	}
+
+	return makeEmailFromTemplate(recipient, input.Subject, input, b.templates.loginCodeEmail)
+}
+
 func makeEmailFromTemplate(recipient, subject string, data any, bodyTemplate *template.Template) ([]byte, error) {
 	body, err := messageBody(data, bodyTemplate)
 	if err != nil {
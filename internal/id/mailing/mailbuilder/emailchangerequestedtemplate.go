@@ -0,0 +1,38 @@
+package mailbuilder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zhuboris/never-expires/internal/id/lang"
+)
+
+type emailChangeRequestedTemplateInput struct {
+	Subject         string
+	Header          string
+	Body            string
+	ClickSuggestion string
+	Button          string
+	Link            string
+	Annotation      string
+}
+
+func (b Builder) newEmailChangeRequestedTemplateInput(newEmail, link string, revertUntil time.Time, language lang.Language) (emailChangeRequestedTemplateInput, error) {
+	const dateLayout = "02.01.2006"
+
+	content := b.localesDict.EmailChangeRequested
+	input, err := b.newEmailWithButtonTemplateInput(content, language)
+	if err != nil {
+		return emailChangeRequestedTemplateInput{}, err
+	}
+
+	return emailChangeRequestedTemplateInput{
+		Subject:         input.subject,
+		Header:          input.header,
+		Body:            fmt.Sprintf(input.body, newEmail),
+		ClickSuggestion: fmt.Sprintf(input.clickSuggestion, revertUntil.UTC().Format(dateLayout)),
+		Button:          input.button,
+		Link:            link,
+		Annotation:      input.annotation,
+	}, nil
+}
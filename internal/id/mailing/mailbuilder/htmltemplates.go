@@ -6,17 +6,17 @@ import (
 )
 
 const (
-	emailWithButtonTemplatePath  = "web/emails/templates/with_button.html"
-	newPasswordEmailTemplatePath = "web/emails/templates/new_password.html"
-	newDeviceEmailTemplatePath   = "web/emails/templates/new_device.html"
-	messageEmailTemplatePath     = "web/emails/templates/message.html"
+	emailWithButtonTemplatePath = "web/emails/templates/with_button.html"
+	newDeviceEmailTemplatePath  = "web/emails/templates/new_device.html"
+	messageEmailTemplatePath    = "web/emails/templates/message.html"
+	loginCodeEmailTemplatePath  = "web/emails/templates/login_code.html"
 )
 
 type htmlTemplates struct {
-	emailWithButton  *template.Template
-	newPasswordEmail *template.Template
-	newDeviceEmail   *template.Template
-	messageEmail     *template.Template
+	emailWithButton *template.Template
+	newDeviceEmail  *template.Template
+	messageEmail    *template.Template
+	loginCodeEmail  *template.Template
 }
 
 func newHtmlTemplates() (*htmlTemplates, error) {
@@ -25,26 +25,26 @@ func newHtmlTemplates() (*htmlTemplates, error) {
 		return nil, err
 	}
 
-	newPasswordEmailTemplate, err := parseTemplate(newPasswordEmailTemplatePath)
+	newDeviceEmailTemplate, err := parseTemplate(newDeviceEmailTemplatePath)
 	if err != nil {
 		return nil, err
 	}
 
-	newDeviceEmailTemplate, err := parseTemplate(newDeviceEmailTemplatePath)
+	messageEmailTemplate, err := parseTemplate(messageEmailTemplatePath)
 	if err != nil {
 		return nil, err
 	}
 
-	messageEmailTemplate, err := parseTemplate(messageEmailTemplatePath)
+	loginCodeEmailTemplate, err := parseTemplate(loginCodeEmailTemplatePath)
 	if err != nil {
 		return nil, err
 	}
 
 	return &htmlTemplates{
-		emailWithButton:  emailWithButtonTemplate,
-		newPasswordEmail: newPasswordEmailTemplate,
-		newDeviceEmail:   newDeviceEmailTemplate,
-		messageEmail:     messageEmailTemplate,
+		emailWithButton: emailWithButtonTemplate,
+		newDeviceEmail:  newDeviceEmailTemplate,
+		messageEmail:    messageEmailTemplate,
+		loginCodeEmail:  loginCodeEmailTemplate,
 	}, nil
 }
 
@@ -0,0 +1,25 @@
+package mailbuilder
+
+import "github.com/zhuboris/never-expires/internal/id/lang"
+
+type lockedAccountTemplateInput struct {
+	Subject    string
+	Header     string
+	Body       string
+	Annotation string
+}
+
+func (b Builder) newLockedAccountTemplateInput(language lang.Language) (lockedAccountTemplateInput, error) {
+	content := b.localesDict.AccountLocked
+	input, err := b.newMessageEmailTemplateInput(content, language)
+	if err != nil {
+		return lockedAccountTemplateInput{}, err
+	}
+
+	return lockedAccountTemplateInput{
+		Subject:    input.subject,
+		Header:     input.header,
+		Body:       input.body,
+		Annotation: input.annotation,
+	}, nil
+}
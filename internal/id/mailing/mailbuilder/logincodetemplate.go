@@ -0,0 +1,40 @@
+package mailbuilder
+
+import "github.com/zhuboris/never-expires/internal/id/lang"
+
+type loginCodeTemplateInput struct {
+	Subject         string
+	Header          string
+	Body            string
+	Form            string
+	Value           string
+	ClickSuggestion string
+	Button          string
+	Link            string
+	Annotation      string
+}
+
+func (b Builder) newLoginCodeTemplateInput(code, link string, language lang.Language) (loginCodeTemplateInput, error) {
+	content := b.localesDict.LoginCode
+	input, err := b.newEmailWithButtonTemplateInput(content.emailWithButtonContent, language)
+	if err != nil {
+		return loginCodeTemplateInput{}, err
+	}
+
+	form, err := content.Form.requestedOrDefaultValue(language)
+	if err != nil {
+		return loginCodeTemplateInput{}, err
+	}
+
+	return loginCodeTemplateInput{
+		Subject:         input.subject,
+		Header:          input.header,
+		Body:            input.body,
+		Form:            form,
+		Value:           code,
+		ClickSuggestion: input.clickSuggestion,
+		Button:          input.button,
+		Link:            link,
+		Annotation:      input.annotation,
+	}, nil
+}
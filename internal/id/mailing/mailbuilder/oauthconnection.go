@@ -2,6 +2,7 @@ package mailbuilder
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/zhuboris/never-expires/internal/id/lang"
 	"github.com/zhuboris/never-expires/internal/id/usr/oauth"
@@ -33,6 +34,28 @@ func (b Builder) newOauthConnectionTemplateInputTemplateInput(language lang.Lang
 	}, nil
 }
 
+func (b Builder) newExternalConnectionTemplateInput(language lang.Language, providerName string) (oauthConnectionTemplateInput, error) {
+	return b.newExternalAccountTemplateInput(b.localesDict.ExternalConnection, language, providerName)
+}
+
+func (b Builder) newExternalDisconnectionTemplateInput(language lang.Language, providerName string) (oauthConnectionTemplateInput, error) {
+	return b.newExternalAccountTemplateInput(b.localesDict.ExternalDisconnection, language, providerName)
+}
+
+func (b Builder) newExternalAccountTemplateInput(content messageEmailContent, language lang.Language, providerName string) (oauthConnectionTemplateInput, error) {
+	input, err := b.newMessageEmailTemplateInput(content, language)
+	if err != nil {
+		return oauthConnectionTemplateInput{}, err
+	}
+
+	return oauthConnectionTemplateInput{
+		Subject:    fmt.Sprintf(input.subject, providerName),
+		Header:     fmt.Sprintf(input.header, providerName),
+		Body:       fmt.Sprintf(input.body, providerName),
+		Annotation: input.annotation,
+	}, nil
+}
+
 func (b Builder) oAuthConnectionContext(connectionType oauth.Type) (messageEmailContent, error) {
 	switch connectionType {
 	case oauth.GoogleAccount:
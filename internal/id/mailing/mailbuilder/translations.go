@@ -9,16 +9,22 @@ import (
 
 type (
 	translationKeys struct {
-		Register         emailWithButtonContent  `json:"register"`
-		ConfirmEmail     emailWithButtonContent  `json:"confirm_email"`
-		ResetPassword    emailWithButtonContent  `json:"reset_password"`
-		NewPassword      newPasswordEmailContent `json:"new_password"`
-		ChangeEmail      emailWithButtonContent  `json:"change_email"`
-		GoogleConnection messageEmailContent     `json:"google_connection"`
-		AppleConnection  messageEmailContent     `json:"apple_connection"`
-		ChangedPassword  messageEmailContent     `json:"changed_password"`
-		NewDevice        newDeviceEmailContent   `json:"new_device"`
-		Annotation       translations            `json:"annotation"`
+		Register              emailWithButtonContent `json:"register"`
+		ConfirmEmail          emailWithButtonContent `json:"confirm_email"`
+		ResetPassword         emailWithButtonContent `json:"reset_password"`
+		ChangeEmail           emailWithButtonContent `json:"change_email"`
+		DataExport            emailWithButtonContent `json:"data_export"`
+		AccountDeletion       emailWithButtonContent `json:"account_deletion"`
+		EmailChangeRequested  emailWithButtonContent `json:"email_change_requested"`
+		GoogleConnection      messageEmailContent    `json:"google_connection"`
+		AppleConnection       messageEmailContent    `json:"apple_connection"`
+		ExternalConnection    messageEmailContent    `json:"external_connection"`
+		ExternalDisconnection messageEmailContent    `json:"external_disconnection"`
+		ChangedPassword       messageEmailContent    `json:"changed_password"`
+		AccountLocked         messageEmailContent    `json:"account_locked"`
+		NewDevice             newDeviceEmailContent  `json:"new_device"`
+		LoginCode             loginCodeEmailContent  `json:"login_code"`
+		Annotation            translations           `json:"annotation"`
 	}
 	emailWithButtonContent struct {
 		messageEmailContent
@@ -26,8 +32,8 @@ type (
 		ClickSuggestion translations `json:"click_suggestion"`
 		Button          translations `json:"button"`
 	}
-	newPasswordEmailContent struct {
-		messageEmailContent
+	loginCodeEmailContent struct {
+		emailWithButtonContent
 
 		Form translations `json:"form"`
 	}
@@ -7,11 +7,13 @@ import (
 	"net/smtp"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
 	"github.com/zhuboris/never-expires/internal/id/mailing/mailmsg"
 	"github.com/zhuboris/never-expires/internal/shared/str"
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
 	"github.com/zhuboris/never-expires/internal/shared/try"
 )
 
@@ -21,24 +23,29 @@ const timeLogKey = "elapsedTime"
 
 var errSMPTConnectionRefused = errors.New("cannot connect to given SMPT server")
 
+type sendDurationObserver interface {
+	Observe(duration time.Duration, labelValues ...string)
+}
+
 type SmtpClient struct {
-	client    *smtp.Client
-	config    Config
-	tlsConfig *tls.Config
-	from      string
-	logger    *zap.Logger
+	client       *smtp.Client
+	config       Config
+	tlsConfig    *tls.Config
+	from         string
+	sendDuration sendDurationObserver
+	logger       *zap.Logger
 }
 
 func NewSMTPClient(ctx context.Context, config Config, logger *zap.Logger) (*SmtpClient, error) {
 	tlsConfig := &tls.Config{
 		InsecureSkipVerify: false,
-		ServerName:         config.host,
+		ServerName:         config.Host,
 	}
 
 	smtpClient := &SmtpClient{
 		tlsConfig: tlsConfig,
 		config:    config,
-		from:      config.from,
+		from:      config.From,
 		logger:    logger,
 	}
 
@@ -49,15 +56,26 @@ func NewSMTPClient(ctx context.Context, config Config, logger *zap.Logger) (*Smt
 	return smtpClient, nil
 }
 
+// SetSendDurationHistogram makes the client record duration of every sending attempt labeled by result.
+func (c *SmtpClient) SetSendDurationHistogram(histogram sendDurationObserver) {
+	c.sendDuration = histogram
+}
+
 func (c *SmtpClient) Quit() error {
 	return c.client.Quit()
 }
 
-func (c *SmtpClient) sendEmail(ctx context.Context, messageID string, message mailmsg.Message) error {
+func (c *SmtpClient) sendEmail(ctx context.Context, messageID string, message mailmsg.Message) (err error) {
+	ctx, span := tracing.Start(ctx, "smtp send", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	sendingFunc := func() error {
 		var err error
 		defer func(startTime time.Time) {
 			c.logSending(startTime, messageID, message.Recipient, err)
+			c.observeSending(startTime, err)
 		}(time.Now())
 
 		if err = c.reconnectIfNeeded(); err != nil {
@@ -71,6 +89,19 @@ func (c *SmtpClient) sendEmail(ctx context.Context, messageID string, message ma
 	return try.DoWithAttempts(ctx, sendingFunc, attemptsDelay)
 }
 
+func (c *SmtpClient) observeSending(startTime time.Time, err error) {
+	if c.sendDuration == nil {
+		return
+	}
+
+	result := "success"
+	if err != nil {
+		result = "fail"
+	}
+
+	c.sendDuration.Observe(time.Since(startTime), result)
+}
+
 func (c *SmtpClient) connect() error {
 	var err error
 	defer func(startTime time.Time) {
@@ -82,7 +113,7 @@ func (c *SmtpClient) connect() error {
 		return errors.Join(errSMPTConnectionRefused, err)
 	}
 
-	client, err := smtp.NewClient(conn, c.config.host)
+	client, err := smtp.NewClient(conn, c.config.Host)
 	if err != nil {
 		return errors.Join(errSMPTConnectionRefused, err)
 	}
@@ -6,7 +6,7 @@ import (
 	"time"
 
 	"github.com/zhuboris/never-expires/internal/id/mailing/mailmsg"
-	"github.com/zhuboris/never-expires/internal/id/mailing/rabbitmq"
+	"github.com/zhuboris/never-expires/internal/shared/rabbitmq"
 )
 
 type (
@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS disable_previous_users_email ON emails;
+DROP FUNCTION IF EXISTS disable_users_emails();
+
+DROP TABLE IF EXISTS users_to_delete;
+DROP TABLE IF EXISTS password_restoration_tokens;
+DROP TABLE IF EXISTS apple_refresh_tokens;
+DROP TABLE IF EXISTS apple_ids;
+DROP TABLE IF EXISTS google_ids;
+DROP TABLE IF EXISTS mail_confirmation_tokens;
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS passwords;
+DROP TABLE IF EXISTS emails;
+DROP TABLE IF EXISTS users;
@@ -85,11 +85,11 @@ BEGIN
 END;
 $$ LANGUAGE plpgsql;
 
-CREATE TRIGGER disable_previous_users_email
+CREATE OR REPLACE TRIGGER disable_previous_users_email
 BEFORE INSERT ON emails
 FOR EACH ROW
 EXECUTE FUNCTION disable_users_emails();
 
-CREATE UNIQUE INDEX active_email_per_user
+CREATE UNIQUE INDEX IF NOT EXISTS active_email_per_user
 ON emails (owner_id)
 WHERE is_active;
\ No newline at end of file
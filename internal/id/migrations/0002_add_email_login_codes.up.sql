@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS email_login_codes(
+    token VARCHAR PRIMARY KEY,
+    email VARCHAR NOT NULL,
+    code_hash VARCHAR NOT NULL,
+    failed_attempts INT DEFAULT 0,
+    is_used BOOLEAN DEFAULT FALSE,
+    expiration timestamptz NOT NULL,
+
+    CONSTRAINT email_fk FOREIGN KEY (email) REFERENCES emails(email) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS email_login_codes_email
+ON email_login_codes (email);
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS webauthn_credentials(
+    id BYTEA PRIMARY KEY,
+    user_id UUID NOT NULL,
+    credential JSONB NOT NULL,
+    created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+    last_used_at timestamptz,
+
+    CONSTRAINT id_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS webauthn_credentials_user_id
+ON webauthn_credentials (user_id);
+
+CREATE TABLE IF NOT EXISTS webauthn_ceremonies(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID,
+    session_data JSONB NOT NULL,
+    expiration timestamptz NOT NULL,
+
+    CONSTRAINT id_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS webauthn_ceremonies_expiration
+ON webauthn_ceremonies (expiration);
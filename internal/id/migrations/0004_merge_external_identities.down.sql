@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS google_ids(
+    user_id UUID PRIMARY KEY,
+    id TEXT UNIQUE NOT NULL,
+
+    CONSTRAINT id_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS apple_ids(
+    user_id UUID PRIMARY KEY,
+    id TEXT UNIQUE NOT NULL,
+
+    CONSTRAINT id_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+INSERT INTO google_ids (user_id, id)
+SELECT user_id, subject FROM external_identities WHERE provider = 'google'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO apple_ids (user_id, id)
+SELECT user_id, subject FROM external_identities WHERE provider = 'apple'
+ON CONFLICT DO NOTHING;
+
+DROP TABLE IF EXISTS external_identities;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS external_identities(
+    provider VARCHAR(50) NOT NULL,
+    subject TEXT NOT NULL,
+    user_id UUID NOT NULL,
+    connected_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (provider, subject),
+    CONSTRAINT one_identity_per_provider UNIQUE (user_id, provider),
+    CONSTRAINT id_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+INSERT INTO external_identities (provider, subject, user_id)
+SELECT 'google', id, user_id FROM google_ids
+ON CONFLICT DO NOTHING;
+
+INSERT INTO external_identities (provider, subject, user_id)
+SELECT 'apple', id, user_id FROM apple_ids
+ON CONFLICT DO NOTHING;
+
+DROP TABLE IF EXISTS google_ids;
+DROP TABLE IF EXISTS apple_ids;
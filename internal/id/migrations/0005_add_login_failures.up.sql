@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS login_failures(
+    scope VARCHAR(20),
+    subject TEXT,
+    failures INT NOT NULL DEFAULT 0,
+    last_failed_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    blocked_until timestamptz,
+
+    PRIMARY KEY (scope, subject)
+);
@@ -0,0 +1 @@
+ALTER TABLE passwords ALTER COLUMN encrypted_password TYPE VARCHAR(100);
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS data_exports(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    language VARCHAR(10) NOT NULL,
+    requested_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    archive BYTEA,
+    download_token VARCHAR(64) UNIQUE,
+    expiration timestamptz,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    failed_at timestamptz,
+    last_error TEXT,
+
+    CONSTRAINT id_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS pending_data_export_per_user
+ON data_exports (user_id)
+WHERE archive IS NULL AND failed_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS data_exports_due
+ON data_exports (next_attempt_at)
+WHERE archive IS NULL AND failed_at IS NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS users_to_delete_delete_after;
+
+ALTER TABLE users_to_delete DROP COLUMN IF EXISTS delete_after;
+ALTER TABLE users_to_delete DROP COLUMN IF EXISTS restore_token;
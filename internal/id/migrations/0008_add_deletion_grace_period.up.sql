@@ -0,0 +1,5 @@
+ALTER TABLE users_to_delete ADD COLUMN IF NOT EXISTS restore_token VARCHAR UNIQUE;
+ALTER TABLE users_to_delete ADD COLUMN IF NOT EXISTS delete_after timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS users_to_delete_delete_after
+ON users_to_delete (delete_after);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS user_deletion_deliveries (
+    user_id UUID NOT NULL,
+    subscriber VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    PRIMARY KEY (user_id, subscriber)
+);
+
+CREATE INDEX IF NOT EXISTS user_deletion_deliveries_due
+ON user_deletion_deliveries (subscriber, next_attempt_at)
+WHERE status = 'pending';
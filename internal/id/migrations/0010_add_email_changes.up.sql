@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS email_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    old_email VARCHAR NOT NULL,
+    new_email VARCHAR NOT NULL,
+    confirm_token VARCHAR UNIQUE NOT NULL,
+    confirm_expiration timestamptz NOT NULL,
+    revert_token VARCHAR UNIQUE NOT NULL,
+    revert_expiration timestamptz NOT NULL,
+    confirmed_at timestamptz,
+    reverted_at timestamptz,
+
+    CONSTRAINT id_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS email_changes_user_id
+ON email_changes (user_id);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS user_profiles (
+    user_id UUID PRIMARY KEY,
+    language VARCHAR(10),
+    time_zone VARCHAR(64),
+    avatar_content_type VARCHAR(20),
+    marketing_opt_in BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT id_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS admin_audit_log;
+DROP TABLE IF EXISTS admin_roles;
+DROP TABLE IF EXISTS disabled_users;
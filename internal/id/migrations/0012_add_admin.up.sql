@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS disabled_users (
+    user_id UUID PRIMARY KEY,
+    disabled_by UUID NOT NULL,
+    disabled_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT id_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS admin_roles (
+    user_id UUID PRIMARY KEY,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('support', 'admin')),
+    granted_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT id_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id UUID NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    target_user_id UUID,
+    details TEXT,
+    error TEXT,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS admin_audit_log_target
+ON admin_audit_log (target_user_id, created_at);
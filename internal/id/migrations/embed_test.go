@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhuboris/never-expires/internal/shared/migration"
+)
+
+func TestFS(t *testing.T) {
+	migrations, err := migration.Load(FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, m := range migrations {
+		assert.Equal(t, i+1, m.Version, "migration versions must have no gaps")
+		assert.NotEmpty(t, m.Down, "migration %s must be reversible", m)
+	}
+}
@@ -0,0 +1,40 @@
+package passkey
+
+import (
+	"strings"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+type Config struct {
+	RPID          string   `env:"WEBAUTHN_RP_ID" required:"true"`
+	RPDisplayName string   `env:"WEBAUTHN_RP_DISPLAY_NAME" default:"Never Expires"`
+	RPOrigins     []string `env:"WEBAUTHN_RP_ORIGINS" required:"true"`
+}
+
+func newWebAuthnConfig(rpID, displayName string, origins []string) *webauthn.Config {
+	for i := range origins {
+		origins[i] = strings.TrimSpace(origins[i])
+	}
+
+	return &webauthn.Config{
+		RPID:          rpID,
+		RPDisplayName: displayName,
+		RPOrigins:     origins,
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			ResidentKey:      protocol.ResidentKeyRequirementRequired,
+			UserVerification: protocol.VerificationRequired,
+		},
+		Timeouts: webauthn.TimeoutsConfig{
+			Login: webauthn.TimeoutConfig{
+				Enforce: true,
+				Timeout: ceremonyLifetime,
+			},
+			Registration: webauthn.TimeoutConfig{
+				Enforce: true,
+				Timeout: ceremonyLifetime,
+			},
+		},
+	}
+}
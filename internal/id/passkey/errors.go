@@ -0,0 +1,9 @@
+package passkey
+
+import "errors"
+
+var (
+	ErrCeremonyNotFound   = errors.New("passkey ceremony not found or expired")
+	ErrCredentialNotFound = errors.New("passkey credential not found")
+	ErrVerificationFailed = errors.New("passkey verification failed")
+)
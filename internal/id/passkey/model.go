@@ -0,0 +1,63 @@
+package passkey
+
+import (
+	"time"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const ceremonyLifetime = 5 * time.Minute
+
+type Owner struct {
+	ID          pgtype.UUID
+	Name        string
+	DisplayName string
+}
+
+type Ceremony struct {
+	ID      pgtype.UUID `json:"ceremony_id"`
+	Options any         `json:"options"`
+}
+
+type ceremony struct {
+	ownerID pgtype.UUID
+	session webauthn.SessionData
+}
+
+type user struct {
+	Owner
+
+	credentials []webauthn.Credential
+}
+
+func (u user) WebAuthnID() []byte {
+	return u.ID.Bytes[:]
+}
+
+func (u user) WebAuthnName() string {
+	return u.Name
+}
+
+func (u user) WebAuthnDisplayName() string {
+	return u.DisplayName
+}
+
+func (u user) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
+
+func (u user) WebAuthnIcon() string {
+	return ""
+}
+
+func userIDFromHandle(userHandle []byte) (pgtype.UUID, error) {
+	var id pgtype.UUID
+	if len(userHandle) != len(id.Bytes) {
+		return id, ErrCredentialNotFound
+	}
+
+	copy(id.Bytes[:], userHandle)
+	id.Valid = true
+	return id, nil
+}
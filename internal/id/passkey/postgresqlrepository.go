@@ -0,0 +1,125 @@
+package passkey
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zhuboris/never-expires/internal/shared/postgresql"
+)
+
+type PostgresqlRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresqlRepository(pool *pgxpool.Pool) (*PostgresqlRepository, error) {
+	if pool == nil {
+		return nil, postgresql.ErrPoolInitRequired
+	}
+
+	return &PostgresqlRepository{
+		pool: pool,
+	}, nil
+}
+
+func (r PostgresqlRepository) Ping(ctx context.Context) error {
+	return r.pool.Ping(ctx)
+}
+
+// saveCeremony also removes expired ceremonies, they are never finished, so the table does not grow with abandoned ones.
+func (r PostgresqlRepository) saveCeremony(ctx context.Context, ownerID pgtype.UUID, session webauthn.SessionData) (pgtype.UUID, error) {
+	const sql = `
+		WITH expired AS (
+		    DELETE FROM webauthn_ceremonies
+		    WHERE expiration <= CURRENT_TIMESTAMP
+		)
+		INSERT INTO webauthn_ceremonies (user_id, session_data, expiration)
+		VALUES ($1, $2, $3)
+
+		RETURNING id;
+	`
+
+	var id pgtype.UUID
+	err := r.pool.QueryRow(ctx, sql, ownerID, session, session.Expires).
+		Scan(&id)
+
+	return id, postgresql.HandleQueryErr(err)
+}
+
+func (r PostgresqlRepository) popCeremony(ctx context.Context, id pgtype.UUID) (ceremony, error) {
+	const sql = `
+		DELETE FROM webauthn_ceremonies
+		WHERE id = $1
+		AND expiration > CURRENT_TIMESTAMP
+
+		RETURNING user_id, session_data;
+	`
+
+	var result ceremony
+	err := r.pool.QueryRow(ctx, sql, id).
+		Scan(&result.ownerID, &result.session)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ceremony{}, errors.Join(ErrCeremonyNotFound, err)
+	}
+
+	return result, postgresql.HandleQueryErr(err)
+}
+
+func (r PostgresqlRepository) credentials(ctx context.Context, userID pgtype.UUID) ([]webauthn.Credential, error) {
+	const sql = `
+		SELECT credential FROM webauthn_credentials
+		WHERE user_id = $1;
+	`
+
+	rows, err := r.pool.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, postgresql.HandleQueryErr(err)
+	}
+
+	credentials := make([]webauthn.Credential, 0)
+	for rows.Next() {
+		var credential webauthn.Credential
+		if scanError := rows.Scan(&credential); scanError != nil {
+			err = errors.Join(scanError, err)
+			continue
+		}
+
+		credentials = append(credentials, credential)
+	}
+
+	return credentials, postgresql.HandleQueryErr(err)
+}
+
+func (r PostgresqlRepository) addCredential(ctx context.Context, userID pgtype.UUID, credential webauthn.Credential) error {
+	const sql = `
+		INSERT INTO webauthn_credentials (id, user_id, credential)
+		VALUES ($1, $2, $3);
+	`
+
+	_, err := r.pool.Exec(ctx, sql, credential.ID, userID, credential)
+	return postgresql.CheckErrorForUniqueViolation(err)
+}
+
+func (r PostgresqlRepository) updateCredential(ctx context.Context, credential webauthn.Credential) error {
+	const sql = `
+		UPDATE webauthn_credentials
+		SET credential = $2,
+			last_used_at = CURRENT_TIMESTAMP
+		WHERE id = $1;
+	`
+
+	result, err := r.pool.Exec(ctx, sql, credential.ID, credential)
+	if err != nil {
+		return postgresql.HandleQueryErr(err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrCredentialNotFound
+	}
+
+	return nil
+}
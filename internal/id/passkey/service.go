@@ -0,0 +1,171 @@
+package passkey
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type repository interface {
+	saveCeremony(ctx context.Context, ownerID pgtype.UUID, session webauthn.SessionData) (pgtype.UUID, error)
+	popCeremony(ctx context.Context, id pgtype.UUID) (ceremony, error)
+	credentials(ctx context.Context, userID pgtype.UUID) ([]webauthn.Credential, error)
+	addCredential(ctx context.Context, userID pgtype.UUID, credential webauthn.Credential) error
+	updateCredential(ctx context.Context, credential webauthn.Credential) error
+	Ping(ctx context.Context) error
+}
+
+type Service struct {
+	webAuthn *webauthn.WebAuthn
+	repo     repository
+}
+
+func NewService(repo repository, config Config) (*Service, error) {
+	return newService(repo, newWebAuthnConfig(config.RPID, config.RPDisplayName, config.RPOrigins))
+}
+
+func newService(repo repository, config *webauthn.Config) (*Service, error) {
+	webAuthn, err := webauthn.New(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		webAuthn: webAuthn,
+		repo:     repo,
+	}, nil
+}
+
+func (s Service) BeginRegistration(ctx context.Context, owner Owner) (Ceremony, error) {
+	credentials, err := s.repo.credentials(ctx, owner.ID)
+	if err != nil {
+		return Ceremony{}, err
+	}
+
+	registeringUser := user{
+		Owner:       owner,
+		credentials: credentials,
+	}
+
+	creation, session, err := s.webAuthn.BeginRegistration(registeringUser,
+		webauthn.WithExclusions(descriptors(credentials)),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+	)
+	if err != nil {
+		return Ceremony{}, err
+	}
+
+	id, err := s.repo.saveCeremony(ctx, owner.ID, *session)
+	if err != nil {
+		return Ceremony{}, err
+	}
+
+	return Ceremony{
+		ID:      id,
+		Options: creation,
+	}, nil
+}
+
+func (s Service) FinishRegistration(ctx context.Context, ownerID, ceremonyID pgtype.UUID, response io.Reader) error {
+	started, err := s.repo.popCeremony(ctx, ceremonyID)
+	if err != nil {
+		return err
+	}
+
+	if started.ownerID != ownerID {
+		return ErrCeremonyNotFound
+	}
+
+	parsedResponse, err := protocol.ParseCredentialCreationResponseBody(response)
+	if err != nil {
+		return errors.Join(ErrVerificationFailed, err)
+	}
+
+	registeringUser := user{
+		Owner: Owner{ID: ownerID},
+	}
+
+	credential, err := s.webAuthn.CreateCredential(registeringUser, started.session, parsedResponse)
+	if err != nil {
+		return errors.Join(ErrVerificationFailed, err)
+	}
+
+	return s.repo.addCredential(ctx, ownerID, *credential)
+}
+
+func (s Service) BeginLogin(ctx context.Context) (Ceremony, error) {
+	assertion, session, err := s.webAuthn.BeginDiscoverableLogin()
+	if err != nil {
+		return Ceremony{}, err
+	}
+
+	id, err := s.repo.saveCeremony(ctx, pgtype.UUID{}, *session)
+	if err != nil {
+		return Ceremony{}, err
+	}
+
+	return Ceremony{
+		ID:      id,
+		Options: assertion,
+	}, nil
+}
+
+// FinishLogin validates the assertion of a discoverable credential and returns its owner ID.
+func (s Service) FinishLogin(ctx context.Context, ceremonyID pgtype.UUID, response io.Reader) (pgtype.UUID, error) {
+	started, err := s.repo.popCeremony(ctx, ceremonyID)
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+
+	parsedResponse, err := protocol.ParseCredentialRequestResponseBody(response)
+	if err != nil {
+		return pgtype.UUID{}, errors.Join(ErrVerificationFailed, err)
+	}
+
+	var ownerID pgtype.UUID
+	findOwner := func(rawID, userHandle []byte) (webauthn.User, error) {
+		id, err := userIDFromHandle(userHandle)
+		if err != nil {
+			return nil, err
+		}
+
+		credentials, err := s.repo.credentials(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		ownerID = id
+		return user{
+			Owner:       Owner{ID: id},
+			credentials: credentials,
+		}, nil
+	}
+
+	credential, err := s.webAuthn.ValidateDiscoverableLogin(findOwner, started.session, parsedResponse)
+	if err != nil {
+		return pgtype.UUID{}, errors.Join(ErrVerificationFailed, err)
+	}
+
+	if credential.Authenticator.CloneWarning {
+		return pgtype.UUID{}, errors.Join(ErrVerificationFailed, errors.New("signature counter indicates cloned authenticator"))
+	}
+
+	return ownerID, s.repo.updateCredential(ctx, *credential)
+}
+
+func (s Service) Ping(ctx context.Context) error {
+	return s.repo.Ping(ctx)
+}
+
+func descriptors(credentials []webauthn.Credential) []protocol.CredentialDescriptor {
+	result := make([]protocol.CredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		result = append(result, credential.Descriptor())
+	}
+
+	return result
+}
@@ -0,0 +1,252 @@
+package passkey
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRPID   = "never-expires.com"
+	testOrigin = "https://never-expires.com"
+)
+
+func TestService_Registration(t *testing.T) {
+	owner := Owner{
+		ID:          newTestUUID(),
+		Name:        "user@test.com",
+		DisplayName: "user",
+	}
+
+	tests := []struct {
+		name            string
+		origin          string
+		finishingUserID pgtype.UUID
+		wantCredential  bool
+		expectedError   error
+	}{
+		{
+			name:            "successful registration",
+			origin:          testOrigin,
+			finishingUserID: owner.ID,
+			wantCredential:  true,
+		},
+		{
+			name:            "finished by other user",
+			origin:          testOrigin,
+			finishingUserID: newTestUUID(),
+			expectedError:   ErrCeremonyNotFound,
+		},
+		{
+			name:            "unexpected origin",
+			origin:          "https://evil.com",
+			finishingUserID: owner.ID,
+			expectedError:   ErrVerificationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newInMemoryRepo(t)
+			service := arrangeService(t, repo)
+			authenticator := newSoftAuthenticator(t, tt.origin)
+
+			started, err := service.BeginRegistration(context.Background(), owner)
+			require.NoError(t, err, "begin registration error")
+
+			response := authenticator.create(t, started.Options)
+			err = service.FinishRegistration(context.Background(), tt.finishingUserID, started.ID, bytes.NewReader(response))
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			stored := repo.stored[owner.ID]
+			if !tt.wantCredential {
+				assert.Empty(t, stored, "credential must not be saved")
+				return
+			}
+
+			require.Len(t, stored, 1, "credential was not saved")
+			assert.Equal(t, authenticator.credentialID, stored[0].ID)
+		})
+	}
+}
+
+func TestService_Login(t *testing.T) {
+	owner := Owner{
+		ID:          newTestUUID(),
+		Name:        "user@test.com",
+		DisplayName: "user",
+	}
+
+	tests := []struct {
+		name          string
+		arrange       func(t *testing.T, authenticator *softAuthenticator)
+		replay        bool
+		expectedError error
+	}{
+		{
+			name: "successful login",
+		},
+		{
+			name: "credential is not registered",
+			arrange: func(t *testing.T, authenticator *softAuthenticator) {
+				authenticator.credentialID = []byte("unknown credential")
+			},
+			expectedError: ErrVerificationFailed,
+		},
+		{
+			name: "user handle of other user",
+			arrange: func(t *testing.T, authenticator *softAuthenticator) {
+				other := newTestUUID()
+				authenticator.userHandle = other.Bytes[:]
+			},
+			expectedError: ErrVerificationFailed,
+		},
+		{
+			name:          "ceremony is replayed",
+			replay:        true,
+			expectedError: ErrCeremonyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newInMemoryRepo(t)
+			service := arrangeService(t, repo)
+			authenticator := newSoftAuthenticator(t, testOrigin)
+			arrangeRegisteredCredential(t, service, owner, authenticator)
+
+			if tt.arrange != nil {
+				tt.arrange(t, authenticator)
+			}
+
+			started, err := service.BeginLogin(context.Background())
+			require.NoError(t, err, "begin login error")
+
+			response := authenticator.get(t, started.Options)
+			if tt.replay {
+				_, err := service.FinishLogin(context.Background(), started.ID, bytes.NewReader(response))
+				require.NoError(t, err, "first login error")
+			}
+
+			userID, err := service.FinishLogin(context.Background(), started.ID, bytes.NewReader(response))
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, owner.ID, userID)
+			assert.Equal(t, authenticator.signCount, repo.stored[owner.ID][0].Authenticator.SignCount, "sign count was not updated")
+		})
+	}
+}
+
+type inMemoryRepo struct {
+	*Mockrepository
+
+	ceremonies map[pgtype.UUID]ceremony
+	stored     map[pgtype.UUID][]webauthn.Credential
+}
+
+func newInMemoryRepo(t *testing.T) *inMemoryRepo {
+	repo := &inMemoryRepo{
+		Mockrepository: NewMockrepository(t),
+		ceremonies:     make(map[pgtype.UUID]ceremony),
+		stored:         make(map[pgtype.UUID][]webauthn.Credential),
+	}
+
+	repo.EXPECT().
+		saveCeremony(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, ownerID pgtype.UUID, session webauthn.SessionData) (pgtype.UUID, error) {
+			id := newTestUUID()
+			repo.ceremonies[id] = ceremony{ownerID: ownerID, session: session}
+			return id, nil
+		}).
+		Maybe()
+
+	repo.EXPECT().
+		popCeremony(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, id pgtype.UUID) (ceremony, error) {
+			started, ok := repo.ceremonies[id]
+			if !ok {
+				return ceremony{}, ErrCeremonyNotFound
+			}
+
+			delete(repo.ceremonies, id)
+			return started, nil
+		}).
+		Maybe()
+
+	repo.EXPECT().
+		credentials(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, userID pgtype.UUID) ([]webauthn.Credential, error) {
+			return repo.stored[userID], nil
+		}).
+		Maybe()
+
+	repo.EXPECT().
+		addCredential(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, userID pgtype.UUID, credential webauthn.Credential) error {
+			repo.stored[userID] = append(repo.stored[userID], credential)
+			return nil
+		}).
+		Maybe()
+
+	repo.EXPECT().
+		updateCredential(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, credential webauthn.Credential) error {
+			for userID, credentials := range repo.stored {
+				for i := range credentials {
+					if bytes.Equal(credentials[i].ID, credential.ID) {
+						repo.stored[userID][i] = credential
+						return nil
+					}
+				}
+			}
+
+			return ErrCredentialNotFound
+		}).
+		Maybe()
+
+	return repo
+}
+
+func arrangeService(t *testing.T, repo repository) *Service {
+	t.Helper()
+
+	config := newWebAuthnConfig(testRPID, "Never Expires", []string{testOrigin})
+	service, err := newService(repo, config)
+	require.NoError(t, err, "creating service error")
+	return service
+}
+
+func arrangeRegisteredCredential(t *testing.T, service *Service, owner Owner, authenticator *softAuthenticator) {
+	t.Helper()
+
+	started, err := service.BeginRegistration(context.Background(), owner)
+	require.NoError(t, err, "begin registration error")
+
+	response := authenticator.create(t, started.Options)
+	err = service.FinishRegistration(context.Background(), owner.ID, started.ID, bytes.NewReader(response))
+	require.NoError(t, err, "finish registration error")
+}
+
+func newTestUUID() pgtype.UUID {
+	return pgtype.UUID{
+		Bytes: uuid.New(),
+		Valid: true,
+	}
+}
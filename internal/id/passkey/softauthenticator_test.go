@@ -0,0 +1,153 @@
+package passkey
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	flagUserPresent  byte = 0x01
+	flagUserVerified byte = 0x04
+	flagAttestedData byte = 0x40
+)
+
+// softAuthenticator is an in-memory ES256 authenticator that produces
+// responses the way a platform authenticator does for a discoverable credential.
+type softAuthenticator struct {
+	origin       string
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	userHandle   []byte
+	signCount    uint32
+}
+
+func newSoftAuthenticator(t *testing.T, origin string) *softAuthenticator {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err, "generating key error")
+
+	credentialID := make([]byte, 32)
+	_, err = rand.Read(credentialID)
+	require.NoError(t, err, "generating credential id error")
+
+	return &softAuthenticator{
+		origin:       origin,
+		key:          key,
+		credentialID: credentialID,
+	}
+}
+
+func (a *softAuthenticator) create(t *testing.T, options any) []byte {
+	t.Helper()
+
+	creation, ok := options.(*protocol.CredentialCreation)
+	require.True(t, ok, "unexpected registration options type")
+
+	userHandle, ok := creation.Response.User.ID.(protocol.URLEncodedBase64)
+	require.True(t, ok, "unexpected user id type")
+	a.userHandle = userHandle
+
+	clientData := a.clientData(t, "webauthn.create", creation.Response.Challenge)
+	authData := a.authData(creation.Response.RelyingParty.ID, flagUserPresent|flagUserVerified|flagAttestedData)
+	authData = append(authData, a.attestedCredentialData(t)...)
+
+	attestationObject, err := cbor.Marshal(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": authData,
+	})
+	require.NoError(t, err, "encoding attestation object error")
+
+	return a.marshalResponse(t, map[string]string{
+		"clientDataJSON":    encode(clientData),
+		"attestationObject": encode(attestationObject),
+	})
+}
+
+func (a *softAuthenticator) get(t *testing.T, options any) []byte {
+	t.Helper()
+
+	assertion, ok := options.(*protocol.CredentialAssertion)
+	require.True(t, ok, "unexpected login options type")
+
+	a.signCount++
+	clientData := a.clientData(t, "webauthn.get", assertion.Response.Challenge)
+	authData := a.authData(assertion.Response.RelyingPartyID, flagUserPresent|flagUserVerified)
+
+	clientDataHash := sha256.Sum256(clientData)
+	signed := sha256.Sum256(append(authData, clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, signed[:])
+	require.NoError(t, err, "signing assertion error")
+
+	return a.marshalResponse(t, map[string]string{
+		"clientDataJSON":    encode(clientData),
+		"authenticatorData": encode(authData),
+		"signature":         encode(signature),
+		"userHandle":        encode(a.userHandle),
+	})
+}
+
+func (a *softAuthenticator) clientData(t *testing.T, ceremonyType string, challenge protocol.URLEncodedBase64) []byte {
+	t.Helper()
+
+	clientData, err := json.Marshal(map[string]string{
+		"type":      ceremonyType,
+		"challenge": encode(challenge),
+		"origin":    a.origin,
+	})
+	require.NoError(t, err, "encoding client data error")
+	return clientData
+}
+
+func (a *softAuthenticator) authData(rpID string, flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+
+	result := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(result, a.signCount)
+}
+
+func (a *softAuthenticator) attestedCredentialData(t *testing.T) []byte {
+	t.Helper()
+
+	publicKey, err := cbor.Marshal(map[int]any{
+		1:  2,  // kty: EC2
+		3:  -7, // alg: ES256
+		-1: 1,  // crv: P-256
+		-2: a.key.PublicKey.X.FillBytes(make([]byte, 32)),
+		-3: a.key.PublicKey.Y.FillBytes(make([]byte, 32)),
+	})
+	require.NoError(t, err, "encoding public key error")
+
+	result := make([]byte, 16) // zero AAGUID
+	result = binary.BigEndian.AppendUint16(result, uint16(len(a.credentialID)))
+	result = append(result, a.credentialID...)
+	return append(result, publicKey...)
+}
+
+func (a *softAuthenticator) marshalResponse(t *testing.T, response map[string]string) []byte {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{
+		"id":       encode(a.credentialID),
+		"rawId":    encode(a.credentialID),
+		"type":     "public-key",
+		"response": response,
+	})
+	require.NoError(t, err, "encoding response error")
+	return body
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
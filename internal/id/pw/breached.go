@@ -0,0 +1,133 @@
+package pw
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	sha1HexLength    = 40
+	hashPrefixLength = 5
+)
+
+// OpenBreachedDB opens k-anonymity database of breached passwords in HIBP format, where every line is
+// uppercase SHA-1 hash (or its suffix) and optional count separated by colon.
+// Path is either a directory with range files named by 5 chars hash prefix, as made by HIBP downloader,
+// or a single dump file with full hashes that is loaded into memory.
+func OpenBreachedDB(path string) (BreachedChecker, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Join(errInvalidBreachedDB, err)
+	}
+
+	if info.IsDir() {
+		return hashRangeDir{dir: path}, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Join(errInvalidBreachedDB, err)
+	}
+
+	defer file.Close()
+
+	ranges, err := loadHashRanges(file)
+	if err != nil {
+		return nil, errors.Join(errInvalidBreachedDB, err)
+	}
+
+	return ranges, nil
+}
+
+type hashRangeDir struct {
+	dir string
+}
+
+func (d hashRangeDir) IsBreached(password string) (bool, error) {
+	prefix, suffix := hashParts(password)
+
+	file, err := os.Open(filepath.Join(d.dir, prefix+".txt"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, errors.Join(errInvalidBreachedDB, err)
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if strings.EqualFold(hashFromLine(scanner.Text()), suffix) {
+			return true, nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return false, errors.Join(errInvalidBreachedDB, err)
+	}
+
+	return false, nil
+}
+
+// hashRanges contains sorted hash suffixes by their prefixes.
+type hashRanges map[string][]string
+
+func loadHashRanges(r io.Reader) (hashRanges, error) {
+	ranges := make(hashRanges)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		hash := strings.ToUpper(hashFromLine(scanner.Text()))
+		if hash == "" {
+			continue
+		}
+
+		if len(hash) != sha1HexLength {
+			return nil, fmt.Errorf("line %d does not contain SHA-1 hash", line)
+		}
+
+		prefix, suffix := hash[:hashPrefixLength], hash[hashPrefixLength:]
+		ranges[prefix] = append(ranges[prefix], suffix)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, suffixes := range ranges {
+		sort.Strings(suffixes)
+	}
+
+	return ranges, nil
+}
+
+func (r hashRanges) IsBreached(password string) (bool, error) {
+	prefix, suffix := hashParts(password)
+	suffixes := r[prefix]
+
+	i := sort.SearchStrings(suffixes, suffix)
+	return i < len(suffixes) && suffixes[i] == suffix, nil
+}
+
+func hashParts(password string) (prefix, suffix string) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	return hash[:hashPrefixLength], hash[hashPrefixLength:]
+}
+
+func hashFromLine(line string) string {
+	hash, _, _ := strings.Cut(line, ":")
+	return strings.TrimSpace(hash)
+}
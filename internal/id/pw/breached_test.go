@@ -0,0 +1,78 @@
+package pw
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8.
+const (
+	breachedPassword       = "password"
+	breachedPasswordPrefix = "5BAA6"
+	breachedPasswordSuffix = "1E4C9B93F3F0682250B6CF8331B7EE68FD8"
+)
+
+func TestOpenBreachedDB(t *testing.T) {
+	dir := t.TempDir()
+	rangeDir := filepath.Join(dir, "ranges")
+	require.NoError(t, os.Mkdir(rangeDir, 0o700))
+
+	rangeFile := "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n" + breachedPasswordSuffix + ":9545824\r\n"
+	require.NoError(t, os.WriteFile(filepath.Join(rangeDir, breachedPasswordPrefix+".txt"), []byte(rangeFile), 0o600))
+
+	dumpPath := filepath.Join(dir, "dump.txt")
+	dump := "000000005AD76BD555C1D6D771DE417A4B87E4B4:10\n" + breachedPasswordPrefix + breachedPasswordSuffix + ":9545824\n"
+	require.NoError(t, os.WriteFile(dumpPath, []byte(dump), 0o600))
+
+	invalidDumpPath := filepath.Join(dir, "invalid.txt")
+	require.NoError(t, os.WriteFile(invalidDumpPath, []byte("not a hash:1\n"), 0o600))
+
+	tests := []struct {
+		name      string
+		path      string
+		wantError bool
+	}{
+		{
+			name: "directory with hash ranges",
+			path: rangeDir,
+		},
+		{
+			name: "dump file",
+			path: dumpPath,
+		},
+		{
+			name:      "dump file with invalid line",
+			path:      invalidDumpPath,
+			wantError: true,
+		},
+		{
+			name:      "not existing path",
+			path:      filepath.Join(dir, "missing"),
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := OpenBreachedDB(tt.path)
+			if tt.wantError {
+				assert.ErrorIs(t, err, errInvalidBreachedDB)
+				return
+			}
+
+			require.NoError(t, err)
+
+			isBreached, err := db.IsBreached(breachedPassword)
+			require.NoError(t, err)
+			assert.True(t, isBreached, "breached password is not found")
+
+			isBreached, err = db.IsBreached("Unique-Passw0rd-" + t.Name())
+			require.NoError(t, err)
+			assert.False(t, isBreached, "not breached password is found")
+		})
+	}
+}
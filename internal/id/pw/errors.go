@@ -5,7 +5,23 @@ import (
 	"fmt"
 )
 
+// Rule names the policy rule that the password failed.
+type Rule string
+
+const (
+	RuleMinLength    Rule = "min_length"
+	RuleMaxLength    Rule = "max_length"
+	RuleAllowedChars Rule = "allowed_chars"
+	RuleUpper        Rule = "upper"
+	RuleLower        Rule = "lower"
+	RuleNumber       Rule = "number"
+	RuleSymbol       Rule = "symbol"
+	RuleBannedWord   Rule = "banned_word"
+	RuleBreached     Rule = "breached"
+)
+
 type InsecurePasswordError struct {
+	rule   Rule
 	reason string
 }
 
@@ -17,12 +33,33 @@ func (e InsecurePasswordError) Reason() string {
 	return e.reason
 }
 
+func (e InsecurePasswordError) Rule() Rule {
+	return e.rule
+}
+
 var ErrWrongPassword = errors.New("wrong password")
 
 var (
-	errTooShort               = InsecurePasswordError{"the password must contain at least 8 symbols"}
-	errContainsNotAllowedChar = InsecurePasswordError{"the password can only contain the following characters: 'A-Z', 'a-z', '0-9', '~`!@#$%^&*()_-+={[}]|\\:;\"'<,>.?/'"}
-	errNoUppers               = InsecurePasswordError{"the password must contain uppercase letter"}
-	errNoLowers               = InsecurePasswordError{"the password must contain lowercase letter"}
-	errNoNumbers              = InsecurePasswordError{"the password must contain numbers"}
+	errASCIIOnly          = InsecurePasswordError{RuleAllowedChars, "the password can only contain the following characters: 'A-Z', 'a-z', '0-9', '~`!@#$%^&*()_-+={[}]|\\:;\"'<,>.?/'"}
+	errNotAllowedChar     = InsecurePasswordError{RuleAllowedChars, "the password can only contain letters, numbers, spaces, punctuation and symbols"}
+	errNoUppers           = InsecurePasswordError{RuleUpper, "the password must contain uppercase letter"}
+	errNoLowers           = InsecurePasswordError{RuleLower, "the password must contain lowercase letter"}
+	errNoNumbers          = InsecurePasswordError{RuleNumber, "the password must contain numbers"}
+	errNoSymbols          = InsecurePasswordError{RuleSymbol, "the password must contain punctuation or symbol"}
+	errContainsBannedWord = InsecurePasswordError{RuleBannedWord, "the password contains a commonly used word or the name of the service"}
+	errBreached           = InsecurePasswordError{RuleBreached, "the password has appeared in a data breach, choose another one"}
+)
+
+var (
+	errInvalidPolicy     = errors.New("invalid password policy configuration")
+	errInvalidBreachedDB = errors.New("invalid breached passwords database")
+	errInvalidHashParams = errors.New("invalid password hashing parameters")
 )
+
+func errTooShort(minLength int) InsecurePasswordError {
+	return InsecurePasswordError{RuleMinLength, fmt.Sprintf("the password must contain at least %d symbols", minLength)}
+}
+
+func errTooLong(maxLength int) InsecurePasswordError {
+	return InsecurePasswordError{RuleMaxLength, fmt.Sprintf("the password must contain at most %d symbols", maxLength)}
+}
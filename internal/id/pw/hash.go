@@ -0,0 +1,155 @@
+package pw
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	argon2idID      = "argon2id"
+	argon2idVersion = argon2.Version
+)
+
+var (
+	errBcrypting         = errors.New("unexpected bcrypting error")
+	errHashing           = errors.New("unexpected password hashing error")
+	errUnknownHashFormat = errors.New("stored password hash has unknown format")
+)
+
+var activeParams = DefaultArgon2idParams()
+
+// InitHashing replaces parameters used by Hash, it must be called before serving requests.
+func InitHashing(params Argon2idParams) {
+	activeParams = params
+}
+
+// Hash returns password hash in PHC string format made with Argon2id and current parameters.
+func Hash(password string) (string, error) {
+	return activeParams.hash(password)
+}
+
+// Check compares password with stored hash, that can be Argon2id PHC string or legacy bcrypt hash.
+func Check(input, storedHash string) error {
+	if input == "" && storedHash == "" {
+		return nil
+	}
+
+	switch {
+	case isBcryptHash(storedHash):
+		return checkBcrypt(input, storedHash)
+	case strings.HasPrefix(storedHash, "$"+argon2idID+"$"):
+		return checkArgon2id(input, storedHash)
+	default:
+		return errUnknownHashFormat
+	}
+}
+
+// NeedsRehash reports if stored hash was made with other algorithm or parameters than current ones.
+func NeedsRehash(storedHash string) bool {
+	stored, err := parseArgon2idHash(storedHash)
+	if err != nil {
+		return true
+	}
+
+	return stored.params != activeParams
+}
+
+func (p Argon2idParams) hash(password string) (string, error) {
+	salt := make([]byte, p.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.Join(errHashing, err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idID,
+		argon2idVersion,
+		p.Memory,
+		p.Iterations,
+		p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+type argon2idHash struct {
+	params Argon2idParams
+	salt   []byte
+	key    []byte
+}
+
+func parseArgon2idHash(phc string) (argon2idHash, error) {
+	parts := strings.Split(phc, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != argon2idID {
+		return argon2idHash{}, errUnknownHashFormat
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2idVersion {
+		return argon2idHash{}, errUnknownHashFormat
+	}
+
+	var result argon2idHash
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &result.params.Memory, &result.params.Iterations, &result.params.Parallelism); err != nil {
+		return argon2idHash{}, errors.Join(errUnknownHashFormat, err)
+	}
+
+	var err error
+	if result.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return argon2idHash{}, errors.Join(errUnknownHashFormat, err)
+	}
+
+	if result.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return argon2idHash{}, errors.Join(errUnknownHashFormat, err)
+	}
+
+	result.params.SaltLength = uint32(len(result.salt))
+	result.params.KeyLength = uint32(len(result.key))
+	return result, nil
+}
+
+func checkArgon2id(input, storedHash string) error {
+	stored, err := parseArgon2idHash(storedHash)
+	if err != nil {
+		return err
+	}
+
+	p := stored.params
+	key := argon2.IDKey([]byte(input), stored.salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	if subtle.ConstantTimeCompare(key, stored.key) != 1 {
+		return ErrWrongPassword
+	}
+
+	return nil
+}
+
+func isBcryptHash(storedHash string) bool {
+	for _, prefix := range [...]string{"$2a$", "$2b$", "$2y$"} {
+		if strings.HasPrefix(storedHash, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkBcrypt(input, storedHash string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(storedHash), []byte(input))
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+		return ErrWrongPassword
+	default:
+		return errors.Join(errBcrypting, err)
+	}
+}
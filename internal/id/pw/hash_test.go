@@ -0,0 +1,159 @@
+package pw
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const testPassword = "Passw0rd"
+
+func TestHashAndCheck(t *testing.T) {
+	hash, err := Hash(testPassword)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=19456,t=2,p=1$"), "hash %q is not in expected PHC format", hash)
+
+	otherHash, err := Hash(testPassword)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash, "same salt is used")
+
+	legacyHash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		input         string
+		storedHash    string
+		expectedError error
+	}{
+		{
+			name:       "correct password",
+			input:      testPassword,
+			storedHash: hash,
+		},
+		{
+			name:          "wrong password",
+			input:         "Passw0rd1",
+			storedHash:    hash,
+			expectedError: ErrWrongPassword,
+		},
+		{
+			name:       "correct password with legacy bcrypt hash",
+			input:      testPassword,
+			storedHash: string(legacyHash),
+		},
+		{
+			name:          "wrong password with legacy bcrypt hash",
+			input:         "Passw0rd1",
+			storedHash:    string(legacyHash),
+			expectedError: ErrWrongPassword,
+		},
+		{
+			name:          "unknown hash format",
+			input:         testPassword,
+			storedHash:    "$md5$" + testPassword,
+			expectedError: errUnknownHashFormat,
+		},
+		{
+			name:          "malformed argon2id hash",
+			input:         testPassword,
+			storedHash:    "$argon2id$v=19$m=19456,t=2$salt$key",
+			expectedError: errUnknownHashFormat,
+		},
+		{
+			name: "user without password",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.input, tt.storedHash)
+
+			if tt.expectedError == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	currentHash, err := Hash(testPassword)
+	require.NoError(t, err)
+
+	cheaperParams := DefaultArgon2idParams()
+	cheaperParams.Iterations = 1
+	outdatedHash, err := cheaperParams.hash(testPassword)
+	require.NoError(t, err)
+
+	legacyHash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		storedHash string
+		expected   bool
+	}{
+		{
+			name:       "current parameters",
+			storedHash: currentHash,
+			expected:   false,
+		},
+		{
+			name:       "outdated parameters",
+			storedHash: outdatedHash,
+			expected:   true,
+		},
+		{
+			name:       "legacy bcrypt hash",
+			storedHash: string(legacyHash),
+			expected:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NeedsRehash(tt.storedHash))
+		})
+	}
+}
+
+// BenchmarkCheck shows how hashing cost affects latency of password check on login.
+func BenchmarkCheck(b *testing.B) {
+	params := []Argon2idParams{
+		DefaultArgon2idParams(),
+		{Memory: 46 * 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32},
+		{Memory: 64 * 1024, Iterations: 3, Parallelism: 4, SaltLength: 16, KeyLength: 32},
+		{Memory: 128 * 1024, Iterations: 4, Parallelism: 4, SaltLength: 16, KeyLength: 32},
+	}
+
+	for _, p := range params {
+		hash, err := p.hash(testPassword)
+		require.NoError(b, err)
+
+		b.Run(fmt.Sprintf("argon2id m=%dKiB t=%d p=%d", p.Memory, p.Iterations, p.Parallelism), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = Check(testPassword, hash)
+			}
+		})
+	}
+
+	for _, cost := range []int{10, 12} {
+		hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), cost)
+		require.NoError(b, err)
+
+		b.Run(fmt.Sprintf("bcrypt cost=%d", cost), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = Check(testPassword, string(hash))
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package pw
+
+type Argon2idParams struct {
+	// Memory is in KiB.
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2idParams returns minimal parameters recommended by OWASP.
+func DefaultArgon2idParams() Argon2idParams {
+	return Argon2idParams{
+		Memory:      19 * 1024,
+		Iterations:  2,
+		Parallelism: 1,
+		SaltLength:  16,
+		KeyLength:   32,
+	}
+}
+
+// HashConfig overrides cost values of DefaultArgon2idParams, fields that are not set keep the default.
+type HashConfig struct {
+	Memory      *int `env:"PASSWORD_HASH_MEMORY_KIB"`
+	Iterations  *int `env:"PASSWORD_HASH_ITERATIONS"`
+	Parallelism *int `env:"PASSWORD_HASH_PARALLELISM"`
+}
+
+func NewHashParams(config HashConfig) (Argon2idParams, error) {
+	var (
+		params                          = DefaultArgon2idParams()
+		memory, iterations, parallelism = int(params.Memory), int(params.Iterations), int(params.Parallelism)
+	)
+
+	override(&memory, config.Memory)
+	override(&iterations, config.Iterations)
+	override(&parallelism, config.Parallelism)
+
+	const maxParallelism = 255
+	if memory < 8*parallelism || iterations < 1 || parallelism < 1 || parallelism > maxParallelism {
+		return Argon2idParams{}, errInvalidHashParams
+	}
+
+	params.Memory = uint32(memory)
+	params.Iterations = uint32(iterations)
+	params.Parallelism = uint8(parallelism)
+	return params, nil
+}
@@ -1,51 +1,133 @@
 package pw
 
 import (
+	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
-const minValidLength = 8
+var activePolicy = DefaultPolicy()
+
+// InitPolicy replaces the policy used by Validate, it must be called before serving requests.
+func InitPolicy(policy Policy) {
+	activePolicy = policy
+}
 
 func Validate(password string) error {
-	var (
-		containsUpper  = false
-		containsLower  = false
-		containsNumber = false
-	)
+	return activePolicy.Validate(password)
+}
+
+func (p Policy) Validate(password string) error {
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		return errTooShort(p.MinLength)
+	}
+
+	if p.MaxLength > 0 && length > p.MaxLength {
+		return errTooLong(p.MaxLength)
+	}
+
+	classes, err := p.charClasses(password)
+	if err != nil {
+		return err
+	}
 
-	if len(password) < minValidLength {
-		return errTooShort
+	if p.containsBannedWord(password) {
+		return errContainsBannedWord
 	}
 
+	if !p.isPassphrase(length) {
+		if err := p.checkRequiredClasses(classes); err != nil {
+			return err
+		}
+	}
+
+	return p.checkBreached(password)
+}
+
+type charClasses struct {
+	upper  bool
+	lower  bool
+	number bool
+	symbol bool
+}
+
+func (p Policy) charClasses(password string) (charClasses, error) {
+	var result charClasses
 	for _, char := range password {
+		if !p.AllowUnicode && isNotASCIIChar(char) {
+			return charClasses{}, errASCIIOnly
+		}
+
 		switch {
-		case isNotASCIIChar(char):
-			return errContainsNotAllowedChar
 		case unicode.IsUpper(char):
-			containsUpper = true
+			result.upper = true
 		case unicode.IsLower(char):
-			containsLower = true
+			result.lower = true
 		case unicode.IsNumber(char):
-			containsNumber = true
+			result.number = true
 		case unicode.IsPunct(char) || unicode.IsSymbol(char):
+			result.symbol = true
+		case p.AllowUnicode && (unicode.IsLetter(char) || char == ' '):
 			continue
+		case p.AllowUnicode:
+			return charClasses{}, errNotAllowedChar
 		default:
-			return errContainsNotAllowedChar
+			return charClasses{}, errASCIIOnly
 		}
 	}
 
+	return result, nil
+}
+
+func (p Policy) checkRequiredClasses(classes charClasses) error {
 	switch {
-	case !containsUpper:
+	case p.RequireUpper && !classes.upper:
 		return errNoUppers
-	case !containsLower:
+	case p.RequireLower && !classes.lower:
 		return errNoLowers
-	case !containsNumber:
+	case p.RequireNumber && !classes.number:
 		return errNoNumbers
+	case p.RequireSymbol && !classes.symbol:
+		return errNoSymbols
 	default:
 		return nil
 	}
 }
 
+// isPassphrase reports if password is long enough to not require character classes.
+func (p Policy) isPassphrase(length int) bool {
+	return p.PassphraseMinLength > 0 && length >= p.PassphraseMinLength
+}
+
+func (p Policy) containsBannedWord(password string) bool {
+	password = strings.ToLower(password)
+	for _, word := range p.BannedWords {
+		if strings.Contains(password, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p Policy) checkBreached(password string) error {
+	if p.Breached == nil {
+		return nil
+	}
+
+	isBreached, err := p.Breached.IsBreached(password)
+	if err != nil {
+		return err
+	}
+
+	if isBreached {
+		return errBreached
+	}
+
+	return nil
+}
+
 func isNotASCIIChar(c rune) bool {
 	const (
 		minASCIICode = 0
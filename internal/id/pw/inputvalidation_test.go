@@ -0,0 +1,133 @@
+package pw
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type breachedStub map[string]bool
+
+func (b breachedStub) IsBreached(password string) (bool, error) {
+	return b[password], nil
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       func(p *Policy)
+		password     string
+		expectedRule Rule
+	}{
+		{
+			name:     "valid by default policy",
+			password: "Passw0rd",
+		},
+		{
+			name:         "too short",
+			password:     "Pass0rd",
+			expectedRule: RuleMinLength,
+		},
+		{
+			name:         "too long",
+			policy:       func(p *Policy) { p.MaxLength = 10 },
+			password:     "Passw0rdPassw0rd",
+			expectedRule: RuleMaxLength,
+		},
+		{
+			name:         "missing upper",
+			password:     "passw0rd",
+			expectedRule: RuleUpper,
+		},
+		{
+			name:         "missing lower",
+			password:     "PASSW0RD",
+			expectedRule: RuleLower,
+		},
+		{
+			name:         "missing number",
+			password:     "Password",
+			expectedRule: RuleNumber,
+		},
+		{
+			name:         "missing symbol when required",
+			policy:       func(p *Policy) { p.RequireSymbol = true },
+			password:     "Passw0rd",
+			expectedRule: RuleSymbol,
+		},
+		{
+			name:         "unicode is not allowed by default",
+			password:     "Пароль123Pass",
+			expectedRule: RuleAllowedChars,
+		},
+		{
+			name:     "unicode is allowed",
+			policy:   func(p *Policy) { p.AllowUnicode = true },
+			password: "Пароль123",
+		},
+		{
+			name:         "control char with allowed unicode",
+			policy:       func(p *Policy) { p.AllowUnicode = true },
+			password:     "Пароль123\n",
+			expectedRule: RuleAllowedChars,
+		},
+		{
+			name:         "length is counted in symbols",
+			policy:       func(p *Policy) { p.AllowUnicode = true },
+			password:     "Пар0ль",
+			expectedRule: RuleMinLength,
+		},
+		{
+			name: "passphrase does not require classes",
+			policy: func(p *Policy) {
+				p.AllowUnicode = true
+				p.PassphraseMinLength = 20
+			},
+			password: "correct horse battery staple",
+		},
+		{
+			name: "short passphrase requires classes",
+			policy: func(p *Policy) {
+				p.AllowUnicode = true
+				p.PassphraseMinLength = 20
+			},
+			password:     "horse battery",
+			expectedRule: RuleUpper,
+		},
+		{
+			name:         "contains banned word",
+			policy:       func(p *Policy) { p.BannedWords = []string{"expires"} },
+			password:     "NeverExpires1",
+			expectedRule: RuleBannedWord,
+		},
+		{
+			name:         "breached",
+			policy:       func(p *Policy) { p.Breached = breachedStub{"Passw0rd": true} },
+			password:     "Passw0rd",
+			expectedRule: RuleBreached,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := DefaultPolicy()
+			if tt.policy != nil {
+				tt.policy(&policy)
+			}
+
+			err := policy.Validate(tt.password)
+
+			if tt.expectedRule == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var errInsecure InsecurePasswordError
+			require.True(t, errors.As(err, &errInsecure), "expected InsecurePasswordError, got %v", err)
+			assert.Equal(t, tt.expectedRule, errInsecure.Rule())
+			assert.NotEmpty(t, errInsecure.Reason())
+		})
+	}
+}
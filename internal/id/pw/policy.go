@@ -0,0 +1,130 @@
+package pw
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+)
+
+type BreachedChecker interface {
+	IsBreached(password string) (bool, error)
+}
+
+type Policy struct {
+	MinLength int
+	// MaxLength is not checked when it is 0.
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireNumber bool
+	RequireSymbol bool
+	// AllowUnicode allows letters of any alphabet and spaces, otherwise only printable ASCII is allowed.
+	AllowUnicode bool
+	// PassphraseMinLength is length starting from which required character classes are not checked, 0 disables it.
+	PassphraseMinLength int
+	// BannedWords are lowercase words that password must not contain.
+	BannedWords []string
+	Breached    BreachedChecker
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		MinLength:     8,
+		MaxLength:     64,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireNumber: true,
+	}
+}
+
+// PolicyConfig overrides values of DefaultPolicy, fields that are not set keep the default.
+type PolicyConfig struct {
+	MinLength           *int     `env:"PASSWORD_MIN_LENGTH"`
+	MaxLength           *int     `env:"PASSWORD_MAX_LENGTH"`
+	RequireUpper        *bool    `env:"PASSWORD_REQUIRE_UPPER"`
+	RequireLower        *bool    `env:"PASSWORD_REQUIRE_LOWER"`
+	RequireNumber       *bool    `env:"PASSWORD_REQUIRE_NUMBER"`
+	RequireSymbol       *bool    `env:"PASSWORD_REQUIRE_SYMBOL"`
+	AllowUnicode        *bool    `env:"PASSWORD_ALLOW_UNICODE"`
+	PassphraseMinLength *int     `env:"PASSWORD_PASSPHRASE_MIN_LENGTH"`
+	BannedWords         []string `env:"PASSWORD_BANNED_WORDS"`
+	BannedWordsFile     string   `env:"PASSWORD_BANNED_WORDS_FILE"`
+	BreachedDBPath      string   `env:"PASSWORD_BREACHED_DB_PATH"`
+}
+
+func NewPolicy(config PolicyConfig) (Policy, error) {
+	policy := DefaultPolicy()
+	override(&policy.MinLength, config.MinLength)
+	override(&policy.MaxLength, config.MaxLength)
+	override(&policy.RequireUpper, config.RequireUpper)
+	override(&policy.RequireLower, config.RequireLower)
+	override(&policy.RequireNumber, config.RequireNumber)
+	override(&policy.RequireSymbol, config.RequireSymbol)
+	override(&policy.AllowUnicode, config.AllowUnicode)
+	override(&policy.PassphraseMinLength, config.PassphraseMinLength)
+	policy.BannedWords = append(policy.BannedWords, normalizeBannedWords(config.BannedWords)...)
+
+	if config.BannedWordsFile != "" {
+		words, err := loadBannedWords(config.BannedWordsFile)
+		if err != nil {
+			return Policy{}, errors.Join(errInvalidPolicy, err)
+		}
+
+		policy.BannedWords = append(policy.BannedWords, words...)
+	}
+
+	if config.BreachedDBPath != "" {
+		breached, err := OpenBreachedDB(config.BreachedDBPath)
+		if err != nil {
+			return Policy{}, err
+		}
+
+		policy.Breached = breached
+	}
+
+	if policy.MinLength < 1 || policy.MaxLength < 0 || (policy.MaxLength > 0 && policy.MaxLength < policy.MinLength) {
+		return Policy{}, errInvalidPolicy
+	}
+
+	return policy, nil
+}
+
+func override[T any](value *T, configured *T) {
+	if configured != nil {
+		*value = *configured
+	}
+}
+
+func loadBannedWords(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		words = append(words, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return normalizeBannedWords(words), nil
+}
+
+func normalizeBannedWords(words []string) []string {
+	result := make([]string, 0, len(words))
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word != "" {
+			result = append(result, word)
+		}
+	}
+
+	return result
+}
@@ -3,7 +3,6 @@ package session
 import (
 	"context"
 	"math/rand"
-	"os"
 	"strconv"
 	"testing"
 
@@ -13,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/zhuboris/never-expires/internal/id/migrations"
 	"github.com/zhuboris/never-expires/internal/id/usr"
 	"github.com/zhuboris/never-expires/internal/shared/postgresql"
 	"github.com/zhuboris/never-expires/internal/test"
@@ -27,7 +27,7 @@ func TestNewPostgresqlRepository(t *testing.T) {
 		DBName:   "test" + strconv.Itoa(rand.Int()),
 	}
 
-	pool := test.SetupDatabase(t, config, "")
+	pool := test.SetupDatabase(t, config, nil)
 
 	tests := []struct {
 		name          string
@@ -485,10 +485,7 @@ func arrangeRepoWithTestDB(t *testing.T) *PostgresqlRepository {
 		DBName:   "test" + strconv.Itoa(rand.Int()),
 	}
 
-	path := os.Getenv("SQL_AUTHENTICATION_INIT_FILE_PATH")
-	require.NotEmpty(t, path, "path to .sql is empty")
-
-	pool := test.SetupDatabase(t, config, path)
+	pool := test.SetupDatabase(t, config, migrations.FS)
 	t.Cleanup(func() {
 		test.DropDatabase(t, pool, config)
 	})
@@ -1,15 +1,12 @@
 package tkn
 
 import (
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const secretEnvKey = "JWT_SECRET_KEY"
-
 func CreateJWT(userID pgtype.UUID, expires time.Duration) (string, error) {
 	claims := &UserClaims{
 		UserID: userID,
@@ -18,16 +15,12 @@ func CreateJWT(userID pgtype.UUID, expires time.Duration) (string, error) {
 		},
 	}
 
-	var (
-		token            = jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-		jwtSecretKey, ok = os.LookupEnv(secretEnvKey)
-	)
-
-	if !ok {
-		return "", errEnvMissingKey
+	if secretKey == "" {
+		return "", errMissingSecretKey
 	}
 
-	return token.SignedString([]byte(jwtSecretKey))
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secretKey))
 }
 
 func expiresIn(timeLeft time.Duration) *jwt.NumericDate {
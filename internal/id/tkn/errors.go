@@ -1,12 +1,9 @@
 package tkn
 
-import (
-	"errors"
-	"fmt"
-)
+import "errors"
 
 var (
-	ErrUnauthorized  = errors.New("access denied")
-	ErrForbidden     = errors.New("access forbidden")
-	errEnvMissingKey = fmt.Errorf("key %q not exists in env", secretEnvKey)
+	ErrUnauthorized     = errors.New("access denied")
+	ErrForbidden        = errors.New("access forbidden")
+	errMissingSecretKey = errors.New("jwt secret key is not set")
 )
@@ -0,0 +1,12 @@
+package tkn
+
+type Config struct {
+	SecretKey string `env:"JWT_SECRET_KEY" required:"true" secret:"true"`
+}
+
+var secretKey string
+
+// Init sets the key to sign and verify JWT, it must be called before serving requests.
+func Init(config Config) {
+	secretKey = config.SecretKey
+}
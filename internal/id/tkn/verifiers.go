@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"os"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -26,9 +25,8 @@ func belongToDifferentUsers(firstOwner, secondOwner pgtype.UUID, err error) bool
 }
 
 func parseIfValid(signedToken string) (pgtype.UUID, error) {
-	jwtSecretKey, ok := os.LookupEnv(secretEnvKey)
-	if !ok {
-		return pgtype.UUID{}, errEnvMissingKey
+	if secretKey == "" {
+		return pgtype.UUID{}, errMissingSecretKey
 	}
 
 	claims := new(UserClaims)
@@ -37,7 +35,7 @@ func parseIfValid(signedToken string) (pgtype.UUID, error) {
 			return nil, fmt.Errorf("%w: wrong signing method", ErrUnauthorized)
 		}
 
-		return []byte(jwtSecretKey), nil
+		return []byte(secretKey), nil
 	})
 
 	if err != nil || !token.Valid {
@@ -0,0 +1,5 @@
+package avatarfs
+
+import "errors"
+
+var ErrInvalidUserID = errors.New("user id cannot be used as avatar file name")
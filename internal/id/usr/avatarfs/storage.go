@@ -0,0 +1,100 @@
+package avatarfs
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+)
+
+const (
+	filePerm = 0o640
+	dirPerm  = 0o750
+)
+
+type Config struct {
+	Dir string `env:"AVATARS_DIR" default:"./avatars"`
+}
+
+// Storage keeps avatars on local filesystem, one file per user named after the user id.
+type Storage struct {
+	dir string
+}
+
+func New(config Config) (*Storage, error) {
+	return NewInDir(config.Dir)
+}
+
+func NewInDir(dir string) (*Storage, error) {
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, err
+	}
+
+	return &Storage{
+		dir: dir,
+	}, nil
+}
+
+// Save replaces the avatar through temporary file, so readers never get partially written one.
+func (s Storage) Save(_ context.Context, userID string, data []byte) error {
+	path, err := s.path(userID)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmp.Name(), filePerm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s Storage) Load(_ context.Context, userID string) ([]byte, error) {
+	path, err := s.path(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(path)
+}
+
+func (s Storage) Delete(_ context.Context, userID string) error {
+	path, err := s.path(userID)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+func (s Storage) path(userID string) (string, error) {
+	if _, err := uuid.Parse(userID); err != nil {
+		return "", errors.Join(ErrInvalidUserID, err)
+	}
+
+	return filepath.Join(s.dir, userID), nil
+}
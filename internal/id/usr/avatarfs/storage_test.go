@@ -0,0 +1,67 @@
+package avatarfs
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const userID = "a1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01"
+
+func TestStorage(t *testing.T) {
+	ctx := context.Background()
+	storage, err := NewInDir(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = storage.Load(ctx, userID)
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	require.NoError(t, storage.Save(ctx, userID, []byte("first")))
+	require.NoError(t, storage.Save(ctx, userID, []byte("second")))
+
+	data, err := storage.Load(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), data)
+
+	require.NoError(t, storage.Delete(ctx, userID))
+	require.NoError(t, storage.Delete(ctx, userID), "deleting missing avatar is not an error")
+
+	_, err = storage.Load(ctx, userID)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestStorage_path(t *testing.T) {
+	storage, err := NewInDir(t.TempDir())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		userID  string
+		wantErr error
+	}{
+		{
+			name:   "uuid",
+			userID: userID,
+		},
+		{
+			name:    "path traversal",
+			userID:  "../" + userID,
+			wantErr: ErrInvalidUserID,
+		},
+		{
+			name:    "empty",
+			userID:  "",
+			wantErr: ErrInvalidUserID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := storage.path(tt.userID)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package usr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// DataExportToken guards download of exported data, only its hash is stored.
+type DataExportToken struct {
+	ConfirmationToken
+
+	Hash string
+}
+
+func NewDataExportToken(lifetime time.Duration) (DataExportToken, error) {
+	token, err := newConfirmationToken(lifetime)
+	if err != nil {
+		return DataExportToken{}, err
+	}
+
+	return DataExportToken{
+		ConfirmationToken: token,
+		Hash:              hashDataExportToken(token.Value),
+	}, nil
+}
+
+func hashDataExportToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -2,20 +2,14 @@ package usr
 
 import "github.com/zhuboris/never-expires/internal/shared/postgresql"
 
-func DBConfig() (postgresql.Config, error) {
-	const (
-		dbUsernameKey = "AUTH_PG_USERNAME"
-		dbPasswordKey = "AUTH_PG_PASSWORD"
-		dbHostKey     = "AUTH_PG_HOST"
-		dbPortKey     = "AUTH_PG_PORT"
-		dbNamedKey    = "AUTH_PG_DBNAME"
-	)
+type DBConfig struct {
+	Username string `env:"AUTH_PG_USERNAME" required:"true"`
+	Password string `env:"AUTH_PG_PASSWORD" required:"true" secret:"true"`
+	Host     string `env:"AUTH_PG_HOST" required:"true"`
+	Port     int    `env:"AUTH_PG_PORT" required:"true"`
+	DBName   string `env:"AUTH_PG_DBNAME" required:"true"`
+}
 
-	return postgresql.NewConfig(postgresql.ConfigEnvsAddress{
-		UsernameKey: dbUsernameKey,
-		PasswordKey: dbPasswordKey,
-		HostKey:     dbHostKey,
-		PortKey:     dbPortKey,
-		DBNameKey:   dbNamedKey,
-	})
+func (c DBConfig) Postgres() postgresql.Config {
+	return postgresql.Config(c)
 }
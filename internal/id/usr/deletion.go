@@ -0,0 +1,22 @@
+package usr
+
+import "time"
+
+// ScheduledDeletion describes account that is disabled until RestoreToken expires and deleted after.
+type ScheduledDeletion struct {
+	Email        string
+	RestoreToken ConfirmationToken
+}
+
+func (d ScheduledDeletion) DeleteAfter() time.Time {
+	return d.RestoreToken.ExpirationTime
+}
+
+// DeletionConfig sets for how many days deleted account can be restored.
+type DeletionConfig struct {
+	GraceDays int `env:"ACCOUNT_DELETION_GRACE_DAYS" default:"14"`
+}
+
+func (c DeletionConfig) GracePeriod() time.Duration {
+	return time.Duration(c.GraceDays) * 24 * time.Hour
+}
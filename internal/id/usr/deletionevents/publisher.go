@@ -0,0 +1,40 @@
+package deletionevents
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/zhuboris/never-expires/internal/shared/eventbus"
+)
+
+type eventPublisher interface {
+	PublishWithID(ctx context.Context, id string, event eventbus.Event) error
+}
+
+type Publisher struct {
+	events eventPublisher
+}
+
+func New(events eventPublisher) *Publisher {
+	return &Publisher{
+		events: events,
+	}
+}
+
+// DeleteUsers publishes user.deleted event for every user. Retries of the failed delivery publish
+// events with the same ids, so subscribers that already handled them skip the duplicates.
+func (p Publisher) DeleteUsers(ctx context.Context, usersIDs []string) error {
+	var err error
+	for _, id := range usersIDs {
+		err = errors.Join(err, p.events.PublishWithID(ctx, eventID(id), eventbus.UserDeleted{UserID: id}))
+	}
+
+	return err
+}
+
+// eventID is derived from the user id, because there is only one deletion delivery per user.
+func eventID(userID string) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(eventbus.UserDeletedType+":"+userID)).String()
+}
@@ -0,0 +1,51 @@
+package deletionevents
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zhuboris/never-expires/internal/shared/eventbus"
+)
+
+func TestPublisher_DeleteUsers(t *testing.T) {
+	errPublish := errors.New("broker is down")
+
+	tests := []struct {
+		name       string
+		ids        []string
+		publishErr error
+		wantErr    error
+	}{
+		{
+			name: "event for every user",
+			ids:  []string{"first", "second"},
+		},
+		{
+			name:       "publishing error is returned",
+			ids:        []string{"first"},
+			publishErr: errPublish,
+			wantErr:    errPublish,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			events := NewMockeventPublisher(t)
+			for _, id := range tt.ids {
+				events.EXPECT().PublishWithID(ctx, eventID(id), eventbus.UserDeleted{UserID: id}).Return(tt.publishErr)
+			}
+
+			err := New(events).DeleteUsers(ctx, tt.ids)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestEventID(t *testing.T) {
+	assert.Equal(t, eventID("first"), eventID("first"), "retried deletion must keep event id")
+	assert.NotEqual(t, eventID("first"), eventID("second"))
+}
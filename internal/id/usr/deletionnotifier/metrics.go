@@ -0,0 +1,91 @@
+package deletionnotifier
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/zhuboris/never-expires/internal/shared/prometheusexporter"
+)
+
+const metricsFilePath = "./metrics/userdeleter.prom"
+
+type metricsExporter interface {
+	NewAttemptsCounter(entityName string) (*prometheusexporter.AttemptsCounter, error)
+	NewValueGauge(name, help string) (*prometheusexporter.ValueGauge, error)
+	WriteToFile(path string) error
+}
+
+// PrometheusMetrics is written to file on Save, because deleter is run by cron and cannot be scraped.
+type PrometheusMetrics struct {
+	exporter metricsExporter
+	pending  *prometheusexporter.ValueGauge
+	dead     *prometheusexporter.ValueGauge
+	counters map[string]*prometheusexporter.AttemptsCounter
+	mu       sync.Mutex
+	logger   *zap.Logger
+}
+
+func NewPrometheusMetrics(exporter metricsExporter, logger *zap.Logger) (*PrometheusMetrics, error) {
+	pending, err := exporter.NewValueGauge("user_deletion_pending_deliveries", "Number of user deletions waiting for delivery to subscriber")
+	if err != nil {
+		return nil, err
+	}
+
+	dead, err := exporter.NewValueGauge("user_deletion_dead_deliveries", "Number of user deletions that subscriber failed to apply after all attempts")
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrometheusMetrics{
+		exporter: exporter,
+		pending:  pending,
+		dead:     dead,
+		counters: make(map[string]*prometheusexporter.AttemptsCounter),
+		logger:   logger,
+	}, nil
+}
+
+func (m *PrometheusMetrics) CountDelivery(subscriber string, isSuccess bool) {
+	counter, err := m.counter(subscriber)
+	if err != nil {
+		m.logger.Error("Creating deliveries counter error", zap.String("subscriber", subscriber), zap.Error(err))
+		return
+	}
+
+	if isSuccess {
+		counter.IncrementSuccess()
+		return
+	}
+
+	counter.IncrementFail()
+}
+
+func (m *PrometheusMetrics) SetBacklog(backlog Backlog) {
+	m.pending.Set(float64(backlog.Pending))
+	m.dead.Set(float64(backlog.Dead))
+}
+
+func (m *PrometheusMetrics) Save() {
+	if err := m.exporter.WriteToFile(metricsFilePath); err != nil {
+		m.logger.Error("Error writing metrics to file", zap.String("path", metricsFilePath), zap.Error(err))
+	}
+}
+
+func (m *PrometheusMetrics) counter(subscriber string) (*prometheusexporter.AttemptsCounter, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if counter, ok := m.counters[subscriber]; ok {
+		return counter, nil
+	}
+
+	counter, err := m.exporter.NewAttemptsCounter(fmt.Sprintf("user_deletion_%s_delivery", subscriber))
+	if err != nil {
+		return nil, err
+	}
+
+	m.counters[subscriber] = counter
+	return counter, nil
+}
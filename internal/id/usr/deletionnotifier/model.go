@@ -0,0 +1,26 @@
+package deletionnotifier
+
+import "time"
+
+type deliveryStatus string
+
+const (
+	statusPending deliveryStatus = "pending"
+	statusDead    deliveryStatus = "dead"
+)
+
+type delivery struct {
+	userID   string
+	attempts int
+}
+
+type failedDelivery struct {
+	userID        string
+	nextAttemptAt time.Time
+	status        deliveryStatus
+}
+
+type Backlog struct {
+	Pending int
+	Dead    int
+}
@@ -4,80 +4,177 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 )
 
+const batchSize = 100
+
 type UserDeleter interface {
-	DeleteUsers(ctx context.Context, usersIDs []string)
+	DeleteUsers(ctx context.Context, usersIDs []string) error
 }
 
-type userRepository interface {
-	popIDsToDelete(ctx context.Context, limit int) ([]string, error)
+type (
+	userRepository interface {
+		enqueueDueDeletions(ctx context.Context, subscribers []string, limit int) (int, error)
+		dueDeliveries(ctx context.Context, subscriber string, limit int) ([]delivery, error)
+		markDelivered(ctx context.Context, subscriber string, usersIDs []string) error
+		markFailed(ctx context.Context, subscriber string, failures []failedDelivery, reason string) error
+		backlog(ctx context.Context) (Backlog, error)
+	}
+	deletionMetrics interface {
+		CountDelivery(subscriber string, isSuccess bool)
+		SetBacklog(backlog Backlog)
+		Save()
+	}
+)
+
+type subscriber struct {
+	name    string
+	deleter UserDeleter
 }
 
+// UserDeletionNotifier propagates deletion of accounts which grace period is over to every subscriber.
+// Each subscriber acknowledges its own delivery, failed ones are retried with backoff until they are dead.
 type UserDeletionNotifier struct {
-	repo     userRepository
-	deleters []UserDeleter
-	logger   *zap.Logger
+	repo        userRepository
+	subscribers []subscriber
+	metrics     deletionMetrics
+	policy      retryPolicy
+	now         func() time.Time
+	logger      *zap.Logger
 }
 
-func New(repo userRepository, logger *zap.Logger) *UserDeletionNotifier {
+func New(repo userRepository, metrics deletionMetrics, logger *zap.Logger) *UserDeletionNotifier {
 	return &UserDeletionNotifier{
-		repo:   repo,
-		logger: logger,
+		repo:    repo,
+		metrics: metrics,
+		policy:  defaultRetryPolicy,
+		now:     time.Now,
+		logger:  logger,
 	}
 }
 
-func (n *UserDeletionNotifier) RegisterSubscriber(subscriber UserDeleter) {
-	n.deleters = append(n.deleters, subscriber)
+// RegisterSubscriber adds deleter under name that is stored with its deliveries, so it must not change.
+func (n *UserDeletionNotifier) RegisterSubscriber(name string, deleter UserDeleter) {
+	n.subscribers = append(n.subscribers, subscriber{
+		name:    name,
+		deleter: deleter,
+	})
 }
 
-func (n *UserDeletionNotifier) DeleteAll(ctx context.Context) error {
-	const batchSize = 100
+func (n *UserDeletionNotifier) RunWithCtx(ctx context.Context) error {
+	defer n.metrics.Save()
 
 	n.logger.Info("Deleter is up")
+	err := n.enqueueAll(ctx)
+	if err == nil {
+		err = n.deliverAll(ctx)
+	}
 
-	var err error
-	for err == nil {
-		err = n.deleteBatch(ctx, batchSize)
+	n.updateBacklog(ctx)
+	if err != nil {
+		n.logger.Error("Deleter is shutdown", zap.Error(err))
 	}
 
-	n.logger.Error("Deleter is shutdown", zap.Error(err))
 	return err
 }
 
-func (n *UserDeletionNotifier) deleteBatch(ctx context.Context, batchSize int) error {
-	if n.deleters == nil {
+func (n *UserDeletionNotifier) enqueueAll(ctx context.Context) error {
+	if len(n.subscribers) == 0 {
 		return nil
 	}
 
-	toDelete, err := n.repo.popIDsToDelete(ctx, batchSize)
-	if err != nil {
-		return err
+	names := make([]string, 0, len(n.subscribers))
+	for _, subscriber := range n.subscribers {
+		names = append(names, subscriber.name)
 	}
 
-	if len(toDelete) == 0 {
-		return errors.New("nothing left to delete")
+	for {
+		enqueued, err := n.repo.enqueueDueDeletions(ctx, names, batchSize)
+		if err != nil || enqueued < batchSize {
+			return err
+		}
 	}
-
-	n.doDeletions(ctx, toDelete)
-	return nil
 }
 
-func (n *UserDeletionNotifier) doDeletions(ctx context.Context, toDelete []string) {
-	var wg sync.WaitGroup
-	deletersNumber := len(n.deleters)
-	wg.Add(deletersNumber)
+func (n *UserDeletionNotifier) deliverAll(ctx context.Context) error {
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(n.subscribers))
+	)
 
-	for i := range n.deleters {
+	wg.Add(len(n.subscribers))
+	for i := range n.subscribers {
 		i := i
-
 		go func(ctx context.Context) {
 			defer wg.Done()
-			n.deleters[i].DeleteUsers(ctx, toDelete)
+			errs[i] = n.deliverToSubscriber(ctx, n.subscribers[i])
 		}(ctx)
 	}
-
 	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func (n *UserDeletionNotifier) deliverToSubscriber(ctx context.Context, subscriber subscriber) error {
+	for {
+		deliveries, err := n.repo.dueDeliveries(ctx, subscriber.name, batchSize)
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		if err := n.deliverBatch(ctx, subscriber, deliveries); err != nil {
+			return err
+		}
+	}
+}
+
+func (n *UserDeletionNotifier) deliverBatch(ctx context.Context, subscriber subscriber, deliveries []delivery) error {
+	ids := make([]string, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		ids = append(ids, delivery.userID)
+	}
+
+	deleteErr := subscriber.deleter.DeleteUsers(ctx, ids)
+	n.metrics.CountDelivery(subscriber.name, deleteErr == nil)
+	if deleteErr == nil {
+		return n.repo.markDelivered(ctx, subscriber.name, ids)
+	}
+
+	failures := n.failures(subscriber.name, deliveries)
+	return n.repo.markFailed(ctx, subscriber.name, failures, deleteErr.Error())
+}
+
+func (n *UserDeletionNotifier) failures(subscriberName string, deliveries []delivery) []failedDelivery {
+	now := n.now()
+	failures := make([]failedDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		attempt := delivery.attempts + 1
+		failure := failedDelivery{
+			userID:        delivery.userID,
+			nextAttemptAt: now.Add(n.policy.delay(attempt)),
+			status:        statusPending,
+		}
+
+		if n.policy.isExhausted(attempt) {
+			failure.status = statusDead
+			n.logger.Error("Deletion is dead, attempts are exhausted", zap.String("subscriber", subscriberName), zap.String("id", delivery.userID))
+		}
+
+		failures = append(failures, failure)
+	}
+
+	return failures
+}
+
+func (n *UserDeletionNotifier) updateBacklog(ctx context.Context) {
+	backlog, err := n.repo.backlog(ctx)
+	if err != nil {
+		n.logger.Error("Failed to count deletions backlog", zap.Error(err))
+		return
+	}
+
+	n.metrics.SetBacklog(backlog)
 }
@@ -0,0 +1,118 @@
+package deletionnotifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testSubscriber = "reminder"
+
+type deleterStub struct {
+	err error
+}
+
+func (s deleterStub) DeleteUsers(context.Context, []string) error {
+	return s.err
+}
+
+type metricsStub struct {
+	deliveries map[bool]int
+	backlog    Backlog
+	isSaved    bool
+}
+
+func (s *metricsStub) CountDelivery(_ string, isSuccess bool) {
+	s.deliveries[isSuccess]++
+}
+
+func (s *metricsStub) SetBacklog(backlog Backlog) {
+	s.backlog = backlog
+}
+
+func (s *metricsStub) Save() {
+	s.isSaved = true
+}
+
+func TestUserDeletionNotifier_RunWithCtx(t *testing.T) {
+	var (
+		now        = time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+		deleteErr  = errors.New("reminder db is unavailable")
+		deliveries = []delivery{
+			{userID: "first", attempts: 0},
+			{userID: "second", attempts: defaultRetryPolicy.maxAttempts - 1},
+		}
+	)
+
+	tests := []struct {
+		name             string
+		deleteErr        error
+		expectedFailures []failedDelivery
+	}{
+		{
+			name: "delivered deletions are acknowledged",
+		},
+		{
+			name:      "failed deletions are retried or dead",
+			deleteErr: deleteErr,
+			expectedFailures: []failedDelivery{
+				{
+					userID:        "first",
+					nextAttemptAt: now.Add(defaultRetryPolicy.baseDelay),
+					status:        statusPending,
+				},
+				{
+					userID:        "second",
+					nextAttemptAt: now.Add(defaultRetryPolicy.maxDelay),
+					status:        statusDead,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockuserRepository(t)
+			repo.EXPECT().enqueueDueDeletions(mock.Anything, []string{testSubscriber}, batchSize).Return(2, nil)
+			repo.EXPECT().dueDeliveries(mock.Anything, testSubscriber, batchSize).Return(deliveries, nil).Once()
+			repo.EXPECT().dueDeliveries(mock.Anything, testSubscriber, batchSize).Return(nil, nil).Once()
+			repo.EXPECT().backlog(mock.Anything).Return(Backlog{Dead: len(tt.expectedFailures)}, nil)
+
+			if tt.deleteErr == nil {
+				repo.EXPECT().markDelivered(mock.Anything, testSubscriber, []string{"first", "second"}).Return(nil)
+			} else {
+				repo.EXPECT().markFailed(mock.Anything, testSubscriber, tt.expectedFailures, tt.deleteErr.Error()).Return(nil)
+			}
+
+			metrics := &metricsStub{deliveries: make(map[bool]int)}
+			notifier := New(repo, metrics, zap.NewNop())
+			notifier.now = func() time.Time { return now }
+			notifier.RegisterSubscriber(testSubscriber, deleterStub{err: tt.deleteErr})
+
+			err := notifier.RunWithCtx(context.Background())
+			require.NoError(t, err)
+
+			assert.True(t, metrics.isSaved, "metrics are not saved")
+			assert.Equal(t, 1, metrics.deliveries[tt.deleteErr == nil])
+			assert.Equal(t, len(tt.expectedFailures), metrics.backlog.Dead)
+		})
+	}
+}
+
+func TestUserDeletionNotifier_enqueueAll(t *testing.T) {
+	repo := NewMockuserRepository(t)
+	repo.EXPECT().enqueueDueDeletions(mock.Anything, []string{"id", testSubscriber}, batchSize).Return(batchSize, nil).Once()
+	repo.EXPECT().enqueueDueDeletions(mock.Anything, []string{"id", testSubscriber}, batchSize).Return(1, nil).Once()
+
+	notifier := New(repo, &metricsStub{}, zap.NewNop())
+	notifier.RegisterSubscriber("id", deleterStub{})
+	notifier.RegisterSubscriber(testSubscriber, deleterStub{})
+
+	require.NoError(t, notifier.enqueueAll(context.Background()))
+}
@@ -0,0 +1,29 @@
+package deletionnotifier
+
+import "time"
+
+type retryPolicy struct {
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	maxAttempts int
+}
+
+var defaultRetryPolicy = retryPolicy{
+	baseDelay:   10 * time.Minute,
+	maxDelay:    24 * time.Hour,
+	maxAttempts: 10,
+}
+
+// delay returns pause before the next try after failed attempt number, it doubles with each attempt.
+func (p retryPolicy) delay(attempt int) time.Duration {
+	delay := p.baseDelay
+	for i := 1; i < attempt && delay < p.maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, p.maxDelay)
+}
+
+func (p retryPolicy) isExhausted(attempt int) bool {
+	return attempt >= p.maxAttempts
+}
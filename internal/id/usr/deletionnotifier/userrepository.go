@@ -3,6 +3,7 @@ package deletionnotifier
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -23,34 +24,115 @@ func NewUserPostgresqlRepository(pool *pgxpool.Pool) (*UserPostgresqlRepository,
 	}, nil
 }
 
-func (r UserPostgresqlRepository) popIDsToDelete(ctx context.Context, limit int) ([]string, error) {
+func (r UserPostgresqlRepository) enqueueDueDeletions(ctx context.Context, subscribers []string, limit int) (int, error) {
 	const sql = `
 		WITH batch AS (
-		    SELECT id
-		    FROM  users_to_delete
-		    LIMIT $1
+		    DELETE FROM users_to_delete
+		    WHERE id IN (
+		        SELECT id
+		        FROM  users_to_delete
+		        WHERE delete_after <= CURRENT_TIMESTAMP
+		        LIMIT $2
+		        FOR UPDATE SKIP LOCKED
+		    )
+		    RETURNING id
+		), deliveries AS (
+		    INSERT INTO user_deletion_deliveries (user_id, subscriber)
+		    SELECT b.id, s.subscriber
+		    FROM batch b
+		    CROSS JOIN UNNEST($1::VARCHAR[]) AS s(subscriber)
+		    ON CONFLICT (user_id, subscriber) DO NOTHING
 		)
-		DELETE FROM users_to_delete
-		WHERE id IN (SELECT id FROM batch)
-		
-		RETURNING id;
+		SELECT COUNT(*) FROM batch;
 	`
 
-	rows, err := r.pool.Query(ctx, sql, limit)
+	var enqueued int
+	err := r.pool.QueryRow(ctx, sql, subscribers, limit).
+		Scan(&enqueued)
+
+	return enqueued, postgresql.HandleQueryErr(err)
+}
+
+func (r UserPostgresqlRepository) dueDeliveries(ctx context.Context, subscriber string, limit int) ([]delivery, error) {
+	const sql = `
+		SELECT user_id, attempts
+		FROM user_deletion_deliveries
+		WHERE subscriber = $1
+		AND status = $2
+		AND next_attempt_at <= CURRENT_TIMESTAMP
+		ORDER BY next_attempt_at
+		LIMIT $3;
+	`
+
+	rows, err := r.pool.Query(ctx, sql, subscriber, statusPending, limit)
 	if err != nil {
 		return nil, postgresql.HandleQueryErr(err)
 	}
 
-	var ids []string
+	var deliveries []delivery
 	for rows.Next() {
-		var id string
-		if scanError := rows.Scan(&id); scanError != nil {
+		var result delivery
+		if scanError := rows.Scan(&result.userID, &result.attempts); scanError != nil {
 			err = errors.Join(scanError, err)
 			continue
 		}
 
-		ids = append(ids, id)
+		deliveries = append(deliveries, result)
 	}
 
-	return ids, postgresql.HandleQueryErr(err)
+	return deliveries, postgresql.HandleQueryErr(errors.Join(err, rows.Err()))
+}
+
+func (r UserPostgresqlRepository) markDelivered(ctx context.Context, subscriber string, usersIDs []string) error {
+	const sql = `
+		DELETE FROM user_deletion_deliveries
+		WHERE subscriber = $1
+		AND user_id = ANY($2);
+	`
+
+	_, err := r.pool.Exec(ctx, sql, subscriber, usersIDs)
+	return postgresql.HandleQueryErr(err)
+}
+
+func (r UserPostgresqlRepository) markFailed(ctx context.Context, subscriber string, failures []failedDelivery, reason string) error {
+	const sql = `
+		UPDATE user_deletion_deliveries d
+		SET attempts = d.attempts + 1,
+		    next_attempt_at = f.next_attempt_at,
+		    status = f.status,
+		    last_error = $5
+		FROM UNNEST($2::UUID[], $3::TIMESTAMPTZ[], $4::VARCHAR[]) AS f(user_id, next_attempt_at, status)
+		WHERE d.subscriber = $1
+		AND d.user_id = f.user_id;
+	`
+
+	var (
+		ids          = make([]string, 0, len(failures))
+		nextAttempts = make([]time.Time, 0, len(failures))
+		statuses     = make([]string, 0, len(failures))
+	)
+
+	for _, failure := range failures {
+		ids = append(ids, failure.userID)
+		nextAttempts = append(nextAttempts, failure.nextAttemptAt)
+		statuses = append(statuses, string(failure.status))
+	}
+
+	_, err := r.pool.Exec(ctx, sql, subscriber, ids, nextAttempts, statuses, reason)
+	return postgresql.HandleQueryErr(err)
+}
+
+func (r UserPostgresqlRepository) backlog(ctx context.Context) (Backlog, error) {
+	const sql = `
+		SELECT
+		    COUNT(*) FILTER (WHERE status = $1),
+		    COUNT(*) FILTER (WHERE status = $2)
+		FROM user_deletion_deliveries;
+	`
+
+	var result Backlog
+	err := r.pool.QueryRow(ctx, sql, statusPending, statusDead).
+		Scan(&result.Pending, &result.Dead)
+
+	return result, postgresql.HandleQueryErr(err)
 }
@@ -0,0 +1,27 @@
+package usr
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	emailChangeConfirmationLifetime = 24 * time.Hour
+	emailChangeRevertWindow         = 7 * 24 * time.Hour
+)
+
+// EmailChange is a pending switch of login email, it is applied only after NewEmail is confirmed
+// and can be undone from OldEmail until RevertToken expires.
+type EmailChange struct {
+	OldEmail     string
+	NewEmail     string
+	ConfirmToken ConfirmationToken
+	RevertToken  ConfirmationToken
+}
+
+type AppliedEmailChange struct {
+	UserID     pgtype.UUID
+	Email      string
+	IsSwitched bool
+}
@@ -0,0 +1,217 @@
+package exportnotifier
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/zhuboris/never-expires/internal/id/lang"
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/shared/dataexport"
+)
+
+const (
+	checkInterval        = time.Minute
+	batchSize            = 10
+	downloadLinkLifetime = 7 * 24 * time.Hour
+	tokenQueryName       = "token"
+)
+
+type UserDataExporter interface {
+	ExportUserData(ctx context.Context, userID string) ([]dataexport.File, error)
+}
+
+type (
+	repository interface {
+		deleteExpiredArchives(ctx context.Context) error
+		pendingExports(ctx context.Context, limit int) ([]pendingExport, error)
+		saveArchive(ctx context.Context, exportID string, archive []byte, token usr.DataExportToken) error
+		markFailed(ctx context.Context, exportID string, nextAttemptAt time.Time, isDead bool, reason string) error
+	}
+	emailQueue interface {
+		Add(ctx context.Context, recipient string, msg []byte) error
+	}
+	emailMessages interface {
+		DataExportReady(recipient, url string, language lang.Language) ([]byte, error)
+	}
+)
+
+type pendingExport struct {
+	id       string
+	userID   string
+	email    string
+	language string // language from user profile, the one export was requested with otherwise
+	attempts int
+}
+
+type UserExportNotifier struct {
+	repo        repository
+	exporters   []UserDataExporter
+	queue       emailQueue
+	messages    emailMessages
+	downloadURL string
+	policy      retryPolicy
+	now         func() time.Time
+	logger      *zap.Logger
+}
+
+func New(repo repository, queue emailQueue, messages emailMessages, downloadURL string, logger *zap.Logger) *UserExportNotifier {
+	return &UserExportNotifier{
+		repo:        repo,
+		queue:       queue,
+		messages:    messages,
+		downloadURL: downloadURL,
+		policy:      defaultRetryPolicy,
+		now:         time.Now,
+		logger:      logger,
+	}
+}
+
+func (n *UserExportNotifier) RegisterSubscriber(subscriber UserDataExporter) {
+	n.exporters = append(n.exporters, subscriber)
+}
+
+func (n *UserExportNotifier) RunWithCtx(ctx context.Context) error {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	n.logger.Info("Exporter is up")
+	for {
+		if err := n.exportPending(ctx); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (n *UserExportNotifier) exportPending(ctx context.Context) error {
+	if err := n.repo.deleteExpiredArchives(ctx); err != nil {
+		return err
+	}
+
+	pending, err := n.repo.pendingExports(ctx, batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, export := range pending {
+		err := n.export(ctx, export)
+		n.logExport(err, export.userID)
+		if err == nil {
+			continue
+		}
+
+		if err := n.markFailed(ctx, export, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// markFailed postpones the export with growing delay, so failing exports do not hold the head of the queue,
+// after the last attempt the export is failed for good and the user can request a new one.
+func (n *UserExportNotifier) markFailed(ctx context.Context, export pendingExport, exportErr error) error {
+	attempt := export.attempts + 1
+	isDead := n.policy.isExhausted(attempt)
+	if isDead {
+		n.logger.Error("Export is failed, attempts are exhausted", zap.String("id", export.userID))
+	}
+
+	return n.repo.markFailed(ctx, export.id, n.now().Add(n.policy.delay(attempt)), isDead, exportErr.Error())
+}
+
+func (n *UserExportNotifier) export(ctx context.Context, export pendingExport) error {
+	files, err := n.collectFiles(ctx, export.userID)
+	if err != nil {
+		return err
+	}
+
+	archive, err := dataexport.Zip(files)
+	if err != nil {
+		return err
+	}
+
+	token, err := usr.NewDataExportToken(downloadLinkLifetime)
+	if err != nil {
+		return err
+	}
+
+	link, err := n.downloadLink(token.Value)
+	if err != nil {
+		return err
+	}
+
+	msg, err := n.messages.DataExportReady(export.email, link, lang.Language(export.language))
+	if err != nil {
+		return err
+	}
+
+	if err := n.repo.saveArchive(ctx, export.id, archive, token); err != nil {
+		return err
+	}
+
+	return n.queue.Add(ctx, export.email, msg)
+}
+
+func (n *UserExportNotifier) collectFiles(ctx context.Context, userID string) ([]dataexport.File, error) {
+	var (
+		wg      sync.WaitGroup
+		results = make([][]dataexport.File, len(n.exporters))
+		errs    = make([]error, len(n.exporters))
+	)
+
+	wg.Add(len(n.exporters))
+	for i := range n.exporters {
+		i := i
+		go func(ctx context.Context) {
+			defer wg.Done()
+			results[i], errs[i] = n.exporters[i].ExportUserData(ctx, userID)
+		}(ctx)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	var files []dataexport.File
+	for _, result := range results {
+		files = append(files, result...)
+	}
+
+	return files, nil
+}
+
+func (n *UserExportNotifier) downloadLink(token string) (string, error) {
+	link, err := url.Parse(n.downloadURL)
+	if err != nil {
+		return "", err
+	}
+
+	query := link.Query()
+	query.Set(tokenQueryName, token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
+
+func (n *UserExportNotifier) logExport(err error, userID string) {
+	msg := "Successfully exported"
+	logLvl := zapcore.InfoLevel
+	if err != nil {
+		msg = "Failed to export"
+		logLvl = zapcore.ErrorLevel
+	}
+
+	n.logger.Log(logLvl, msg, zap.Error(err), zap.String("id", userID))
+}
@@ -0,0 +1,165 @@
+package exportnotifier
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/zhuboris/never-expires/internal/id/lang"
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/shared/dataexport"
+)
+
+const testDownloadURL = "https://id.test.com/user/export/download"
+
+var testExport = pendingExport{
+	id:       "export-id",
+	userID:   "user-id",
+	email:    "user@test.com",
+	language: "ru",
+}
+
+type exporterStub struct {
+	files []dataexport.File
+	err   error
+}
+
+func (s exporterStub) ExportUserData(context.Context, string) ([]dataexport.File, error) {
+	return s.files, s.err
+}
+
+type queueStub struct {
+	recipients []string
+}
+
+func (s *queueStub) Add(_ context.Context, recipient string, _ []byte) error {
+	s.recipients = append(s.recipients, recipient)
+	return nil
+}
+
+type messagesStub struct {
+	links []string
+}
+
+func (s *messagesStub) DataExportReady(_, url string, _ lang.Language) ([]byte, error) {
+	s.links = append(s.links, url)
+	return []byte("email"), nil
+}
+
+func TestUserExportNotifier_exportPending(t *testing.T) {
+	var (
+		accountFile  = dataexport.File{Name: "account/profile.json", Content: []byte("{}")}
+		reminderFile = dataexport.File{Name: "reminder/items.csv", Content: []byte("name")}
+	)
+
+	var (
+		now         = time.Date(2023, 7, 11, 15, 0, 0, 0, time.UTC)
+		failing     = exporterStub{err: errors.New("reminder is unavailable")}
+		lastAttempt = defaultRetryPolicy.maxAttempts - 1
+	)
+
+	tests := []struct {
+		name              string
+		exporters         []UserDataExporter
+		attempts          int
+		isSaved           bool
+		expectedFiles     []string
+		expectedNextRetry time.Time
+		isDead            bool
+	}{
+		{
+			name: "files from all subscribers are archived",
+			exporters: []UserDataExporter{
+				exporterStub{files: []dataexport.File{accountFile}},
+				exporterStub{files: []dataexport.File{reminderFile}},
+			},
+			isSaved:       true,
+			expectedFiles: []string{accountFile.Name, reminderFile.Name},
+		},
+		{
+			name: "failed subscriber postpones export",
+			exporters: []UserDataExporter{
+				exporterStub{files: []dataexport.File{accountFile}},
+				failing,
+			},
+			attempts:          1,
+			expectedNextRetry: now.Add(defaultRetryPolicy.delay(2)),
+		},
+		{
+			name:              "export is failed after the last attempt",
+			exporters:         []UserDataExporter{failing},
+			attempts:          lastAttempt,
+			expectedNextRetry: now.Add(defaultRetryPolicy.delay(lastAttempt + 1)),
+			isDead:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var savedArchive []byte
+
+			export := testExport
+			export.attempts = tt.attempts
+
+			repo := NewMockrepository(t)
+			repo.EXPECT().deleteExpiredArchives(mock.Anything).Return(nil)
+			repo.EXPECT().pendingExports(mock.Anything, batchSize).Return([]pendingExport{export}, nil)
+			if !tt.isSaved {
+				repo.EXPECT().markFailed(mock.Anything, testExport.id, tt.expectedNextRetry, tt.isDead, mock.Anything).Return(nil)
+			}
+
+			if tt.isSaved {
+				repo.EXPECT().
+					saveArchive(mock.Anything, testExport.id, mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, _ string, archive []byte, _ usr.DataExportToken) error {
+						savedArchive = archive
+						return nil
+					})
+			}
+
+			var (
+				queue    = new(queueStub)
+				messages = new(messagesStub)
+				notifier = New(repo, queue, messages, testDownloadURL, zap.NewNop())
+			)
+
+			notifier.now = func() time.Time { return now }
+
+			for _, exporter := range tt.exporters {
+				notifier.RegisterSubscriber(exporter)
+			}
+
+			err := notifier.exportPending(context.Background())
+			require.NoError(t, err)
+
+			if !tt.isSaved {
+				assert.Empty(t, queue.recipients)
+				return
+			}
+
+			assert.Equal(t, []string{testExport.email}, queue.recipients)
+			require.Len(t, messages.links, 1)
+			link, err := url.Parse(messages.links[0])
+			require.NoError(t, err)
+			assert.NotEmpty(t, link.Query().Get(tokenQueryName))
+
+			reader, err := zip.NewReader(bytes.NewReader(savedArchive), int64(len(savedArchive)))
+			require.NoError(t, err)
+
+			var names []string
+			for _, file := range reader.File {
+				names = append(names, file.Name)
+			}
+			assert.ElementsMatch(t, tt.expectedFiles, names)
+		})
+	}
+}
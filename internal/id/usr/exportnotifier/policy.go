@@ -0,0 +1,29 @@
+package exportnotifier
+
+import "time"
+
+type retryPolicy struct {
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	maxAttempts int
+}
+
+var defaultRetryPolicy = retryPolicy{
+	baseDelay:   5 * time.Minute,
+	maxDelay:    6 * time.Hour,
+	maxAttempts: 8,
+}
+
+// delay returns pause before the next try after failed attempt number, it doubles with each attempt.
+func (p retryPolicy) delay(attempt int) time.Duration {
+	delay := p.baseDelay
+	for i := 1; i < attempt && delay < p.maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, p.maxDelay)
+}
+
+func (p retryPolicy) isExhausted(attempt int) bool {
+	return attempt >= p.maxAttempts
+}
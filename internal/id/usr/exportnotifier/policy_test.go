@@ -0,0 +1,52 @@
+package exportnotifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_delay(t *testing.T) {
+	policy := retryPolicy{
+		baseDelay:   10 * time.Minute,
+		maxDelay:    time.Hour,
+		maxAttempts: 5,
+	}
+
+	tests := []struct {
+		name          string
+		attempt       int
+		expectedDelay time.Duration
+		isExhausted   bool
+	}{
+		{
+			name:          "first failure",
+			attempt:       1,
+			expectedDelay: 10 * time.Minute,
+		},
+		{
+			name:          "delay doubles",
+			attempt:       3,
+			expectedDelay: 40 * time.Minute,
+		},
+		{
+			name:          "delay is capped",
+			attempt:       4,
+			expectedDelay: time.Hour,
+		},
+		{
+			name:          "attempts are exhausted",
+			attempt:       5,
+			expectedDelay: time.Hour,
+			isExhausted:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedDelay, policy.delay(tt.attempt))
+			assert.Equal(t, tt.isExhausted, policy.isExhausted(tt.attempt))
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package exportnotifier
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/shared/postgresql"
+)
+
+type PostgresqlRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresqlRepository(pool *pgxpool.Pool) (*PostgresqlRepository, error) {
+	if pool == nil {
+		return nil, postgresql.ErrPoolInitRequired
+	}
+
+	return &PostgresqlRepository{
+		pool: pool,
+	}, nil
+}
+
+func (r PostgresqlRepository) deleteExpiredArchives(ctx context.Context) error {
+	const sql = `
+		DELETE FROM data_exports
+		WHERE expiration < CURRENT_TIMESTAMP
+		OR failed_at < CURRENT_TIMESTAMP - INTERVAL '30 days';
+	`
+
+	_, err := r.pool.Exec(ctx, sql)
+	return postgresql.HandleQueryErr(err)
+}
+
+func (r PostgresqlRepository) pendingExports(ctx context.Context, limit int) ([]pendingExport, error) {
+	const sql = `
+		SELECT de.id, de.user_id, e.email, COALESCE(p.language, de.language), de.attempts
+		FROM data_exports de
+		JOIN emails e ON e.owner_id = de.user_id
+		LEFT JOIN user_profiles p ON p.user_id = de.user_id
+		WHERE de.archive IS NULL
+		AND de.failed_at IS NULL
+		AND de.next_attempt_at <= CURRENT_TIMESTAMP
+		AND e.is_active = TRUE
+		AND e.is_confirmed = TRUE
+		ORDER BY de.next_attempt_at
+		LIMIT $1;
+	`
+
+	rows, err := r.pool.Query(ctx, sql, limit)
+	if err != nil {
+		return nil, postgresql.HandleQueryErr(err)
+	}
+
+	var exports []pendingExport
+	for rows.Next() {
+		var export pendingExport
+		if scanError := rows.Scan(&export.id, &export.userID, &export.email, &export.language, &export.attempts); scanError != nil {
+			err = errors.Join(scanError, err)
+			continue
+		}
+
+		exports = append(exports, export)
+	}
+
+	return exports, postgresql.HandleQueryErr(errors.Join(err, rows.Err()))
+}
+
+func (r PostgresqlRepository) saveArchive(ctx context.Context, exportID string, archive []byte, token usr.DataExportToken) error {
+	const sql = `
+		UPDATE data_exports
+		SET archive = $2,
+		    download_token = $3,
+		    expiration = $4
+		WHERE id = $1;
+	`
+
+	_, err := r.pool.Exec(ctx, sql, exportID, archive, token.Hash, token.ExpirationTime)
+	return postgresql.HandleQueryErr(err)
+}
+
+func (r PostgresqlRepository) markFailed(ctx context.Context, exportID string, nextAttemptAt time.Time, isDead bool, reason string) error {
+	const sql = `
+		UPDATE data_exports
+		SET attempts = attempts + 1,
+		    next_attempt_at = $2,
+		    failed_at = CASE WHEN $3 THEN CURRENT_TIMESTAMP END,
+		    last_error = $4
+		WHERE id = $1
+		AND archive IS NULL;
+	`
+
+	_, err := r.pool.Exec(ctx, sql, exportID, nextAttemptAt, isDead, reason)
+	return postgresql.HandleQueryErr(err)
+}
@@ -0,0 +1,8 @@
+package usr
+
+import "time"
+
+type ExternalIdentity struct {
+	Provider    string    `json:"provider"`
+	ConnectedAt time.Time `json:"connected_at"`
+}
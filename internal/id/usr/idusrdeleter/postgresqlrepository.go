@@ -0,0 +1,59 @@
+package idusrdeleter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zhuboris/never-expires/internal/shared/postgresql"
+)
+
+type PostgresqlRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresqlRepository(pool *pgxpool.Pool) (*PostgresqlRepository, error) {
+	if pool == nil {
+		return nil, postgresql.ErrPoolInitRequired
+	}
+
+	return &PostgresqlRepository{
+		pool: pool,
+	}, nil
+}
+
+func (r PostgresqlRepository) appleRefreshTokens(ctx context.Context, ids []string) ([]string, error) {
+	const sql = `
+		SELECT token FROM apple_refresh_tokens
+		WHERE user_id = ANY($1);
+	`
+
+	rows, err := r.pool.Query(ctx, sql, ids)
+	if err != nil {
+		return nil, postgresql.HandleQueryErr(err)
+	}
+
+	var tokens []string
+	for rows.Next() {
+		var token string
+		if scanError := rows.Scan(&token); scanError != nil {
+			err = errors.Join(scanError, err)
+			continue
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, postgresql.HandleQueryErr(errors.Join(err, rows.Err()))
+}
+
+func (r PostgresqlRepository) deleteUsers(ctx context.Context, ids []string) error {
+	const sql = `
+		DELETE FROM users
+		WHERE id = ANY($1);
+	`
+
+	_, err := r.pool.Exec(ctx, sql, ids)
+	return postgresql.HandleQueryErr(err)
+}
@@ -0,0 +1,85 @@
+package idusrdeleter
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type (
+	repository interface {
+		appleRefreshTokens(ctx context.Context, ids []string) ([]string, error)
+		deleteUsers(ctx context.Context, ids []string) error
+	}
+	AppleTokenRevoker interface {
+		RevokeRefreshToken(ctx context.Context, refreshToken string) error
+	}
+	AvatarRemover interface {
+		Delete(ctx context.Context, userID string) error
+	}
+)
+
+// UserDeleter removes accounts which grace period is over, Apple tokens are revoked first
+// because they are deleted together with the account. Avatar files are removed only after the account is gone.
+type UserDeleter struct {
+	repo         repository
+	appleRevoker AppleTokenRevoker
+	avatars      AvatarRemover
+	logger       *zap.Logger
+}
+
+func New(repo repository, appleRevoker AppleTokenRevoker, avatars AvatarRemover, logger *zap.Logger) *UserDeleter {
+	return &UserDeleter{
+		repo:         repo,
+		appleRevoker: appleRevoker,
+		avatars:      avatars,
+		logger:       logger,
+	}
+}
+
+func (d UserDeleter) DeleteUsers(ctx context.Context, usersIDs []string) error {
+	d.revokeAppleTokens(ctx, usersIDs)
+
+	err := d.repo.deleteUsers(ctx, usersIDs)
+	d.logDeleting(err, usersIDs)
+	if err != nil {
+		return err
+	}
+
+	d.removeAvatars(ctx, usersIDs)
+	return nil
+}
+
+func (d UserDeleter) removeAvatars(ctx context.Context, usersIDs []string) {
+	for _, id := range usersIDs {
+		if err := d.avatars.Delete(ctx, id); err != nil {
+			d.logger.Error("Failed to remove avatar", zap.Error(err), zap.String("id", id))
+		}
+	}
+}
+
+func (d UserDeleter) revokeAppleTokens(ctx context.Context, usersIDs []string) {
+	tokens, err := d.repo.appleRefreshTokens(ctx, usersIDs)
+	if err != nil {
+		d.logger.Error("Failed to get Apple tokens", zap.Error(err), zap.Strings("ids", usersIDs))
+		return
+	}
+
+	for _, token := range tokens {
+		if err := d.appleRevoker.RevokeRefreshToken(ctx, token); err != nil {
+			d.logger.Error("Failed to revoke Apple token", zap.Error(err))
+		}
+	}
+}
+
+func (d UserDeleter) logDeleting(err error, usersIDs []string) {
+	msg := "Successfully deleted"
+	logLvl := zapcore.InfoLevel
+	if err != nil {
+		msg = "Failed to delete"
+		logLvl = zapcore.ErrorLevel
+	}
+
+	d.logger.Log(logLvl, msg, zap.Error(err), zap.Strings("ids", usersIDs))
+}
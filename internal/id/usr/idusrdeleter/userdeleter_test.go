@@ -0,0 +1,95 @@
+package idusrdeleter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type appleRevokerStub struct {
+	revoked []string
+	err     error
+}
+
+func (s *appleRevokerStub) RevokeRefreshToken(_ context.Context, refreshToken string) error {
+	s.revoked = append(s.revoked, refreshToken)
+	return s.err
+}
+
+type avatarRemoverStub struct {
+	removed []string
+	err     error
+}
+
+func (s *avatarRemoverStub) Delete(_ context.Context, userID string) error {
+	s.removed = append(s.removed, userID)
+	return s.err
+}
+
+func TestUserDeleter_DeleteUsers(t *testing.T) {
+	ids := []string{"first", "second"}
+
+	tests := []struct {
+		name        string
+		tokens      []string
+		tokensErr   error
+		revokeErr   error
+		deleteErr   error
+		removeErr   error
+		wantRemoved []string
+	}{
+		{
+			name:        "tokens are revoked",
+			tokens:      []string{"token1", "token2"},
+			wantRemoved: ids,
+		},
+		{
+			name:        "without apple tokens",
+			wantRemoved: ids,
+		},
+		{
+			name:        "failed revoke does not stop deletion",
+			tokens:      []string{"token1"},
+			revokeErr:   errors.New("apple is unavailable"),
+			wantRemoved: ids,
+		},
+		{
+			name:        "failed tokens search does not stop deletion",
+			tokensErr:   errors.New("db error"),
+			wantRemoved: ids,
+		},
+		{
+			name:        "failed avatar removal is not returned",
+			removeErr:   errors.New("disk error"),
+			wantRemoved: ids,
+		},
+		{
+			name:      "failed deletion is returned",
+			tokens:    []string{"token1"},
+			deleteErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockrepository(t)
+			repo.EXPECT().appleRefreshTokens(mock.Anything, ids).Return(tt.tokens, tt.tokensErr)
+			repo.EXPECT().deleteUsers(mock.Anything, ids).Return(tt.deleteErr)
+
+			var (
+				revoker = &appleRevokerStub{err: tt.revokeErr}
+				avatars = &avatarRemoverStub{err: tt.removeErr}
+			)
+
+			err := New(repo, revoker, avatars, zap.NewNop()).DeleteUsers(context.Background(), ids)
+
+			assert.ErrorIs(t, err, tt.deleteErr)
+			assert.Equal(t, tt.tokens, revoker.revoked)
+			assert.Equal(t, tt.wantRemoved, avatars.removed)
+		})
+	}
+}
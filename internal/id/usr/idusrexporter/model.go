@@ -0,0 +1,27 @@
+package idusrexporter
+
+import "time"
+
+type (
+	profile struct {
+		ID             string `json:"id"`
+		Username       string `json:"username"`
+		Language       string `json:"language,omitempty"`
+		TimeZone       string `json:"time_zone,omitempty"`
+		MarketingOptIn bool   `json:"marketing_opt_in"`
+	}
+	email struct {
+		Email       string `json:"email"`
+		IsActive    bool   `json:"is_active"`
+		IsConfirmed bool   `json:"is_confirmed"`
+	}
+	identity struct {
+		Provider    string    `json:"provider"`
+		ConnectedAt time.Time `json:"connected_at"`
+	}
+	session struct {
+		Device    string    `json:"device"`
+		StartTime time.Time `json:"start_time"`
+		IsActive  bool      `json:"is_active"`
+	}
+)
@@ -0,0 +1,121 @@
+package idusrexporter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zhuboris/never-expires/internal/shared/postgresql"
+)
+
+type PostgresqlRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresqlRepository(pool *pgxpool.Pool) (*PostgresqlRepository, error) {
+	if pool == nil {
+		return nil, postgresql.ErrPoolInitRequired
+	}
+
+	return &PostgresqlRepository{
+		pool: pool,
+	}, nil
+}
+
+func (r PostgresqlRepository) profile(ctx context.Context, userID string) (profile, error) {
+	const sql = `
+		SELECT
+		    u.id,
+		    u.username,
+		    COALESCE(p.language, ''),
+		    COALESCE(p.time_zone, ''),
+		    COALESCE(p.marketing_opt_in, FALSE)
+		FROM users u
+		LEFT JOIN user_profiles p ON p.user_id = u.id
+		WHERE u.id = $1;
+	`
+
+	var result profile
+	err := r.pool.QueryRow(ctx, sql, userID).
+		Scan(&result.ID, &result.Username, &result.Language, &result.TimeZone, &result.MarketingOptIn)
+
+	return result, postgresql.HandleQueryErr(err)
+}
+
+func (r PostgresqlRepository) emails(ctx context.Context, userID string) ([]email, error) {
+	const sql = `
+		SELECT email, is_active, is_confirmed FROM emails
+		WHERE owner_id = $1;
+	`
+
+	rows, err := r.pool.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, postgresql.HandleQueryErr(err)
+	}
+
+	var emails []email
+	for rows.Next() {
+		var result email
+		if scanError := rows.Scan(&result.Email, &result.IsActive, &result.IsConfirmed); scanError != nil {
+			err = errors.Join(scanError, err)
+			continue
+		}
+
+		emails = append(emails, result)
+	}
+
+	return emails, postgresql.HandleQueryErr(errors.Join(err, rows.Err()))
+}
+
+func (r PostgresqlRepository) identities(ctx context.Context, userID string) ([]identity, error) {
+	const sql = `
+		SELECT provider, connected_at FROM external_identities
+		WHERE user_id = $1
+		ORDER BY connected_at;
+	`
+
+	rows, err := r.pool.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, postgresql.HandleQueryErr(err)
+	}
+
+	var identities []identity
+	for rows.Next() {
+		var result identity
+		if scanError := rows.Scan(&result.Provider, &result.ConnectedAt); scanError != nil {
+			err = errors.Join(scanError, err)
+			continue
+		}
+
+		identities = append(identities, result)
+	}
+
+	return identities, postgresql.HandleQueryErr(errors.Join(err, rows.Err()))
+}
+
+func (r PostgresqlRepository) sessions(ctx context.Context, userID string) ([]session, error) {
+	const sql = `
+		SELECT device, start_time, is_active FROM sessions
+		WHERE user_id = $1
+		ORDER BY start_time;
+	`
+
+	rows, err := r.pool.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, postgresql.HandleQueryErr(err)
+	}
+
+	var sessions []session
+	for rows.Next() {
+		var result session
+		if scanError := rows.Scan(&result.Device, &result.StartTime, &result.IsActive); scanError != nil {
+			err = errors.Join(scanError, err)
+			continue
+		}
+
+		sessions = append(sessions, result)
+	}
+
+	return sessions, postgresql.HandleQueryErr(errors.Join(err, rows.Err()))
+}
@@ -0,0 +1,70 @@
+package idusrexporter
+
+import (
+	"context"
+
+	"github.com/zhuboris/never-expires/internal/shared/dataexport"
+)
+
+const exportDir = "account"
+
+type repository interface {
+	profile(ctx context.Context, userID string) (profile, error)
+	emails(ctx context.Context, userID string) ([]email, error)
+	identities(ctx context.Context, userID string) ([]identity, error)
+	sessions(ctx context.Context, userID string) ([]session, error)
+}
+
+type UserExporter struct {
+	repo repository
+}
+
+func New(repo repository) *UserExporter {
+	return &UserExporter{
+		repo: repo,
+	}
+}
+
+func (e UserExporter) ExportUserData(ctx context.Context, userID string) ([]dataexport.File, error) {
+	userProfile, err := e.repo.profile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	emails, err := e.repo.emails(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	identities, err := e.repo.identities(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := e.repo.sessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	toExport := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", userProfile},
+		{"emails.json", emails},
+		{"external_identities.json", identities},
+		{"sessions.json", sessions},
+	}
+
+	files := make([]dataexport.File, 0, len(toExport))
+	for _, item := range toExport {
+		file, err := dataexport.JSONFile(item.name, item.data)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, file)
+	}
+
+	return dataexport.InDir(exportDir, files...), nil
+}
@@ -9,4 +9,16 @@ var (
 	ErrValidationRefused          = errors.New("validation is refused, inputted email cannot be confirmed with provided token")
 	ErrPasswordResetRefused       = errors.New("password reset is refused")
 	ErrNotConfirmedOrChangedEmail = errors.New("email is not confirmed or was changed")
+	ErrEmailNotConfirmed          = errors.New("email must be confirmed to do this")
+	ErrLoginCodeRefused           = errors.New("login with provided code is refused")
+	ErrIdentityAlreadyLinked      = errors.New("external account is already linked to this or other user")
+	ErrIdentityNotLinked          = errors.New("external account of requested provider is not linked")
+	ErrLastLoginMethod            = errors.New("cannot unlink the only login method of the account")
+	ErrRestoreRefused             = errors.New("account restore is refused")
+	ErrEmailChangeRefused         = errors.New("email change is refused")
+	ErrInvalidTimeZone            = errors.New("invalid IANA time zone name")
+	ErrAvatarTooLarge             = errors.New("avatar file is too large")
+	ErrUnsupportedAvatar          = errors.New("avatar must be PNG, JPEG or WebP image")
+	ErrAvatarNotFound             = errors.New("user has no avatar")
+	ErrAccountDisabled            = errors.New("account is disabled, contact support")
 )
@@ -0,0 +1,75 @@
+package usr
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	loginCodeDigits         = 6
+	MaxLoginCodeFailedTries = 5
+)
+
+type LoginCode struct {
+	ConfirmationToken
+
+	Code           string `json:"-"`
+	CodeHash       string `json:"-"`
+	FailedAttempts int    `json:"failed_attempts"`
+}
+
+func newLoginCode(lifetime time.Duration) (LoginCode, error) {
+	token, err := newConfirmationToken(lifetime)
+	if err != nil {
+		return LoginCode{}, err
+	}
+
+	code, err := makeDigitsCode(loginCodeDigits)
+	if err != nil {
+		return LoginCode{}, err
+	}
+
+	return LoginCode{
+		ConfirmationToken: token,
+		Code:              code,
+		CodeHash:          hashLoginCode(token.Value, code),
+	}, nil
+}
+
+func (c LoginCode) matches(code string) bool {
+	return subtle.ConstantTimeCompare([]byte(c.CodeHash), []byte(hashLoginCode(c.Value, code))) == 1
+}
+
+func (c LoginCode) isActive() bool {
+	return !c.IsUsed &&
+		c.FailedAttempts < MaxLoginCodeFailedTries &&
+		time.Now().Before(c.ExpirationTime)
+}
+
+func (c LoginCode) InvalidityReason() error {
+	if c.FailedAttempts >= MaxLoginCodeFailedTries {
+		return errTooManyCodeAttempts
+	}
+
+	return c.ConfirmationToken.InvalidityReason()
+}
+
+func makeDigitsCode(digits int) (string, error) {
+	upperBound := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	n, err := rand.Int(rand.Reader, upperBound)
+	if err != nil {
+		return "", fmt.Errorf("unexpected error occurred while making random code: %w", err)
+	}
+
+	return fmt.Sprintf("%0*d", digits, n), nil
+}
+
+func hashLoginCode(salt, code string) string {
+	sum := sha256.Sum256([]byte(salt + code))
+	return hex.EncodeToString(sum[:])
+}
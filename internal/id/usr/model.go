@@ -34,9 +34,10 @@ type User struct {
 }
 
 type PublicData struct {
-	Username         string `json:"username"`
-	Email            string `json:"email"`
-	IsEmailConfirmed bool   `json:"is_email_confirmed"`
+	Username         string   `json:"username"`
+	Email            string   `json:"email"`
+	IsEmailConfirmed bool     `json:"is_email_confirmed"`
+	Profile          *Profile `json:"profile,omitempty"`
 }
 
 func (u User) PublicData() *PublicData {
@@ -12,6 +12,7 @@ import (
 	"github.com/zhuboris/never-expires/internal/id/tkn"
 	"github.com/zhuboris/never-expires/internal/id/usr/oauth"
 	"github.com/zhuboris/never-expires/internal/id/usr/oauth/applesignin/idtokenapple"
+	"github.com/zhuboris/never-expires/internal/shared/appleconfig"
 )
 
 type validator interface {
@@ -25,13 +26,8 @@ type Service struct {
 	validator    validator
 }
 
-func NewService() (*Service, error) {
-	envs, err := newConfig()
-	if err != nil {
-		return nil, err
-	}
-
-	secret, err := apple.GenerateClientSecret(envs.privateKey, envs.teamID, envs.bundleID, envs.keyID)
+func NewService(config appleconfig.Config) (*Service, error) {
+	secret, err := apple.GenerateClientSecret(config.PrivateKey, config.TeamID, config.BundleID, config.KeyID)
 	if err != nil {
 		return nil, fmt.Errorf("error generating secret: %w", err)
 	}
@@ -39,8 +35,8 @@ func NewService() (*Service, error) {
 	return &Service{
 		client:       apple.New(),
 		clientSecret: secret,
-		clientID:     envs.bundleID,
-		validator:    idtokenapple.NewValidator(envs.bundleID),
+		clientID:     config.BundleID,
+		validator:    idtokenapple.NewValidator(config.BundleID),
 	}, nil
 }
 
@@ -3,7 +3,6 @@ package googleoauthios
 import (
 	"context"
 	"errors"
-	"os"
 
 	"google.golang.org/api/idtoken"
 
@@ -20,18 +19,15 @@ type Service struct {
 	validator validator
 }
 
-func NewService() (*Service, error) {
-	const clientIDEnvKey = "IOS_OAUTH_CLIENT_ID"
-
-	clientID := os.Getenv(clientIDEnvKey)
-	if clientID == "" {
-		return nil, errors.New("clientID env not set")
-	}
+type Config struct {
+	ClientID string `env:"IOS_OAUTH_CLIENT_ID" required:"true"`
+}
 
+func NewService(config Config) *Service {
 	return &Service{
-		clientID:  clientID,
+		clientID:  config.ClientID,
 		validator: idTokenValidator{},
-	}, nil
+	}
 }
 
 func (s Service) UserFromToken(ctx context.Context, idToken oauth.Token) (oauth.User, error) {
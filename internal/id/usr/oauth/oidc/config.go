@@ -0,0 +1,102 @@
+package oidc
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/zhuboris/never-expires/internal/id/usr/oauth"
+	"github.com/zhuboris/never-expires/internal/shared/config"
+)
+
+var defaultScopes = []string{"openid", "email", "profile"}
+
+var providerNameRegexp = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// reservedProviderNames are used by built-in providers, identities of OIDC provider with such name would be mixed with theirs.
+var reservedProviderNames = []string{oauth.GoogleProviderName, oauth.AppleProviderName}
+
+type Config struct {
+	Name        string
+	DisplayName string
+	Issuer      string
+	ClientID    string
+	Scopes      []string
+}
+
+type providersConfig struct {
+	Names []string `env:"OIDC_PROVIDERS"`
+}
+
+type providerConfig struct {
+	Issuer      string `env:"ISSUER" required:"true"`
+	ClientID    string `env:"CLIENT_ID" required:"true"`
+	Scopes      string `env:"SCOPES"`
+	DisplayName string `env:"DISPLAY_NAME"`
+}
+
+// ConfigsFrom reads comma separated provider names from OIDC_PROVIDERS and
+// for each name reads OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID and optional
+// OIDC_<NAME>_SCOPES and OIDC_<NAME>_DISPLAY_NAME.
+func ConfigsFrom(source *config.Source) ([]Config, error) {
+	var providers providersConfig
+	if err := source.Load(&providers); err != nil {
+		return nil, err
+	}
+
+	var configs []Config
+	for _, name := range providers.Names {
+		providerConfig, err := configFrom(source, name)
+		if err != nil {
+			return nil, err
+		}
+
+		configs = append(configs, providerConfig)
+	}
+
+	return configs, nil
+}
+
+func configFrom(source *config.Source, name string) (Config, error) {
+	if !providerNameRegexp.MatchString(name) {
+		return Config{}, fmt.Errorf("%w: %q", errInvalidProviderName, name)
+	}
+
+	if slices.Contains(reservedProviderNames, name) {
+		return Config{}, fmt.Errorf("%w: %q", errReservedProviderName, name)
+	}
+
+	var (
+		prefix   = "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		provider providerConfig
+	)
+
+	if err := source.LoadPrefixed(prefix, &provider); err != nil {
+		return Config{}, err
+	}
+
+	scopes := parseScopes(provider.Scopes)
+	if len(scopes) == 0 {
+		scopes = defaultScopes
+	}
+
+	displayName := provider.DisplayName
+	if displayName == "" {
+		displayName = name
+	}
+
+	return Config{
+		Name:        name,
+		DisplayName: displayName,
+		Issuer:      provider.Issuer,
+		ClientID:    provider.ClientID,
+		Scopes:      scopes,
+	}, nil
+}
+
+func parseScopes(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
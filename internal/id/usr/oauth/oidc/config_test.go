@@ -0,0 +1,102 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhuboris/never-expires/internal/shared/config"
+)
+
+func TestConfigsFrom(t *testing.T) {
+	tests := []struct {
+		name      string
+		envs      map[string]string
+		expected  []Config
+		wantError bool
+	}{
+		{
+			name:     "no providers",
+			envs:     map[string]string{"OIDC_PROVIDERS": ""},
+			expected: nil,
+		},
+		{
+			name: "providers with defaults and custom values",
+			envs: map[string]string{
+				"OIDC_PROVIDERS":              "microsoft, my-gitlab",
+				"OIDC_MICROSOFT_ISSUER":       "https://login.microsoftonline.com/tenant/v2.0",
+				"OIDC_MICROSOFT_CLIENT_ID":    "ms-client",
+				"OIDC_MICROSOFT_DISPLAY_NAME": "Microsoft",
+				"OIDC_MY_GITLAB_ISSUER":       "https://gitlab.com",
+				"OIDC_MY_GITLAB_CLIENT_ID":    "gitlab-client",
+				"OIDC_MY_GITLAB_SCOPES":       "openid,email",
+				"OIDC_MY_GITLAB_DISPLAY_NAME": "",
+				"OIDC_MICROSOFT_SCOPES":       "",
+			},
+			expected: []Config{
+				{
+					Name:        "microsoft",
+					DisplayName: "Microsoft",
+					Issuer:      "https://login.microsoftonline.com/tenant/v2.0",
+					ClientID:    "ms-client",
+					Scopes:      []string{"openid", "email", "profile"},
+				},
+				{
+					Name:        "my-gitlab",
+					DisplayName: "my-gitlab",
+					Issuer:      "https://gitlab.com",
+					ClientID:    "gitlab-client",
+					Scopes:      []string{"openid", "email"},
+				},
+			},
+		},
+		{
+			name: "missing client id",
+			envs: map[string]string{
+				"OIDC_PROVIDERS":           "gitlab",
+				"OIDC_GITLAB_ISSUER":       "https://gitlab.com",
+				"OIDC_GITLAB_CLIENT_ID":    "",
+				"OIDC_GITLAB_SCOPES":       "",
+				"OIDC_GITLAB_DISPLAY_NAME": "",
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid provider name",
+			envs: map[string]string{
+				"OIDC_PROVIDERS": "Git_Lab",
+			},
+			wantError: true,
+		},
+		{
+			name: "reserved provider name",
+			envs: map[string]string{
+				"OIDC_PROVIDERS":        "google",
+				"OIDC_GOOGLE_ISSUER":    "https://accounts.google.com",
+				"OIDC_GOOGLE_CLIENT_ID": "google-client",
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.envs {
+				t.Setenv(key, value)
+			}
+
+			source, err := config.NewSource()
+			require.NoError(t, err)
+
+			configs, err := ConfigsFrom(source)
+			if tt.wantError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, configs)
+		})
+	}
+}
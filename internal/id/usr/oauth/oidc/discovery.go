@@ -0,0 +1,57 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const discoveryPath = "/.well-known/openid-configuration"
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+func fetchDiscoveryDocument(ctx context.Context, client *http.Client, issuer string) (discoveryDocument, error) {
+	url := strings.TrimSuffix(issuer, "/") + discoveryPath
+
+	var document discoveryDocument
+	if err := getJSON(ctx, client, url, &document); err != nil {
+		return discoveryDocument{}, errors.Join(ErrDiscoveryFailed, err)
+	}
+
+	if document.Issuer != issuer {
+		return discoveryDocument{}, fmt.Errorf("%w: issuer %q does not match configured %q", ErrDiscoveryFailed, document.Issuer, issuer)
+	}
+
+	if document.JWKSURI == "" {
+		return discoveryDocument{}, fmt.Errorf("%w: missing jwks_uri", ErrDiscoveryFailed)
+	}
+
+	return document, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, dest any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status %d from %s", resp.StatusCode, url)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(dest)
+}
@@ -0,0 +1,14 @@
+package oidc
+
+import "errors"
+
+var (
+	ErrUnknownProvider      = errors.New("requested identity provider is not configured")
+	ErrKeyNotFound          = errors.New("signing key for given kid not found")
+	ErrDiscoveryFailed      = errors.New("failed to discover provider configuration")
+	errInvalidProviderName  = errors.New("provider name may contain only lowercase latin letters, digits and dashes")
+	errReservedProviderName = errors.New("provider name is reserved for built-in provider")
+	errUnsupportedKey       = errors.New("unsupported json web key")
+	errMissingExpiration    = errors.New("token has no expiration time")
+	errWrongAuthorizedParty = errors.New("token is issued for other authorized party")
+)
@@ -0,0 +1,138 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// mockServer is a minimal OpenID Connect provider serving discovery document and keys.
+type mockServer struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	keys           map[string]any
+	keysRequests   int
+	discoveryCalls int
+}
+
+func newMockServer(t *testing.T) *mockServer {
+	t.Helper()
+
+	s := &mockServer{
+		keys: make(map[string]any),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.discoveryCalls++
+		s.mu.Unlock()
+
+		writeJSON(w, discoveryDocument{
+			Issuer:                s.URL,
+			AuthorizationEndpoint: s.URL + "/authorize",
+			JWKSURI:               s.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.keysRequests++
+		var resp keysResponse
+		for kid, key := range s.keys {
+			resp.Keys = append(resp.Keys, toJSONWebKey(kid, key))
+		}
+
+		writeJSON(w, resp)
+	})
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *mockServer) addRSAKey(t *testing.T, kid string) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	s.mu.Lock()
+	s.keys[kid] = key
+	s.mu.Unlock()
+	return key
+}
+
+func (s *mockServer) addECKey(t *testing.T, kid string) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	s.mu.Lock()
+	s.keys[kid] = key
+	s.mu.Unlock()
+	return key
+}
+
+func (s *mockServer) keysRequestsCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.keysRequests
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key any, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func toJSONWebKey(kid string, key any) jsonWebKey {
+	encode := base64.RawURLEncoding.EncodeToString
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return jsonWebKey{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			N:   encode(k.N.Bytes()),
+			E:   encode(big.NewInt(int64(k.E)).Bytes()),
+		}
+	case *ecdsa.PrivateKey:
+		return jsonWebKey{
+			Kty: "EC",
+			Kid: kid,
+			Use: "sig",
+			Crv: "P-256",
+			X:   encode(k.X.FillBytes(make([]byte, 32))),
+			Y:   encode(k.Y.FillBytes(make([]byte, 32))),
+		}
+	default:
+		panic("unsupported key type")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(data)
+}
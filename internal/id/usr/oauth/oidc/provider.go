@@ -0,0 +1,126 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/zhuboris/never-expires/internal/id/tkn"
+	"github.com/zhuboris/never-expires/internal/id/usr/oauth"
+)
+
+const clockSkewLeeway = time.Minute
+
+var supportedSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+type ProviderInfo struct {
+	Name        string   `json:"name"`
+	DisplayName string   `json:"display_name"`
+	Issuer      string   `json:"issuer"`
+	ClientID    string   `json:"client_id"`
+	Scopes      []string `json:"scopes"`
+}
+
+type Provider struct {
+	config    Config
+	client    *http.Client
+	discovery *discoveryDocument
+	keys      *keySet
+	mu        sync.Mutex
+}
+
+func NewProvider(config Config, client *http.Client) *Provider {
+	return &Provider{
+		config: config,
+		client: client,
+	}
+}
+
+func (p *Provider) Info() ProviderInfo {
+	return ProviderInfo{
+		Name:        p.config.Name,
+		DisplayName: p.config.DisplayName,
+		Issuer:      p.config.Issuer,
+		ClientID:    p.config.ClientID,
+		Scopes:      p.config.Scopes,
+	}
+}
+
+func (p *Provider) UserFromToken(ctx context.Context, token oauth.Token) (oauth.User, error) {
+	claims, err := p.validate(ctx, token.IDToken.TokenString)
+	if err != nil {
+		return oauth.User{}, errors.Join(tkn.ErrUnauthorized, oauth.ErrInvalidToken, err)
+	}
+
+	data, err := oauth.NewClaimsData(claims)
+	if err != nil {
+		return oauth.User{}, errors.Join(tkn.ErrUnauthorized, err)
+	}
+
+	return data.CastToUser()
+}
+
+func (p *Provider) validate(ctx context.Context, idToken string) (jwt.MapClaims, error) {
+	discovery, keys, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(supportedSigningMethods),
+		jwt.WithIssuer(discovery.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkewLeeway),
+	)
+
+	keyFunc := func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return keys.key(ctx, kid)
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := parser.ParseWithClaims(idToken, claims, keyFunc); err != nil {
+		return nil, err
+	}
+
+	if err := p.checkRequiredClaims(claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (p *Provider) checkRequiredClaims(claims jwt.MapClaims) error {
+	if exp, err := claims.GetExpirationTime(); err != nil || exp == nil {
+		return errMissingExpiration
+	}
+
+	if azp, ok := claims["azp"].(string); ok && azp != p.config.ClientID {
+		return errWrongAuthorizedParty
+	}
+
+	return nil
+}
+
+func (p *Provider) discover(ctx context.Context) (discoveryDocument, *keySet, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return *p.discovery, p.keys, nil
+	}
+
+	document, err := fetchDiscoveryDocument(ctx, p.client, p.config.Issuer)
+	if err != nil {
+		return discoveryDocument{}, nil, err
+	}
+
+	p.discovery = &document
+	p.keys = newKeySet(p.client, document.JWKSURI)
+	return document, p.keys, nil
+}
@@ -0,0 +1,239 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhuboris/never-expires/internal/id/tkn"
+	"github.com/zhuboris/never-expires/internal/id/usr/oauth"
+)
+
+const testClientID = "test-client-id"
+
+func TestProvider_UserFromToken(t *testing.T) {
+	server := newMockServer(t)
+	rsaKey := server.addRSAKey(t, "rsa-key")
+	ecKey := server.addECKey(t, "ec-key")
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":            server.URL,
+			"aud":            testClientID,
+			"sub":            "subject-1",
+			"email":          "user@test.com",
+			"email_verified": true,
+			"name":           "Test User",
+			"iat":            time.Now().Unix(),
+			"exp":            time.Now().Add(time.Hour).Unix(),
+		}
+	}
+
+	tests := []struct {
+		name      string
+		token     func() string
+		wantError bool
+	}{
+		{
+			name: "valid rsa signed token",
+			token: func() string {
+				return signToken(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, validClaims())
+			},
+		},
+		{
+			name: "valid ec signed token",
+			token: func() string {
+				return signToken(t, jwt.SigningMethodES256, "ec-key", ecKey, validClaims())
+			},
+		},
+		{
+			name: "audience in list with matching authorized party",
+			token: func() string {
+				claims := validClaims()
+				claims["aud"] = []string{"other-client", testClientID}
+				claims["azp"] = testClientID
+				return signToken(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, claims)
+			},
+		},
+		{
+			name: "other authorized party",
+			token: func() string {
+				claims := validClaims()
+				claims["azp"] = "other-client"
+				return signToken(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, claims)
+			},
+			wantError: true,
+		},
+		{
+			name: "wrong audience",
+			token: func() string {
+				claims := validClaims()
+				claims["aud"] = "other-client"
+				return signToken(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, claims)
+			},
+			wantError: true,
+		},
+		{
+			name: "wrong issuer",
+			token: func() string {
+				claims := validClaims()
+				claims["iss"] = "https://evil.test"
+				return signToken(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, claims)
+			},
+			wantError: true,
+		},
+		{
+			name: "expired",
+			token: func() string {
+				claims := validClaims()
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+				return signToken(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, claims)
+			},
+			wantError: true,
+		},
+		{
+			name: "missing expiration",
+			token: func() string {
+				claims := validClaims()
+				delete(claims, "exp")
+				return signToken(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, claims)
+			},
+			wantError: true,
+		},
+		{
+			name: "signed with other key",
+			token: func() string {
+				return signToken(t, jwt.SigningMethodRS256, "rsa-key", otherKey, validClaims())
+			},
+			wantError: true,
+		},
+		{
+			name: "unknown kid",
+			token: func() string {
+				return signToken(t, jwt.SigningMethodRS256, "unknown-key", otherKey, validClaims())
+			},
+			wantError: true,
+		},
+		{
+			name: "unsigned token",
+			token: func() string {
+				token := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims())
+				signed, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+				require.NoError(t, err)
+				return signed
+			},
+			wantError: true,
+		},
+		{
+			name: "hmac signed with public key",
+			token: func() string {
+				return signToken(t, jwt.SigningMethodHS256, "rsa-key", rsaKey.N.Bytes(), validClaims())
+			},
+			wantError: true,
+		},
+		{
+			name: "missing email",
+			token: func() string {
+				claims := validClaims()
+				delete(claims, "email")
+				return signToken(t, jwt.SigningMethodRS256, "rsa-key", rsaKey, claims)
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewProvider(testConfig(server.URL), http.DefaultClient)
+
+			var token oauth.Token
+			token.IDToken.TokenString = tt.token()
+
+			user, err := provider.UserFromToken(context.Background(), token)
+			if tt.wantError {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tkn.ErrUnauthorized)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "subject-1", user.ID())
+			assert.Equal(t, "user@test.com", user.Email())
+			assert.Equal(t, "Test User", user.Name())
+			assert.True(t, user.IsEmailVerified())
+		})
+	}
+}
+
+func TestProvider_UserFromToken_keysRotation(t *testing.T) {
+	server := newMockServer(t)
+	oldKey := server.addRSAKey(t, "old-key")
+	provider := NewProvider(testConfig(server.URL), http.DefaultClient)
+
+	claims := jwt.MapClaims{
+		"iss":   server.URL,
+		"aud":   testClientID,
+		"sub":   "subject-1",
+		"email": "user@test.com",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+
+	var token oauth.Token
+	token.IDToken.TokenString = signToken(t, jwt.SigningMethodRS256, "old-key", oldKey, claims)
+	_, err := provider.UserFromToken(context.Background(), token)
+	require.NoError(t, err)
+	require.Equal(t, 1, server.keysRequestsCount())
+
+	_, err = provider.UserFromToken(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, 1, server.keysRequestsCount(), "known keys must be cached")
+
+	newKey := server.addRSAKey(t, "new-key")
+	token.IDToken.TokenString = signToken(t, jwt.SigningMethodRS256, "new-key", newKey, claims)
+	_, err = provider.UserFromToken(context.Background(), token)
+	assert.ErrorIs(t, err, ErrKeyNotFound, "keys must not be refetched more often than allowed")
+	assert.Equal(t, 1, server.keysRequestsCount())
+
+	provider.keys.fetchedAt = time.Now().Add(-minKeysRefreshInterval)
+	_, err = provider.UserFromToken(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.keysRequestsCount())
+}
+
+func TestProvider_UserFromToken_discoveryIssuerMismatch(t *testing.T) {
+	server := newMockServer(t)
+	key := server.addRSAKey(t, "key")
+
+	config := testConfig(server.URL + "/")
+	provider := NewProvider(config, http.DefaultClient)
+
+	var token oauth.Token
+	token.IDToken.TokenString = signToken(t, jwt.SigningMethodRS256, "key", key, jwt.MapClaims{
+		"iss": server.URL,
+		"aud": testClientID,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	_, err := provider.UserFromToken(context.Background(), token)
+	assert.ErrorIs(t, err, ErrDiscoveryFailed)
+}
+
+func testConfig(issuer string) Config {
+	return Config{
+		Name:        "test",
+		DisplayName: "Test",
+		Issuer:      issuer,
+		ClientID:    testClientID,
+		Scopes:      defaultScopes,
+	}
+}
@@ -0,0 +1,67 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/zhuboris/never-expires/internal/id/usr/oauth"
+)
+
+const requestTimeout = 10 * time.Second
+
+type Registry struct {
+	providers map[string]*Provider
+}
+
+func NewRegistry(configs []Config) (*Registry, error) {
+	return newRegistry(configs, &http.Client{Timeout: requestTimeout})
+}
+
+func newRegistry(configs []Config, client *http.Client) (*Registry, error) {
+	providers := make(map[string]*Provider, len(configs))
+	for _, config := range configs {
+		if _, ok := providers[config.Name]; ok {
+			return nil, fmt.Errorf("provider %q is configured twice", config.Name)
+		}
+
+		providers[config.Name] = NewProvider(config, client)
+	}
+
+	return &Registry{
+		providers: providers,
+	}, nil
+}
+
+func (r Registry) Provider(name string) (*Provider, error) {
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	return provider, nil
+}
+
+func (r Registry) UserFromToken(ctx context.Context, providerName string, token oauth.Token) (oauth.User, error) {
+	provider, err := r.Provider(providerName)
+	if err != nil {
+		return oauth.User{}, err
+	}
+
+	return provider.UserFromToken(ctx, token)
+}
+
+func (r Registry) Providers() []ProviderInfo {
+	result := make([]ProviderInfo, 0, len(r.providers))
+	for _, provider := range r.providers {
+		result = append(result, provider.Info())
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
@@ -6,3 +6,8 @@ const (
 	GoogleAccount Type = iota + 1
 	AppleID
 )
+
+const (
+	GoogleProviderName = "google"
+	AppleProviderName  = "apple"
+)
@@ -1,23 +1,31 @@
 package usr
 
+import "github.com/zhuboris/never-expires/internal/id/usr/oauth"
+
 type (
-	serviceTableName string
-	oAuthMethod      func() serviceTableName
+	oAuthProvider string
+	oAuthMethod   func() oAuthProvider
 )
 
 const (
-	appleIDsTableName  = "apple_ids"
-	googleIDsTableName = "google_ids"
+	appleProvider  oAuthProvider = oauth.AppleProviderName
+	googleProvider oAuthProvider = oauth.GoogleProviderName
 )
 
 func withApple() oAuthMethod {
-	return func() serviceTableName {
-		return appleIDsTableName
+	return func() oAuthProvider {
+		return appleProvider
 	}
 }
 
 func withGoogle() oAuthMethod {
-	return func() serviceTableName {
-		return googleIDsTableName
+	return func() oAuthProvider {
+		return googleProvider
+	}
+}
+
+func withOIDC(providerName string) oAuthMethod {
+	return func() oAuthProvider {
+		return oAuthProvider(providerName)
 	}
 }
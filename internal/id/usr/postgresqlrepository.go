@@ -3,7 +3,6 @@ package usr
 import (
 	"context"
 	"errors"
-	"fmt"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -94,20 +93,163 @@ func (r PostgresqlRepository) byID(ctx context.Context, id pgtype.UUID) (*User,
 	return user, postgresql.HandleQueryErr(err)
 }
 
-func (r PostgresqlRepository) delete(ctx context.Context, id pgtype.UUID) error {
+func (r PostgresqlRepository) profile(ctx context.Context, userID pgtype.UUID) (Profile, error) {
 	const sql = `
-		WITH saved_deleted_id AS (
-		    INSERT INTO users_to_delete (id) VALUES ($1)
-			ON CONFLICT (id) DO NOTHING			
+		SELECT
+		    COALESCE(language, ''),
+		    COALESCE(time_zone, ''),
+		    avatar_content_type IS NOT NULL,
+		    marketing_opt_in
+		FROM user_profiles
+		WHERE user_id = $1;
+	`
+
+	var profile Profile
+	err := r.pool.
+		QueryRow(ctx, sql, userID).
+		Scan(
+			&profile.Language,
+			&profile.TimeZone,
+			&profile.HasAvatar,
+			&profile.MarketingOptIn,
 		)
-		DELETE FROM users
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return profile, nil
+	}
+
+	return profile, postgresql.HandleQueryErr(err)
+}
+
+func (r PostgresqlRepository) updateProfile(ctx context.Context, userID pgtype.UUID, update ProfileUpdate) error {
+	const sql = `
+		INSERT INTO user_profiles (user_id, language, time_zone, marketing_opt_in)
+		SELECT id, $2, $3, COALESCE($4, FALSE)
+		FROM users
+		WHERE id = $1
+		ON CONFLICT (user_id) DO UPDATE
+		SET language = COALESCE($2, user_profiles.language),
+		    time_zone = COALESCE($3, user_profiles.time_zone),
+		    marketing_opt_in = COALESCE($4, user_profiles.marketing_opt_in),
+		    updated_at = CURRENT_TIMESTAMP;
+	`
+
+	result, err := r.pool.Exec(ctx, sql, userID, update.Language, update.TimeZone, update.MarketingOptIn)
+	if err != nil {
+		return postgresql.HandleQueryErr(err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return postgresql.ErrNoMatches
+	}
+
+	return nil
+}
+
+func (r PostgresqlRepository) avatarContentType(ctx context.Context, userID pgtype.UUID) (string, error) {
+	const sql = `
+		SELECT avatar_content_type
+		FROM user_profiles
+		WHERE user_id = $1
+		AND avatar_content_type IS NOT NULL;
+	`
+
+	var contentType string
+	err := r.pool.
+		QueryRow(ctx, sql, userID).
+		Scan(&contentType)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", errors.Join(postgresql.ErrNoMatches, err)
+	}
+
+	return contentType, postgresql.HandleQueryErr(err)
+}
+
+func (r PostgresqlRepository) setAvatarContentType(ctx context.Context, userID pgtype.UUID, contentType *string) error {
+	const sql = `
+		INSERT INTO user_profiles (user_id, avatar_content_type)
+		SELECT id, $2
+		FROM users
 		WHERE id = $1
+		ON CONFLICT (user_id) DO UPDATE
+		SET avatar_content_type = EXCLUDED.avatar_content_type,
+		    updated_at = CURRENT_TIMESTAMP;
+	`
+
+	result, err := r.pool.Exec(ctx, sql, userID, contentType)
+	if err != nil {
+		return postgresql.HandleQueryErr(err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return postgresql.ErrNoMatches
+	}
+
+	return nil
+}
+
+func (r PostgresqlRepository) scheduleDeletion(ctx context.Context, id pgtype.UUID, restoreToken ConfirmationToken) (email string, err error) {
+	const sql = `
+		WITH scheduled AS (
+		    INSERT INTO users_to_delete (id, restore_token, delete_after)
+		    VALUES ($1, $2, $3)
+		    ON CONFLICT (id) DO UPDATE
+		    SET restore_token = EXCLUDED.restore_token,
+		        delete_after = EXCLUDED.delete_after
+		)
+		SELECT COALESCE(
+		    (SELECT email FROM emails WHERE owner_id = $1 AND is_active = TRUE),
+		    ''
+		);
+	`
+
+	err = r.pool.QueryRow(ctx, sql, id, restoreToken.Value, restoreToken.ExpirationTime).
+		Scan(&email)
+
+	return email, postgresql.HandleQueryErr(err)
+}
+
+func (r PostgresqlRepository) restore(ctx context.Context, restoreToken string) error {
+	const sql = `
+		DELETE FROM users_to_delete
+		WHERE restore_token = $1
+		AND delete_after > CURRENT_TIMESTAMP
+		RETURNING id;
+	`
+
+	var id pgtype.UUID
+	err := r.pool.QueryRow(ctx, sql, restoreToken).
+		Scan(&id)
+
+	return handleSearchingTokenError(err)
+}
+
+func (r PostgresqlRepository) cancelDeletion(ctx context.Context, id pgtype.UUID) error {
+	const sql = `
+		DELETE FROM users_to_delete
+		WHERE id = $1;
 	`
 
 	_, err := r.pool.Exec(ctx, sql, id)
 	return postgresql.HandleQueryErr(err)
 }
 
+func (r PostgresqlRepository) isDisabled(ctx context.Context, id pgtype.UUID) (bool, error) {
+	const sql = `
+		SELECT EXISTS (
+		    SELECT 1 FROM disabled_users
+		    WHERE user_id = $1
+		);
+	`
+
+	var result bool
+	err := r.pool.QueryRow(ctx, sql, id).
+		Scan(&result)
+
+	return result, postgresql.HandleQueryErr(err)
+}
+
 func (r PostgresqlRepository) byEmail(ctx context.Context, email string) (*User, error) {
 	const sql = `
 		SELECT 
@@ -197,6 +339,167 @@ func (r PostgresqlRepository) updateColumn(ctx context.Context, userID pgtype.UU
 	return nil
 }
 
+func (r PostgresqlRepository) addEmailChange(ctx context.Context, userID pgtype.UUID, change EmailChange) (oldEmail string, err error) {
+	const sql = `
+		WITH active_email AS (
+		    SELECT email FROM emails
+		    WHERE owner_id = $1
+		    AND is_active = TRUE
+		), taken_email AS (
+		    SELECT EXISTS (SELECT 1 FROM emails WHERE email = $2) AS is_taken
+		), cancelled_changes AS (
+		    DELETE FROM email_changes
+		    WHERE user_id = $1
+		    AND confirmed_at IS NULL
+		    AND reverted_at IS NULL
+		    AND NOT (SELECT is_taken FROM taken_email)
+		), added_change AS (
+		    INSERT INTO email_changes (user_id, old_email, new_email, confirm_token, confirm_expiration, revert_token, revert_expiration)
+		    SELECT $1, a.email, $2, $3, $4, $5, $6
+		    FROM active_email a, taken_email t
+		    WHERE NOT t.is_taken
+
+		    RETURNING old_email
+		)
+		SELECT
+		    (SELECT is_taken FROM taken_email),
+		    COALESCE((SELECT old_email FROM added_change), '');
+	`
+
+	var isTaken bool
+	err = r.pool.QueryRow(ctx, sql,
+		userID,
+		change.NewEmail,
+		change.ConfirmToken.Value,
+		change.ConfirmToken.ExpirationTime,
+		change.RevertToken.Value,
+		change.RevertToken.ExpirationTime,
+	).Scan(&isTaken, &oldEmail)
+
+	switch {
+	case err != nil:
+		return "", postgresql.HandleQueryErr(err)
+	case isTaken:
+		return "", postgresql.ErrAddedDuplicateOfUnique
+	case oldEmail == "":
+		return "", postgresql.ErrNoMatches
+	default:
+		return oldEmail, nil
+	}
+}
+
+func (r PostgresqlRepository) confirmEmailChange(ctx context.Context, confirmToken string) (AppliedEmailChange, error) {
+	const (
+		confirmSql = `
+			UPDATE email_changes c
+			SET confirmed_at = CURRENT_TIMESTAMP
+			WHERE c.confirm_token = $1
+			AND c.confirmed_at IS NULL
+			AND c.reverted_at IS NULL
+			AND c.confirm_expiration > CURRENT_TIMESTAMP
+			AND EXISTS (
+			    SELECT 1 FROM emails e
+			    WHERE e.owner_id = c.user_id
+			    AND e.email = c.old_email
+			    AND e.is_active = TRUE
+			)
+
+			RETURNING c.user_id, c.new_email;
+		`
+		switchEmailSql = `
+			INSERT INTO emails (email, owner_id, is_confirmed)
+			VALUES ($1, $2, TRUE);
+		`
+	)
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return AppliedEmailChange{}, postgresql.HandleQueryErr(err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	change := AppliedEmailChange{IsSwitched: true}
+	err = tx.QueryRow(ctx, confirmSql, confirmToken).
+		Scan(&change.UserID, &change.Email)
+	if err != nil {
+		return AppliedEmailChange{}, handleSearchingTokenError(err)
+	}
+
+	if _, err := tx.Exec(ctx, switchEmailSql, change.Email, change.UserID); err != nil {
+		return AppliedEmailChange{}, postgresql.CheckErrorForUniqueViolation(err)
+	}
+
+	return change, postgresql.HandleQueryErr(tx.Commit(ctx))
+}
+
+// revertEmailChange brings back the old email even if user has changed it again after, because undo link is
+// the way to get the account back for the owner of the old email.
+func (r PostgresqlRepository) revertEmailChange(ctx context.Context, revertToken string) (AppliedEmailChange, error) {
+	const (
+		revertSql = `
+			UPDATE email_changes
+			SET reverted_at = CURRENT_TIMESTAMP
+			WHERE revert_token = $1
+			AND reverted_at IS NULL
+			AND revert_expiration > CURRENT_TIMESTAMP
+
+			RETURNING user_id, old_email, new_email, confirmed_at IS NOT NULL;
+		`
+		disableEmailsSql = `
+			UPDATE emails
+			SET is_active = FALSE
+			WHERE owner_id = $1
+			AND is_active = TRUE;
+		`
+		removeNewEmailSql = `
+			DELETE FROM emails
+			WHERE owner_id = $1
+			AND email = $2;
+		`
+		activateOldEmailSql = `
+			UPDATE emails
+			SET is_active = TRUE
+			WHERE owner_id = $1
+			AND email = $2;
+		`
+	)
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return AppliedEmailChange{}, postgresql.HandleQueryErr(err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	var (
+		change   AppliedEmailChange
+		newEmail string
+	)
+
+	err = tx.QueryRow(ctx, revertSql, revertToken).
+		Scan(&change.UserID, &change.Email, &newEmail, &change.IsSwitched)
+	if err != nil {
+		return AppliedEmailChange{}, handleSearchingTokenError(err)
+	}
+
+	if change.IsSwitched {
+		if _, err := tx.Exec(ctx, disableEmailsSql, change.UserID); err != nil {
+			return AppliedEmailChange{}, postgresql.HandleQueryErr(err)
+		}
+
+		if _, err := tx.Exec(ctx, removeNewEmailSql, change.UserID, newEmail); err != nil {
+			return AppliedEmailChange{}, postgresql.HandleQueryErr(err)
+		}
+
+		if _, err := tx.Exec(ctx, activateOldEmailSql, change.UserID, change.Email); err != nil {
+			return AppliedEmailChange{}, postgresql.HandleQueryErr(err)
+		}
+	}
+
+	return change, postgresql.HandleQueryErr(tx.Commit(ctx))
+}
+
 func (r PostgresqlRepository) validateEmail(ctx context.Context, validationToken string) error {
 	const sql = `
 		WITH existing_token AS (
@@ -378,10 +681,36 @@ func (r PostgresqlRepository) saveAppleRefreshToken(ctx context.Context, userID
 	return postgresql.HandleQueryErr(err)
 }
 
-func (r PostgresqlRepository) allAppleRefreshTokens(ctx context.Context, userID pgtype.UUID) ([]string, error) {
+func (r PostgresqlRepository) byOAuth(ctx context.Context, userInputted oauth.User, oAuthServiceOption oAuthMethod) (*User, oauth.LoginResultType, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, oauth.Error, postgresql.HandleQueryErr(err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	provider := oAuthServiceOption()
+	if user, err := r.tryFindUserByIDOAuth(ctx, tx, userInputted, provider); !errors.Is(err, pgx.ErrNoRows) {
+		return user, oauth.Login, postgresql.HandleQueryErr(err)
+	}
+
+	// Existing account is connected only by email that provider has verified,
+	// otherwise anyone could take over account by registering its email in other service.
+	if userInputted.IsEmailVerified() {
+		if user, err := r.tryConnectOAuthUserToRegistered(ctx, tx, userInputted, provider); !errors.Is(err, pgx.ErrNoRows) {
+			return user, oauth.Connect, postgresql.HandleQueryErr(err)
+		}
+	}
+
+	user, err := r.registerUserWithOAuth(ctx, tx, userInputted, provider)
+	return user, oauth.Register, postgresql.HandleQueryErr(postgresql.CheckErrorForUniqueViolation(err))
+}
+
+func (r PostgresqlRepository) externalIdentities(ctx context.Context, userID pgtype.UUID) ([]ExternalIdentity, error) {
 	const sql = `
-		SELECT token FROM apple_refresh_tokens
-		WHERE user_id = $1;
+		SELECT provider, connected_at FROM external_identities
+		WHERE user_id = $1
+		ORDER BY connected_at;
 	`
 
 	rows, err := r.pool.Query(ctx, sql, userID)
@@ -389,39 +718,137 @@ func (r PostgresqlRepository) allAppleRefreshTokens(ctx context.Context, userID
 		return nil, postgresql.HandleQueryErr(err)
 	}
 
-	tokens := make([]string, 0)
+	defer rows.Close()
+
+	identities := make([]ExternalIdentity, 0)
 	for rows.Next() {
-		var token string
-		if scanError := rows.Scan(&token); scanError != nil {
+		var identity ExternalIdentity
+		if scanError := rows.Scan(&identity.Provider, &identity.ConnectedAt); scanError != nil {
 			err = errors.Join(scanError, err)
 			continue
 		}
 
-		tokens = append(tokens, token)
+		identities = append(identities, identity)
 	}
 
-	return tokens, postgresql.HandleQueryErr(err)
+	return identities, postgresql.HandleQueryErr(errors.Join(err, rows.Err()))
 }
 
-func (r PostgresqlRepository) byOAuth(ctx context.Context, userInputted oauth.User, oAuthServiceOption oAuthMethod) (*User, oauth.LoginResultType, error) {
+func (r PostgresqlRepository) addDataExport(ctx context.Context, userID pgtype.UUID, language string) error {
+	const sql = `
+		WITH confirmed_email AS (
+		    SELECT email FROM emails
+		    WHERE owner_id = $1
+		    AND is_active = TRUE
+		    AND is_confirmed = TRUE
+		), added_export AS (
+		    INSERT INTO data_exports (user_id, language)
+		    SELECT $1, $2
+		    WHERE EXISTS(SELECT 1 FROM confirmed_email)
+		    ON CONFLICT (user_id) WHERE archive IS NULL AND failed_at IS NULL DO NOTHING
+		)
+		SELECT EXISTS(SELECT 1 FROM confirmed_email);
+	`
+
+	var isEmailConfirmed bool
+	err := r.pool.QueryRow(ctx, sql, userID, language).
+		Scan(&isEmailConfirmed)
+	if err != nil {
+		return postgresql.HandleQueryErr(err)
+	}
+
+	if !isEmailConfirmed {
+		return ErrEmailNotConfirmed
+	}
+
+	return nil
+}
+
+func (r PostgresqlRepository) dataExportArchive(ctx context.Context, tokenHash string) ([]byte, error) {
+	const sql = `
+		SELECT archive FROM data_exports
+		WHERE download_token = $1
+		AND expiration > CURRENT_TIMESTAMP;
+	`
+
+	var archive []byte
+	err := r.pool.QueryRow(ctx, sql, tokenHash).
+		Scan(&archive)
+
+	return archive, handleSearchingTokenError(err)
+}
+
+func (r PostgresqlRepository) linkExternalIdentity(ctx context.Context, userID pgtype.UUID, provider oAuthProvider, subject string) error {
+	const sql = `
+		INSERT INTO external_identities (provider, subject, user_id)
+		VALUES ($1, $2, $3);
+	`
+
+	_, err := r.pool.Exec(ctx, sql, provider, subject, userID)
+	if err = postgresql.CheckErrorForUniqueViolation(err); errors.Is(err, postgresql.ErrAddedDuplicateOfUnique) {
+		return errors.Join(ErrIdentityAlreadyLinked, err)
+	}
+
+	return postgresql.HandleQueryErr(err)
+}
+
+// unlinkExternalIdentity removes identity only if user still can log in after it
+// with password, passkey or other external identity.
+func (r PostgresqlRepository) unlinkExternalIdentity(ctx context.Context, userID pgtype.UUID, provider oAuthProvider) error {
+	const (
+		lockUserSQL = `
+			SELECT id FROM users
+			WHERE id = $1
+			FOR UPDATE;
+		`
+		deleteSQL = `
+			DELETE FROM external_identities
+			WHERE user_id = $1
+			AND provider = $2;
+		`
+		hasLoginMethodSQL = `
+			SELECT EXISTS (SELECT 1 FROM passwords WHERE user_id = $1)
+				OR EXISTS (SELECT 1 FROM webauthn_credentials WHERE user_id = $1)
+				OR EXISTS (SELECT 1 FROM external_identities WHERE user_id = $1);
+		`
+	)
+
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
-		return nil, oauth.Error, postgresql.HandleQueryErr(err)
+		return postgresql.HandleQueryErr(err)
 	}
 
 	defer tx.Rollback(ctx)
 
-	tableName := oAuthServiceOption()
-	if user, err := r.tryFindUserByIDOAuth(ctx, tx, userInputted, tableName); !errors.Is(err, pgx.ErrNoRows) {
-		return user, oauth.Login, postgresql.HandleQueryErr(err)
+	var lockedID pgtype.UUID
+	err = tx.QueryRow(ctx, lockUserSQL, userID).Scan(&lockedID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.Join(ErrNotFound, err)
+	}
+
+	if err != nil {
+		return postgresql.HandleQueryErr(err)
+	}
+
+	tag, err := tx.Exec(ctx, deleteSQL, userID, provider)
+	if err != nil {
+		return postgresql.HandleQueryErr(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return ErrIdentityNotLinked
+	}
+
+	var hasLoginMethod bool
+	if err := tx.QueryRow(ctx, hasLoginMethodSQL, userID).Scan(&hasLoginMethod); err != nil {
+		return postgresql.HandleQueryErr(err)
 	}
 
-	if user, err := r.tryConnectOAuthUserToRegistered(ctx, tx, userInputted, tableName); !errors.Is(err, pgx.ErrNoRows) {
-		return user, oauth.Connect, postgresql.HandleQueryErr(err)
+	if !hasLoginMethod {
+		return ErrLastLoginMethod
 	}
 
-	user, err := r.registerUserWithOAuth(ctx, tx, userInputted, tableName)
-	return user, oauth.Register, postgresql.HandleQueryErr(err)
+	return postgresql.HandleQueryErr(tx.Commit(ctx))
 }
 
 func (r PostgresqlRepository) addEmailConfirmationToken(ctx context.Context, email string, tempToken ConfirmationToken) error {
@@ -454,6 +881,182 @@ func (r PostgresqlRepository) addPasswordResetToken(ctx context.Context, email s
 	return postgresql.HandleQueryErr(err)
 }
 
+func (r PostgresqlRepository) addEmailLoginCode(ctx context.Context, email string, code LoginCode) error {
+	const sql = `
+		WITH active_email AS (
+			SELECT email FROM emails
+			WHERE email = LOWER($2)
+			AND is_active = TRUE
+		), revoked_codes AS (
+			UPDATE email_login_codes
+			SET is_used = TRUE
+			WHERE email IN (SELECT email FROM active_email)
+			AND is_used = FALSE
+		)
+		INSERT INTO email_login_codes (token, email, code_hash, expiration)
+		SELECT $1, email, $3, $4
+		FROM active_email;
+	`
+
+	result, err := r.pool.Exec(ctx, sql,
+		code.Value,
+		email,
+		code.CodeHash,
+		code.ExpirationTime,
+	)
+	if err != nil {
+		return postgresql.HandleQueryErr(err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return postgresql.ErrNoMatches
+	}
+
+	return nil
+}
+
+func (r PostgresqlRepository) byEmailLoginCode(ctx context.Context, email, code string) (*User, error) {
+	const sql = `
+		SELECT
+			c.token,
+			c.code_hash,
+			c.failed_attempts,
+			c.is_used,
+			c.expiration,
+			e.is_active
+		FROM email_login_codes c
+		LEFT JOIN emails e ON e.email = c.email
+		WHERE c.email = LOWER($1)
+		ORDER BY c.expiration DESC
+		LIMIT 1
+		FOR UPDATE OF c;
+	`
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, postgresql.HandleQueryErr(err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	existingCode, err := r.lockedLoginCode(ctx, tx, sql, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if !existingCode.matches(code) {
+		if err := r.addFailedLoginCodeAttempt(ctx, tx, existingCode.Value); err != nil {
+			return nil, err
+		}
+
+		return nil, errWrongLoginCode
+	}
+
+	return r.useLoginCode(ctx, tx, existingCode.Value)
+}
+
+func (r PostgresqlRepository) byEmailLoginToken(ctx context.Context, token string) (*User, error) {
+	const sql = `
+		SELECT
+			c.token,
+			c.code_hash,
+			c.failed_attempts,
+			c.is_used,
+			c.expiration,
+			e.is_active
+		FROM email_login_codes c
+		LEFT JOIN emails e ON e.email = c.email
+		WHERE c.token = $1
+		FOR UPDATE OF c;
+	`
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, postgresql.HandleQueryErr(err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	existingCode, err := r.lockedLoginCode(ctx, tx, sql, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.useLoginCode(ctx, tx, existingCode.Value)
+}
+
+func (r PostgresqlRepository) lockedLoginCode(ctx context.Context, tx pgx.Tx, sql, arg string) (LoginCode, error) {
+	var (
+		existingCode  LoginCode
+		isEmailActive bool
+	)
+
+	err := tx.QueryRow(ctx, sql, arg).
+		Scan(
+			&existingCode.Value,
+			&existingCode.CodeHash,
+			&existingCode.FailedAttempts,
+			&existingCode.IsUsed,
+			&existingCode.ExpirationTime,
+			&isEmailActive,
+		)
+	if err != nil {
+		return LoginCode{}, handleSearchingTokenError(err)
+	}
+
+	if !isEmailActive {
+		return LoginCode{}, ErrNotConfirmedOrChangedEmail
+	}
+
+	if !existingCode.isActive() {
+		return LoginCode{}, existingCode.InvalidityReason()
+	}
+
+	return existingCode, nil
+}
+
+func (r PostgresqlRepository) addFailedLoginCodeAttempt(ctx context.Context, tx pgx.Tx, token string) error {
+	const sql = `
+		UPDATE email_login_codes
+		SET failed_attempts = failed_attempts + 1
+		WHERE token = $1;
+	`
+
+	if _, err := tx.Exec(ctx, sql, token); err != nil {
+		return postgresql.HandleQueryErr(err)
+	}
+
+	return postgresql.HandleQueryErr(tx.Commit(ctx))
+}
+
+func (r PostgresqlRepository) useLoginCode(ctx context.Context, tx pgx.Tx, token string) (*User, error) {
+	const sql = `
+		WITH used_code AS (
+			UPDATE email_login_codes
+			SET is_used = TRUE
+			WHERE token = $1
+
+			RETURNING email
+		)
+		UPDATE emails
+		SET is_confirmed = TRUE
+		WHERE email IN (SELECT email FROM used_code)
+
+		RETURNING email;
+	`
+
+	var email string
+	if err := tx.QueryRow(ctx, sql, token).Scan(&email); err != nil {
+		return nil, postgresql.HandleQueryErr(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, postgresql.HandleQueryErr(err)
+	}
+
+	return r.byEmail(ctx, email)
+}
+
 func handleSearchingTokenError(err error) error {
 	if errors.Is(err, pgx.ErrNoRows) {
 		return errors.Join(errTokenNotExists, err)
@@ -462,15 +1065,15 @@ func handleSearchingTokenError(err error) error {
 	return postgresql.HandleQueryErr(err)
 }
 
-func (r PostgresqlRepository) tryFindUserByIDOAuth(ctx context.Context, tx pgx.Tx, userInputted oauth.User, tableName serviceTableName) (*User, error) {
-	const sqlFormat = `
-		SELECT user_id FROM %s
-		WHERE id = $1;
+func (r PostgresqlRepository) tryFindUserByIDOAuth(ctx context.Context, tx pgx.Tx, userInputted oauth.User, provider oAuthProvider) (*User, error) {
+	const sql = `
+		SELECT user_id FROM external_identities
+		WHERE provider = $1
+		AND subject = $2;
 	`
 
-	sql := fmt.Sprintf(sqlFormat, tableName)
 	var userID pgtype.UUID
-	err := tx.QueryRow(ctx, sql, userInputted.ID()).
+	err := tx.QueryRow(ctx, sql, provider, userInputted.ID()).
 		Scan(&userID)
 	if err != nil {
 		return nil, err
@@ -483,18 +1086,17 @@ func (r PostgresqlRepository) tryFindUserByIDOAuth(ctx context.Context, tx pgx.T
 	return r.byID(ctx, userID)
 }
 
-func (r PostgresqlRepository) tryConnectOAuthUserToRegistered(ctx context.Context, tx pgx.Tx, userInputted oauth.User, tableName serviceTableName) (*User, error) {
-	const sqlFormat = `
-		INSERT INTO %s (user_id, id)
-		SELECT owner_id, $1 FROM emails
-		WHERE email ILIKE $2
+func (r PostgresqlRepository) tryConnectOAuthUserToRegistered(ctx context.Context, tx pgx.Tx, userInputted oauth.User, provider oAuthProvider) (*User, error) {
+	const sql = `
+		INSERT INTO external_identities (provider, subject, user_id)
+		SELECT $1, $2, owner_id FROM emails
+		WHERE email ILIKE $3
 			
 		RETURNING user_id;
 	`
 
-	sql := fmt.Sprintf(sqlFormat, tableName)
 	var userID pgtype.UUID
-	err := tx.QueryRow(ctx, sql, userInputted.ID(), userInputted.Email()).
+	err := tx.QueryRow(ctx, sql, provider, userInputted.ID(), userInputted.Email()).
 		Scan(&userID)
 	if err != nil {
 		return nil, err
@@ -507,8 +1109,8 @@ func (r PostgresqlRepository) tryConnectOAuthUserToRegistered(ctx context.Contex
 	return r.byID(ctx, userID)
 }
 
-func (r PostgresqlRepository) registerUserWithOAuth(ctx context.Context, tx pgx.Tx, userInputted oauth.User, tableName serviceTableName) (*User, error) {
-	const sqlFormat = `
+func (r PostgresqlRepository) registerUserWithOAuth(ctx context.Context, tx pgx.Tx, userInputted oauth.User, provider oAuthProvider) (*User, error) {
+	const sql = `
 		WITH new_user AS (
 		    INSERT INTO users (username)
 			VALUES ($1)
@@ -520,17 +1122,16 @@ func (r PostgresqlRepository) registerUserWithOAuth(ctx context.Context, tx pgx.
 		           
 		    RETURNING email, owner_id, is_confirmed
 		), oauth_connection AS (
-			INSERT INTO %s (user_id, id)
-			VALUES ((SELECT id FROM new_user), $4)
+			INSERT INTO external_identities (provider, subject, user_id)
+			VALUES ($4, $5, (SELECT id FROM new_user))
 		)
 		SELECT u.id, u.username, e.email, e.is_confirmed
 		FROM new_user u
 		LEFT JOIN new_email e ON u.id = e.owner_id;
 	`
 
-	sql := fmt.Sprintf(sqlFormat, tableName)
 	user := new(User)
-	err := tx.QueryRow(ctx, sql, userInputted.Name(), userInputted.Email(), userInputted.IsEmailVerified(), userInputted.ID()).
+	err := tx.QueryRow(ctx, sql, userInputted.Name(), userInputted.Email(), userInputted.IsEmailVerified(), provider, userInputted.ID()).
 		Scan(&user.ID, &user.Username, &user.Email, &user.IsEmailConfirmed)
 	if err != nil {
 		return nil, err
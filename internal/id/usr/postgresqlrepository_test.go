@@ -2,9 +2,8 @@ package usr
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"math/rand"
-	"os"
 	"strconv"
 	"strings"
 	"testing"
@@ -15,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/zhuboris/never-expires/internal/id/migrations"
 	"github.com/zhuboris/never-expires/internal/id/usr/oauth"
 	"github.com/zhuboris/never-expires/internal/shared/postgresql"
 	"github.com/zhuboris/never-expires/internal/test"
@@ -29,7 +29,7 @@ func TestNewPostgresqlRepository(t *testing.T) {
 		DBName:   "test" + strconv.Itoa(rand.Int()),
 	}
 
-	pool := test.SetupDatabase(t, config, "")
+	pool := test.SetupDatabase(t, config, nil)
 
 	tests := []struct {
 		name          string
@@ -908,12 +908,12 @@ func TestPostgresqlRepository_byOAuth(t *testing.T) {
 			VALUES ('user2')
 			    
 			RETURNING id
-		), google_id AS (
-			INSERT INTO google_ids (user_id, id)
-			VALUES ((SELECT id FROM test_google_id_user), 'existing_google_id')
-		), apple_id AS (
-		    INSERT INTO apple_ids (user_id, id)
-			VALUES ((SELECT id FROM test_google_id_user), 'existing_apple_id')
+		), identities AS (
+			INSERT INTO external_identities (provider, subject, user_id)
+			VALUES 
+				('google', 'existing_google_id', (SELECT id FROM test_google_id_user)),
+				('apple', 'existing_apple_id', (SELECT id FROM test_google_id_user)),
+				('gitlab', 'existing_gitlab_id', (SELECT id FROM test_apple_id_user))
 		)
 		INSERT INTO emails (email, owner_id)
 		VALUES 
@@ -922,25 +922,21 @@ func TestPostgresqlRepository_byOAuth(t *testing.T) {
 			('3@test.com', (SELECT id FROM test_no_oauth_user));
 	`
 
-	checkResultQuery := func(method oAuthMethod) string {
-		const sqlFormat = `
-			SELECT EXISTS (
-			    SELECT 1 FROM %s
-			    WHERE id = $1
-			    AND user_id = $2
-			)
-		`
-
-		t.Helper()
-		tableName := method()
-		return fmt.Sprintf(sqlFormat, tableName)
-	}
+	const checkResultQuery = `
+		SELECT EXISTS (
+		    SELECT 1 FROM external_identities
+		    WHERE provider = $1
+		    AND subject = $2
+		    AND user_id = $3
+		)
+	`
 
 	tests := []struct {
 		name           string
 		oAuthMethod    oAuthMethod
 		user           oauth.User
 		expectedResult oauth.LoginResultType
+		expectedError  error
 	}{
 		{
 			name:           "oAuth id is already registered google",
@@ -957,13 +953,13 @@ func TestPostgresqlRepository_byOAuth(t *testing.T) {
 		{
 			name:           "oAuth id connecting to existing account by matching email google",
 			oAuthMethod:    withGoogle(),
-			user:           oauth.NewUser("new_id1", "3@test.com", "user", false /*isEmailVerified*/),
+			user:           oauth.NewUser("new_id1", "3@test.com", "user", true /*isEmailVerified*/),
 			expectedResult: oauth.Connect,
 		},
 		{
 			name:           "oAuth id connecting to existing account by matching email apple",
 			oAuthMethod:    withApple(),
-			user:           oauth.NewUser("new_id2", "3@test.com", "user", false /*isEmailVerified*/),
+			user:           oauth.NewUser("new_id2", "3@test.com", "user", true /*isEmailVerified*/),
 			expectedResult: oauth.Connect,
 		},
 		{
@@ -981,9 +977,27 @@ func TestPostgresqlRepository_byOAuth(t *testing.T) {
 		{
 			name:           "connect different types of oAuth by matching email",
 			oAuthMethod:    withGoogle(),
-			user:           oauth.NewUser("existing_apple_id", "2@test.com", "user", false /*isEmailVerified*/),
+			user:           oauth.NewUser("existing_apple_id", "2@test.com", "user", true /*isEmailVerified*/),
 			expectedResult: oauth.Connect,
 		},
+		{
+			name:           "oAuth id is already registered oidc provider",
+			oAuthMethod:    withOIDC("gitlab"),
+			user:           oauth.NewUser("existing_gitlab_id", "2@test.com", "user", false /*isEmailVerified*/),
+			expectedResult: oauth.Login,
+		},
+		{
+			name:           "same subject of other provider is not matched",
+			oAuthMethod:    withOIDC("microsoft"),
+			user:           oauth.NewUser("existing_gitlab_id", "new3@test.com", "user", false /*isEmailVerified*/),
+			expectedResult: oauth.Register,
+		},
+		{
+			name:          "not verified email is not connected to existing account",
+			oAuthMethod:   withOIDC("microsoft"),
+			user:          oauth.NewUser("new_id5", "3@test.com", "user", false /*isEmailVerified*/),
+			expectedError: postgresql.ErrAddedDuplicateOfUnique,
+		},
 	}
 
 	for _, tt := range tests {
@@ -993,12 +1007,16 @@ func TestPostgresqlRepository_byOAuth(t *testing.T) {
 			require.NoError(t, err, "error arranging db content")
 
 			user, queryResult, err := repo.byOAuth(context.Background(), tt.user, tt.oAuthMethod)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				return
+			}
 
 			require.NoError(t, err)
 			assert.Equal(t, tt.expectedResult, queryResult)
 
 			var isRegistered bool
-			err = repo.pool.QueryRow(context.Background(), checkResultQuery(tt.oAuthMethod), tt.user.ID(), user.ID).
+			err = repo.pool.QueryRow(context.Background(), checkResultQuery, tt.oAuthMethod(), tt.user.ID(), user.ID).
 				Scan(&isRegistered)
 			require.NoError(t, err, "check result query error")
 			assert.True(t, isRegistered, "password was not changed")
@@ -1006,6 +1024,282 @@ func TestPostgresqlRepository_byOAuth(t *testing.T) {
 	}
 }
 
+func TestPostgresqlRepository_byEmailLoginCode(t *testing.T) {
+	const (
+		arrangeQuery = `
+			WITH test_user AS (
+			    INSERT INTO users (username)
+				VALUES ('user1')
+
+				RETURNING id
+			), users_emails AS (
+			    INSERT INTO emails (email, owner_id, is_confirmed)
+			    VALUES
+			        ('valid@test.com', (SELECT id FROM test_user), false),
+			        ('expired@test.com', (SELECT id FROM test_user), false),
+			        ('used@test.com', (SELECT id FROM test_user), false),
+			        ('blocked@test.com', (SELECT id FROM test_user), false)
+			)
+			INSERT INTO email_login_codes (token, email, code_hash, failed_attempts, is_used, expiration)
+			VALUES
+				('valid', 'valid@test.com', $1, 0, false, NOW() + INTERVAL '1 hour'),
+				('expired', 'expired@test.com', $2, 0, false, NOW() - INTERVAL '1 hour'),
+				('used', 'used@test.com', $3, 0, true, NOW() + INTERVAL '1 hour'),
+				('blocked', 'blocked@test.com', $4, $5, false, NOW() + INTERVAL '1 hour');
+		`
+		code = "123456"
+	)
+
+	tests := []struct {
+		name          string
+		email         string
+		code          string
+		requireError  require.ErrorAssertionFunc
+		expectedError error
+	}{
+		{
+			name:          "code not exist",
+			email:         "missing@test.com",
+			code:          code,
+			requireError:  require.Error,
+			expectedError: errTokenNotExists,
+		},
+		{
+			name:          "expired code",
+			email:         "expired@test.com",
+			code:          code,
+			requireError:  require.Error,
+			expectedError: errTokenExpired,
+		},
+		{
+			name:          "code already used",
+			email:         "used@test.com",
+			code:          code,
+			requireError:  require.Error,
+			expectedError: errTokenAlreadyUsed,
+		},
+		{
+			name:          "too many failed attempts",
+			email:         "blocked@test.com",
+			code:          code,
+			requireError:  require.Error,
+			expectedError: errTooManyCodeAttempts,
+		},
+		{
+			name:          "wrong code",
+			email:         "valid@test.com",
+			code:          "654321",
+			requireError:  require.Error,
+			expectedError: errWrongLoginCode,
+		},
+		{
+			name:         "valid code",
+			email:        "VALID@test.com",
+			code:         code,
+			requireError: require.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := arrangeRepoWithTestDB(t)
+			_, err := repo.pool.Exec(context.Background(), arrangeQuery,
+				hashLoginCode("valid", code),
+				hashLoginCode("expired", code),
+				hashLoginCode("used", code),
+				hashLoginCode("blocked", code),
+				MaxLoginCodeFailedTries,
+			)
+			require.NoError(t, err, "error arranging db content")
+
+			user, err := repo.byEmailLoginCode(context.Background(), strings.ToLower(tt.email), tt.code)
+
+			tt.requireError(t, err)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.Equal(t, strings.ToLower(tt.email), user.Email)
+			assert.True(t, user.IsEmailConfirmed, "email was not confirmed on login")
+
+			_, err = repo.byEmailLoginCode(context.Background(), strings.ToLower(tt.email), tt.code)
+			assert.ErrorIs(t, err, errTokenAlreadyUsed, "code was used twice")
+		})
+	}
+}
+
+func TestPostgresqlRepository_unlinkExternalIdentity(t *testing.T) {
+	const (
+		arrangeQuery = `
+			WITH users_data AS (
+			    INSERT INTO users (id, username)
+				VALUES
+				    ('f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e01', 'with password'),
+				    ('f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e02', 'only google'),
+				    ('f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e03', 'google and apple'),
+				    ('f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e04', 'with passkey')
+			), users_passwords AS (
+			    INSERT INTO passwords (user_id, encrypted_password)
+			    VALUES ('f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e01', 'hash')
+			), users_passkeys AS (
+			    INSERT INTO webauthn_credentials (id, user_id, credential)
+			    VALUES ('\x0102', 'f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e04', '{}')
+			)
+			INSERT INTO external_identities (provider, subject, user_id)
+			VALUES
+				('google', 'google1', 'f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e01'),
+				('google', 'google2', 'f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e02'),
+				('google', 'google3', 'f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e03'),
+				('apple', 'apple3', 'f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e03'),
+				('google', 'google4', 'f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e04');
+		`
+		checkQuery = `
+			SELECT EXISTS (
+			    SELECT 1 FROM external_identities
+			    WHERE user_id = $1
+			    AND provider = $2
+			);
+		`
+	)
+
+	tests := []struct {
+		name          string
+		userID        string
+		provider      oAuthProvider
+		expectedError error
+	}{
+		{
+			name:     "user has password",
+			userID:   "f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e01",
+			provider: googleProvider,
+		},
+		{
+			name:          "identity is the only login method",
+			userID:        "f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e02",
+			provider:      googleProvider,
+			expectedError: ErrLastLoginMethod,
+		},
+		{
+			name:     "user has other identity",
+			userID:   "f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e03",
+			provider: appleProvider,
+		},
+		{
+			name:     "user has passkey",
+			userID:   "f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e04",
+			provider: googleProvider,
+		},
+		{
+			name:          "identity is not linked",
+			userID:        "f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e01",
+			provider:      appleProvider,
+			expectedError: ErrIdentityNotLinked,
+		},
+		{
+			name:          "user not exists",
+			userID:        "f8a4d2b1-53a2-4c4a-9e3b-6a4d1c2b3e09",
+			provider:      googleProvider,
+			expectedError: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := arrangeRepoWithTestDB(t)
+			_, err := repo.pool.Exec(context.Background(), arrangeQuery)
+			require.NoError(t, err, "error arranging db content")
+
+			userID := stringToUUID(t, tt.userID)
+			err = repo.unlinkExternalIdentity(context.Background(), userID, tt.provider)
+
+			var isLinked bool
+			checkErr := repo.pool.QueryRow(context.Background(), checkQuery, userID, tt.provider).
+				Scan(&isLinked)
+			require.NoError(t, checkErr, "check result query error")
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				if errors.Is(tt.expectedError, ErrLastLoginMethod) {
+					assert.True(t, isLinked, "last login method was removed")
+				}
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.False(t, isLinked, "identity was not unlinked")
+		})
+	}
+}
+
+func TestPostgresqlRepository_restore(t *testing.T) {
+	const (
+		arrangeQuery = `
+			WITH users_data AS (
+			    INSERT INTO users (id, username)
+				VALUES
+				    ('a1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', 'scheduled'),
+				    ('a1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e02', 'grace period is over')
+			)
+			INSERT INTO users_to_delete (id, restore_token, delete_after)
+			VALUES
+				('a1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', 'valid', CURRENT_TIMESTAMP + INTERVAL '1 day'),
+				('a1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e02', 'expired', CURRENT_TIMESTAMP - INTERVAL '1 day');
+		`
+		checkQuery = `
+			SELECT EXISTS (
+			    SELECT 1 FROM users_to_delete
+			    WHERE restore_token = $1
+			);
+		`
+	)
+
+	tests := []struct {
+		name          string
+		token         string
+		expectedError error
+	}{
+		{
+			name:  "restored",
+			token: "valid",
+		},
+		{
+			name:          "grace period is over",
+			token:         "expired",
+			expectedError: errTokenNotExists,
+		},
+		{
+			name:          "token not exists",
+			token:         "unknown",
+			expectedError: errTokenNotExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := arrangeRepoWithTestDB(t)
+			_, err := repo.pool.Exec(context.Background(), arrangeQuery)
+			require.NoError(t, err, "error arranging db content")
+
+			err = repo.restore(context.Background(), tt.token)
+
+			var isScheduled bool
+			checkErr := repo.pool.QueryRow(context.Background(), checkQuery, tt.token).
+				Scan(&isScheduled)
+			require.NoError(t, checkErr, "check result query error")
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.False(t, isScheduled, "deletion was not cancelled")
+		})
+	}
+}
+
 func arrangeRepoWithTestDB(t *testing.T) *PostgresqlRepository {
 	config := test.PostgresConfig{
 		Username: "postgres",
@@ -1015,10 +1309,7 @@ func arrangeRepoWithTestDB(t *testing.T) *PostgresqlRepository {
 		DBName:   "test" + strconv.Itoa(rand.Int()),
 	}
 
-	path := os.Getenv("SQL_AUTHENTICATION_INIT_FILE_PATH")
-	require.NotEmpty(t, path, "path to .sql is empty")
-
-	pool := test.SetupDatabase(t, config, path)
+	pool := test.SetupDatabase(t, config, migrations.FS)
 	t.Cleanup(func() {
 		test.DropDatabase(t, pool, config)
 	})
@@ -1044,3 +1335,247 @@ func assertUsersAreEqual(t *testing.T, first, second User) {
 	assert.Equal(t, first.Username, second.Username)
 	assert.Equal(t, first.IsEmailConfirmed, second.IsEmailConfirmed)
 }
+
+func TestPostgresqlRepository_confirmEmailChange(t *testing.T) {
+	const (
+		arrangeQuery = `
+			WITH users_data AS (
+			    INSERT INTO users (id, username)
+				VALUES
+				    ('b1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', 'user1'),
+				    ('b1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e02', 'user2')
+			), users_emails AS (
+			    INSERT INTO emails (email, owner_id, is_confirmed)
+			    VALUES
+			        ('old@test.com', 'b1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', true),
+			        ('taken@test.com', 'b1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e02', true)
+			)
+			INSERT INTO email_changes (user_id, old_email, new_email, confirm_token, confirm_expiration, revert_token, revert_expiration, confirmed_at)
+			VALUES
+				('b1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', 'old@test.com', 'new@test.com', 'valid', NOW() + INTERVAL '1 day', 'revert1', NOW() + INTERVAL '7 days', NULL),
+				('b1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', 'old@test.com', 'new@test.com', 'expired', NOW() - INTERVAL '1 day', 'revert2', NOW() + INTERVAL '7 days', NULL),
+				('b1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', 'old@test.com', 'new@test.com', 'used', NOW() + INTERVAL '1 day', 'revert3', NOW() + INTERVAL '7 days', NOW()),
+				('b1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', 'old@test.com', 'taken@test.com', 'taken', NOW() + INTERVAL '1 day', 'revert4', NOW() + INTERVAL '7 days', NULL);
+		`
+		checkQuery = `
+			SELECT email FROM emails
+			WHERE owner_id = 'b1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01'
+			AND is_active = TRUE;
+		`
+	)
+
+	tests := []struct {
+		name          string
+		token         string
+		expectedEmail string
+		expectedError error
+	}{
+		{
+			name:          "email is switched",
+			token:         "valid",
+			expectedEmail: "new@test.com",
+		},
+		{
+			name:          "token expired",
+			token:         "expired",
+			expectedEmail: "old@test.com",
+			expectedError: errTokenNotExists,
+		},
+		{
+			name:          "token already used",
+			token:         "used",
+			expectedEmail: "old@test.com",
+			expectedError: errTokenNotExists,
+		},
+		{
+			name:          "new email is taken meanwhile",
+			token:         "taken",
+			expectedEmail: "old@test.com",
+			expectedError: postgresql.ErrAddedDuplicateOfUnique,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := arrangeRepoWithTestDB(t)
+			_, err := repo.pool.Exec(context.Background(), arrangeQuery)
+			require.NoError(t, err, "error arranging db content")
+
+			change, err := repo.confirmEmailChange(context.Background(), tt.token)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedEmail, change.Email)
+			}
+
+			var activeEmail string
+			err = repo.pool.QueryRow(context.Background(), checkQuery).
+				Scan(&activeEmail)
+			require.NoError(t, err, "check result query error")
+			assert.Equal(t, tt.expectedEmail, activeEmail)
+		})
+	}
+}
+
+func TestPostgresqlRepository_revertEmailChange(t *testing.T) {
+	const (
+		arrangeQuery = `
+			WITH users_data AS (
+			    INSERT INTO users (id, username)
+				VALUES ('c1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', 'user')
+			), old_email AS (
+			    INSERT INTO emails (email, owner_id, is_confirmed)
+			    VALUES ('old@test.com', 'c1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', true)
+			), new_email AS (
+			    INSERT INTO emails (email, owner_id, is_confirmed, is_active)
+			    VALUES ('new@test.com', 'c1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', true, false)
+			)
+			INSERT INTO email_changes (user_id, old_email, new_email, confirm_token, confirm_expiration, revert_token, revert_expiration, confirmed_at)
+			VALUES
+				('c1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', 'old@test.com', 'new@test.com', 'confirm1', NOW() + INTERVAL '1 day', 'confirmed', NOW() + INTERVAL '7 days', NOW()),
+				('c1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', 'old@test.com', 'pending@test.com', 'confirm2', NOW() + INTERVAL '1 day', 'pending', NOW() + INTERVAL '7 days', NULL),
+				('c1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', 'old@test.com', 'new@test.com', 'confirm3', NOW() + INTERVAL '1 day', 'expired', NOW() - INTERVAL '1 day', NOW());
+		`
+		switchQuery = `
+			INSERT INTO emails (email, owner_id, is_confirmed)
+			VALUES ('switched@test.com', 'c1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', true);
+		`
+		checkQuery = `
+			SELECT email FROM emails
+			WHERE owner_id = 'c1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01'
+			AND is_active = TRUE;
+		`
+	)
+
+	tests := []struct {
+		name           string
+		token          string
+		isChangedAgain bool
+		expectedSwitch bool
+		expectedEmail  string
+		expectedError  error
+	}{
+		{
+			name:           "confirmed change is reverted",
+			token:          "confirmed",
+			isChangedAgain: true,
+			expectedSwitch: true,
+			expectedEmail:  "old@test.com",
+		},
+		{
+			name:          "pending change is cancelled",
+			token:         "pending",
+			expectedEmail: "old@test.com",
+		},
+		{
+			name:           "revert window is over",
+			token:          "expired",
+			isChangedAgain: true,
+			expectedEmail:  "switched@test.com",
+			expectedError:  errTokenNotExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := arrangeRepoWithTestDB(t)
+			_, err := repo.pool.Exec(context.Background(), arrangeQuery)
+			require.NoError(t, err, "error arranging db content")
+
+			if tt.isChangedAgain {
+				_, err := repo.pool.Exec(context.Background(), switchQuery)
+				require.NoError(t, err, "error arranging db content")
+			}
+
+			change, err := repo.revertEmailChange(context.Background(), tt.token)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedSwitch, change.IsSwitched)
+			}
+
+			var activeEmail string
+			err = repo.pool.QueryRow(context.Background(), checkQuery).
+				Scan(&activeEmail)
+			require.NoError(t, err, "check result query error")
+			assert.Equal(t, tt.expectedEmail, activeEmail)
+		})
+	}
+}
+
+func TestPostgresqlRepository_updateProfile(t *testing.T) {
+	const (
+		userID       = "d1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01"
+		arrangeQuery = `
+			WITH users_data AS (
+			    INSERT INTO users (id, username)
+				VALUES ('d1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', 'user')
+			)
+			INSERT INTO emails (email, owner_id, is_confirmed)
+			VALUES ('user@test.com', 'd1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e01', true);
+		`
+	)
+
+	var (
+		ru       = "ru"
+		berlin   = "Europe/Berlin"
+		optIn    = true
+		existing = ProfileUpdate{Language: &ru, TimeZone: &berlin}
+	)
+
+	tests := []struct {
+		name            string
+		before          *ProfileUpdate
+		update          ProfileUpdate
+		userID          string
+		expectedProfile Profile
+		expectedError   error
+	}{
+		{
+			name:            "profile is created",
+			update:          ProfileUpdate{Language: &ru},
+			userID:          userID,
+			expectedProfile: Profile{Language: "ru"},
+		},
+		{
+			name:            "missing fields are kept",
+			before:          &existing,
+			update:          ProfileUpdate{MarketingOptIn: &optIn},
+			userID:          userID,
+			expectedProfile: Profile{Language: "ru", TimeZone: "Europe/Berlin", MarketingOptIn: true},
+		},
+		{
+			name:          "user not exists",
+			update:        ProfileUpdate{Language: &ru},
+			userID:        "d1b2c3d4-53a2-4c4a-9e3b-6a4d1c2b3e99",
+			expectedError: postgresql.ErrNoMatches,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := arrangeRepoWithTestDB(t)
+			_, err := repo.pool.Exec(context.Background(), arrangeQuery)
+			require.NoError(t, err, "error arranging db content")
+
+			id := stringToUUID(t, tt.userID)
+			if tt.before != nil {
+				err = repo.updateProfile(context.Background(), id, *tt.before)
+				require.NoError(t, err, "error arranging profile")
+			}
+
+			err = repo.updateProfile(context.Background(), id, tt.update)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			profile, err := repo.profile(context.Background(), id)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedProfile, profile)
+		})
+	}
+}
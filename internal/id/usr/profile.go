@@ -0,0 +1,90 @@
+package usr
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/zhuboris/never-expires/internal/id/lang"
+)
+
+// MaxAvatarSize limits uploaded avatar, bigger files are refused before they are stored.
+const MaxAvatarSize = 1 << 20
+
+var allowedAvatarTypes = map[string]struct{}{
+	"image/png":  {},
+	"image/jpeg": {},
+	"image/webp": {},
+}
+
+type Profile struct {
+	Language       string `json:"language,omitempty"`
+	TimeZone       string `json:"time_zone,omitempty"`
+	HasAvatar      bool   `json:"has_avatar"`
+	MarketingOptIn bool   `json:"marketing_opt_in"`
+}
+
+// ProfileUpdate contains profile fields to change, nil fields are kept as they are.
+type ProfileUpdate struct {
+	Language       *string `json:"language"`
+	TimeZone       *string `json:"time_zone"`
+	MarketingOptIn *bool   `json:"marketing_opt_in"`
+}
+
+func (u ProfileUpdate) IsEmpty() bool {
+	return u.Language == nil && u.TimeZone == nil && u.MarketingOptIn == nil
+}
+
+func (u ProfileUpdate) normalized() (ProfileUpdate, error) {
+	if u.Language != nil {
+		language, err := lang.Parse(*u.Language)
+		if err != nil {
+			return u, err
+		}
+
+		value := string(language)
+		u.Language = &value
+	}
+
+	if u.TimeZone != nil {
+		if err := validateTimeZone(*u.TimeZone); err != nil {
+			return u, err
+		}
+	}
+
+	return u, nil
+}
+
+func validateTimeZone(name string) error {
+	const local = "Local"
+
+	if name == "" || name == local {
+		return ErrInvalidTimeZone
+	}
+
+	if _, err := time.LoadLocation(name); err != nil {
+		return ErrInvalidTimeZone
+	}
+
+	return nil
+}
+
+type Avatar struct {
+	ContentType string
+	Data        []byte
+}
+
+func NewAvatar(data []byte) (Avatar, error) {
+	if len(data) > MaxAvatarSize {
+		return Avatar{}, ErrAvatarTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if _, ok := allowedAvatarTypes[contentType]; !ok {
+		return Avatar{}, ErrUnsupportedAvatar
+	}
+
+	return Avatar{
+		ContentType: contentType,
+		Data:        data,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package usr
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhuboris/never-expires/internal/id/lang"
+)
+
+func TestProfileUpdate_normalized(t *testing.T) {
+	pointer := func(value string) *string {
+		return &value
+	}
+
+	tests := []struct {
+		name         string
+		update       ProfileUpdate
+		wantLanguage *string
+		wantErr      error
+	}{
+		{
+			name:         "locale is stored as base language",
+			update:       ProfileUpdate{Language: pointer("ru-RU")},
+			wantLanguage: pointer("ru"),
+		},
+		{
+			name:   "valid time zone",
+			update: ProfileUpdate{TimeZone: pointer("America/New_York")},
+		},
+		{
+			name:    "invalid language",
+			update:  ProfileUpdate{Language: pointer("klingon")},
+			wantErr: lang.ErrInvalidLanguage,
+		},
+		{
+			name:    "unknown time zone",
+			update:  ProfileUpdate{TimeZone: pointer("Mars/Olympus_Mons")},
+			wantErr: ErrInvalidTimeZone,
+		},
+		{
+			name:    "local time zone of the server",
+			update:  ProfileUpdate{TimeZone: pointer("Local")},
+			wantErr: ErrInvalidTimeZone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.update.normalized()
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLanguage, got.Language)
+		})
+	}
+}
+
+func TestNewAvatar(t *testing.T) {
+	pngHeader := []byte("\x89PNG\x0D\x0A\x1A\x0A")
+
+	tests := []struct {
+		name            string
+		data            []byte
+		wantContentType string
+		wantErr         error
+	}{
+		{
+			name:            "png",
+			data:            pngHeader,
+			wantContentType: "image/png",
+		},
+		{
+			name:    "gif is not supported",
+			data:    []byte("GIF89a"),
+			wantErr: ErrUnsupportedAvatar,
+		},
+		{
+			name:    "text",
+			data:    []byte("avatar"),
+			wantErr: ErrUnsupportedAvatar,
+		},
+		{
+			name:    "too large",
+			data:    append(pngHeader, bytes.Repeat([]byte{0}, MaxAvatarSize)...),
+			wantErr: ErrAvatarTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			avatar, err := NewAvatar(tt.data)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantContentType, avatar.ContentType)
+		})
+	}
+}
@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/zhuboris/never-expires/internal/id/pw"
 	"github.com/zhuboris/never-expires/internal/id/usr/oauth"
+	"github.com/zhuboris/never-expires/internal/id/usr/oauth/oidc"
 	"github.com/zhuboris/never-expires/internal/shared/postgresql"
 	"github.com/zhuboris/never-expires/internal/shared/servicechecker"
 )
@@ -21,41 +24,72 @@ type (
 		addByPassword(ctx context.Context, user User) (*User, error)
 		byID(ctx context.Context, id pgtype.UUID) (*User, error)
 		byEmail(ctx context.Context, email string) (*User, error)
-		delete(ctx context.Context, id pgtype.UUID) error
+		scheduleDeletion(ctx context.Context, id pgtype.UUID, restoreToken ConfirmationToken) (email string, err error)
+		restore(ctx context.Context, restoreToken string) error
+		cancelDeletion(ctx context.Context, id pgtype.UUID) error
+		isDisabled(ctx context.Context, id pgtype.UUID) (bool, error)
 		encryptedPassword(ctx context.Context, userID pgtype.UUID) (string, error)
 		updateColumn(ctx context.Context, userID pgtype.UUID, toUpdate column, new string) error
+		addEmailChange(ctx context.Context, userID pgtype.UUID, change EmailChange) (oldEmail string, err error)
+		confirmEmailChange(ctx context.Context, confirmToken string) (AppliedEmailChange, error)
+		revertEmailChange(ctx context.Context, revertToken string) (AppliedEmailChange, error)
 		validateEmail(ctx context.Context, validationToken string) error
 		restorePassword(ctx context.Context, validationToken, newPassword string) (userEmail string, err error)
 		isConfirmed(ctx context.Context, email string) (bool, error)
 		byOAuth(ctx context.Context, userInputted oauth.User, oAuthServiceOption oAuthMethod) (*User, oauth.LoginResultType, error)
+		externalIdentities(ctx context.Context, userID pgtype.UUID) ([]ExternalIdentity, error)
+		linkExternalIdentity(ctx context.Context, userID pgtype.UUID, provider oAuthProvider, subject string) error
+		unlinkExternalIdentity(ctx context.Context, userID pgtype.UUID, provider oAuthProvider) error
 		saveAppleRefreshToken(ctx context.Context, userID pgtype.UUID, token string) error
-		allAppleRefreshTokens(ctx context.Context, userID pgtype.UUID) ([]string, error)
+		addDataExport(ctx context.Context, userID pgtype.UUID, language string) error
+		dataExportArchive(ctx context.Context, tokenHash string) ([]byte, error)
 		addEmailConfirmationToken(ctx context.Context, email string, tempToken ConfirmationToken) error
 		addPasswordResetToken(ctx context.Context, email string, tempToken ConfirmationToken) error
+		addEmailLoginCode(ctx context.Context, email string, code LoginCode) error
+		byEmailLoginCode(ctx context.Context, email, code string) (*User, error)
+		byEmailLoginToken(ctx context.Context, token string) (*User, error)
+		profile(ctx context.Context, userID pgtype.UUID) (Profile, error)
+		updateProfile(ctx context.Context, userID pgtype.UUID, update ProfileUpdate) error
+		avatarContentType(ctx context.Context, userID pgtype.UUID) (string, error)
+		setAvatarContentType(ctx context.Context, userID pgtype.UUID, contentType *string) error
 		Ping(ctx context.Context) error
 	}
+	AvatarStorage interface {
+		Save(ctx context.Context, userID string, data []byte) error
+		Load(ctx context.Context, userID string) ([]byte, error)
+		Delete(ctx context.Context, userID string) error
+	}
 	GoogleOAuthService interface {
 		UserFromToken(ctx context.Context, idToken oauth.Token) (oauth.User, error)
 	}
 	AppleOAuthService interface {
 		UserFromToken(ctx context.Context, idToken oauth.Token) (oauth.User, error)
-		RevokeRefreshToken(ctx context.Context, refreshToken string) error
+	}
+	OIDCProviders interface {
+		UserFromToken(ctx context.Context, providerName string, idToken oauth.Token) (oauth.User, error)
+		Providers() []oidc.ProviderInfo
 	}
 )
 
 type Service struct {
-	googleOauth  GoogleOAuthService
-	appleSignIn  AppleOAuthService
-	repo         repository
-	statusMetric servicechecker.StatusDisplay
+	googleOauth         GoogleOAuthService
+	appleSignIn         AppleOAuthService
+	oidcProviders       OIDCProviders
+	repo                repository
+	avatars             AvatarStorage
+	statusMetric        servicechecker.StatusDisplay
+	deletionGracePeriod time.Duration
 }
 
-func NewService(repo repository, googleOauth GoogleOAuthService, appleSignIn AppleOAuthService, statusDisplay servicechecker.StatusDisplay) *Service {
+func NewService(repo repository, avatars AvatarStorage, googleOauth GoogleOAuthService, appleSignIn AppleOAuthService, oidcProviders OIDCProviders, statusDisplay servicechecker.StatusDisplay, deletionGracePeriod time.Duration) *Service {
 	return &Service{
-		repo:         repo,
-		googleOauth:  googleOauth,
-		appleSignIn:  appleSignIn,
-		statusMetric: statusDisplay,
+		repo:                repo,
+		avatars:             avatars,
+		googleOauth:         googleOauth,
+		appleSignIn:         appleSignIn,
+		oidcProviders:       oidcProviders,
+		statusMetric:        statusDisplay,
+		deletionGracePeriod: deletionGracePeriod,
 	}
 }
 
@@ -89,6 +123,15 @@ func (s Service) UserByEmail(ctx context.Context, email string) (*User, error) {
 	return s.repo.byEmail(ctx, email)
 }
 
+func (s Service) UserByID(ctx context.Context, id pgtype.UUID) (*User, error) {
+	user, err := s.repo.byID(ctx, id)
+	if errors.Is(err, postgresql.ErrNoMatches) {
+		err = errors.Join(ErrNotFound, err)
+	}
+
+	return user, err
+}
+
 func (s Service) PublicDataByUserCtx(ctx context.Context) (*PublicData, error) {
 	id, err := ID(ctx)
 	if err != nil {
@@ -100,20 +143,158 @@ func (s Service) PublicDataByUserCtx(ctx context.Context) (*PublicData, error) {
 		return nil, err
 	}
 
+	profile, err := s.repo.profile(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
 	return &PublicData{
 		Username:         user.Username,
 		Email:            user.Email,
 		IsEmailConfirmed: user.IsEmailConfirmed,
+		Profile:          &profile,
 	}, nil
 }
 
-func (s Service) Delete(ctx context.Context) error {
+func (s Service) UpdateProfile(ctx context.Context, update ProfileUpdate) (*PublicData, error) {
+	userID, err := ID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	update, err = update.normalized()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.updateProfile(ctx, userID, update)
+	if errors.Is(err, postgresql.ErrNoMatches) {
+		return nil, errors.Join(ErrNotFound, err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.PublicDataByUserCtx(ctx)
+}
+
+func (s Service) Avatar(ctx context.Context) (Avatar, error) {
+	userID, err := ID(ctx)
+	if err != nil {
+		return Avatar{}, err
+	}
+
+	contentType, err := s.repo.avatarContentType(ctx, userID)
+	if errors.Is(err, postgresql.ErrNoMatches) {
+		return Avatar{}, errors.Join(ErrAvatarNotFound, err)
+	}
+
+	if err != nil {
+		return Avatar{}, err
+	}
+
+	data, err := s.avatars.Load(ctx, uuid.UUID(userID.Bytes).String())
+	if errors.Is(err, fs.ErrNotExist) {
+		return Avatar{}, errors.Join(ErrAvatarNotFound, err)
+	}
+
+	return Avatar{
+		ContentType: contentType,
+		Data:        data,
+	}, err
+}
+
+func (s Service) UpdateAvatar(ctx context.Context, data []byte) error {
+	userID, err := ID(ctx)
+	if err != nil {
+		return err
+	}
+
+	avatar, err := NewAvatar(data)
+	if err != nil {
+		return err
+	}
+
+	if err := s.avatars.Save(ctx, uuid.UUID(userID.Bytes).String(), avatar.Data); err != nil {
+		return err
+	}
+
+	err = s.repo.setAvatarContentType(ctx, userID, &avatar.ContentType)
+	if errors.Is(err, postgresql.ErrNoMatches) {
+		err = errors.Join(ErrNotFound, err)
+	}
+
+	return err
+}
+
+func (s Service) DeleteAvatar(ctx context.Context) error {
+	userID, err := ID(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.setAvatarContentType(ctx, userID, nil)
+	if errors.Is(err, postgresql.ErrNoMatches) {
+		return errors.Join(ErrNotFound, err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return s.avatars.Delete(ctx, uuid.UUID(userID.Bytes).String())
+}
+
+func (s Service) Delete(ctx context.Context) (ScheduledDeletion, error) {
 	id, err := ID(ctx)
+	if err != nil {
+		return ScheduledDeletion{}, err
+	}
+
+	restoreToken, err := newConfirmationToken(s.deletionGracePeriod)
+	if err != nil {
+		return ScheduledDeletion{}, err
+	}
+
+	email, err := s.repo.scheduleDeletion(ctx, id, restoreToken)
+	if err != nil {
+		return ScheduledDeletion{}, err
+	}
+
+	return ScheduledDeletion{
+		Email:        email,
+		RestoreToken: restoreToken,
+	}, nil
+}
+
+func (s Service) Restore(ctx context.Context, restoreToken string) error {
+	if err := checkIfTokenNotEmpty(restoreToken); err != nil {
+		return errors.Join(ErrRestoreRefused, err)
+	}
+
+	if err := s.repo.restore(ctx, restoreToken); err != nil {
+		return errors.Join(ErrRestoreRefused, err)
+	}
+
+	return nil
+}
+
+func (s Service) CancelDeletion(ctx context.Context, id pgtype.UUID) error {
+	return s.repo.cancelDeletion(ctx, id)
+}
+
+func (s Service) CheckNotDisabled(ctx context.Context, id pgtype.UUID) error {
+	isDisabled, err := s.repo.isDisabled(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	return s.repo.delete(ctx, id)
+	if isDisabled {
+		return ErrAccountDisabled
+	}
+
+	return nil
 }
 
 func (s Service) CheckPassword(ctx context.Context, toCheck string) error {
@@ -163,23 +344,84 @@ func (s Service) UpdatePassword(ctx context.Context, new string) error {
 	return err
 }
 
-func (s Service) UpdateEmail(ctx context.Context, new string) error {
-	userID, err := ID(ctx)
+// RehashPassword stores new hash of already verified password, it is not validated by current policy.
+func (s Service) RehashPassword(ctx context.Context, userID pgtype.UUID, verified string) error {
+	encryptedPassword, err := pw.Hash(verified)
 	if err != nil {
 		return err
 	}
+
+	err = s.repo.updateColumn(ctx, userID, password, encryptedPassword)
+	if errors.Is(err, postgresql.ErrNoMatches) {
+		err = errors.Join(ErrNotFound, err)
+	}
+
+	return err
+}
+
+func (s Service) RequestEmailChange(ctx context.Context, new string) (EmailChange, error) {
+	userID, err := ID(ctx)
+	if err != nil {
+		return EmailChange{}, err
+	}
+
 	newAddress, err := parseAddress(new)
 	if err != nil {
-		return errors.Join(ErrInvalidEmail, err)
+		return EmailChange{}, errors.Join(ErrInvalidEmail, err)
 	}
 
-	new = strings.ToLower(newAddress.Address)
-	err = s.repo.updateColumn(ctx, userID, email, new)
+	confirmToken, err := newConfirmationToken(emailChangeConfirmationLifetime)
+	if err != nil {
+		return EmailChange{}, err
+	}
+
+	revertToken, err := newConfirmationToken(emailChangeRevertWindow)
+	if err != nil {
+		return EmailChange{}, err
+	}
+
+	change := EmailChange{
+		NewEmail:     strings.ToLower(newAddress.Address),
+		ConfirmToken: confirmToken,
+		RevertToken:  revertToken,
+	}
+
+	change.OldEmail, err = s.repo.addEmailChange(ctx, userID, change)
 	if errors.Is(err, postgresql.ErrNoMatches) {
-		err = errors.Join(ErrNotFound, err)
+		return EmailChange{}, errors.Join(ErrNotFound, err)
 	}
 
-	return err
+	if err != nil {
+		return EmailChange{}, err
+	}
+
+	return change, nil
+}
+
+func (s Service) ConfirmEmailChange(ctx context.Context, confirmToken string) (AppliedEmailChange, error) {
+	if err := checkIfTokenNotEmpty(confirmToken); err != nil {
+		return AppliedEmailChange{}, errors.Join(ErrEmailChangeRefused, err)
+	}
+
+	change, err := s.repo.confirmEmailChange(ctx, confirmToken)
+	if err != nil {
+		return AppliedEmailChange{}, errors.Join(ErrEmailChangeRefused, err)
+	}
+
+	return change, nil
+}
+
+func (s Service) RevertEmailChange(ctx context.Context, revertToken string) (AppliedEmailChange, error) {
+	if err := checkIfTokenNotEmpty(revertToken); err != nil {
+		return AppliedEmailChange{}, errors.Join(ErrEmailChangeRefused, err)
+	}
+
+	change, err := s.repo.revertEmailChange(ctx, revertToken)
+	if err != nil {
+		return AppliedEmailChange{}, errors.Join(ErrEmailChangeRefused, err)
+	}
+
+	return change, nil
 }
 
 func (s Service) UpdateUsername(ctx context.Context, new string) error {
@@ -208,27 +450,26 @@ func (s Service) ConfirmEmail(ctx context.Context, validationToken string) error
 	return nil
 }
 
-func (s Service) ResetPassword(ctx context.Context, validationToken string) (user *User, newPassword string, err error) {
-	const newPasswordLength = 12
-
+func (s Service) ResetPassword(ctx context.Context, validationToken, newPassword string) (*User, error) {
 	if err := checkIfTokenNotEmpty(validationToken); err != nil {
-		return nil, "", errors.Join(ErrPasswordResetRefused, err)
+		return nil, errors.Join(ErrPasswordResetRefused, err)
+	}
+
+	if err := pw.Validate(newPassword); err != nil {
+		return nil, err
 	}
 
-	newPassword = pw.Generate(newPasswordLength)
 	encryptedPassword, err := pw.Hash(newPassword)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
 	email, err := s.repo.restorePassword(ctx, validationToken, encryptedPassword)
 	if err != nil {
-		return nil, "", errors.Join(ErrPasswordResetRefused, err)
+		return nil, errors.Join(ErrPasswordResetRefused, err)
 	}
 
-	user, err = s.UserByEmail(ctx, email)
-	return user, newPassword, err
-
+	return s.UserByEmail(ctx, email)
 }
 
 func (s Service) IsConfirmed(ctx context.Context, email string) (bool, error) {
@@ -244,6 +485,14 @@ func (s Service) UserFromAppleTokenCode(ctx context.Context, idToken oauth.Token
 	return s.appleSignIn.UserFromToken(ctx, idToken)
 }
 
+func (s Service) UserFromOIDCToken(ctx context.Context, providerName string, idToken oauth.Token) (oauth.User, error) {
+	return s.oidcProviders.UserFromToken(ctx, providerName, idToken)
+}
+
+func (s Service) OIDCProviders() []oidc.ProviderInfo {
+	return s.oidcProviders.Providers()
+}
+
 func (s Service) LoginWithOAuthGoogle(ctx context.Context, user oauth.User) (*User, oauth.LoginResultType, error) {
 	user.UpdateEmailToLower()
 	return s.repo.byOAuth(ctx, user, withGoogle())
@@ -260,25 +509,55 @@ func (s Service) LoginWithApple(ctx context.Context, user oauth.User) (*User, oa
 	return loggedUser, resultType, err
 }
 
-func (s Service) TryRevokeAppleAccount(ctx context.Context) error {
+func (s Service) LoginWithOIDC(ctx context.Context, providerName string, user oauth.User) (*User, oauth.LoginResultType, error) {
+	user.UpdateEmailToLower()
+	return s.repo.byOAuth(ctx, user, withOIDC(providerName))
+}
+
+func (s Service) UserFromExternalToken(ctx context.Context, providerName string, idToken oauth.Token) (oauth.User, error) {
+	switch providerName {
+	case oauth.GoogleProviderName:
+		return s.UserFromGoogleIDToken(ctx, idToken)
+	case oauth.AppleProviderName:
+		return s.UserFromAppleTokenCode(ctx, idToken)
+	default:
+		return s.UserFromOIDCToken(ctx, providerName, idToken)
+	}
+}
+
+func (s Service) ExternalIdentities(ctx context.Context) ([]ExternalIdentity, error) {
+	id, err := ID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.externalIdentities(ctx, id)
+}
+
+func (s Service) LinkExternalIdentity(ctx context.Context, providerName string, user oauth.User) error {
 	id, err := ID(ctx)
 	if err != nil {
 		return err
 	}
 
-	tokens, err := s.repo.allAppleRefreshTokens(ctx, id)
-	if len(tokens) == 0 {
+	if err := s.repo.linkExternalIdentity(ctx, id, oAuthProvider(providerName), user.ID()); err != nil {
 		return err
 	}
 
-	for _, token := range tokens {
-		revokeErr := s.appleSignIn.RevokeRefreshToken(ctx, token)
-		if revokeErr != nil {
-			err = errors.Join(revokeErr, err)
-		}
+	if providerName != oauth.AppleProviderName {
+		return nil
 	}
 
-	return err
+	return s.repo.saveAppleRefreshToken(ctx, id, user.RefreshToken())
+}
+
+func (s Service) UnlinkExternalIdentity(ctx context.Context, providerName string) error {
+	id, err := ID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.unlinkExternalIdentity(ctx, id, oAuthProvider(providerName))
 }
 
 func (s Service) AddEmailConfirmationToken(ctx context.Context, email string, tokenLifetime time.Duration) (string, error) {
@@ -299,6 +578,47 @@ func (s Service) AddPasswordResetToken(ctx context.Context, email string, tokenL
 	return token.Value, s.repo.addPasswordResetToken(ctx, email, token)
 }
 
+func (s Service) AddEmailLoginCode(ctx context.Context, email string, codeLifetime time.Duration) (LoginCode, error) {
+	code, err := newLoginCode(codeLifetime)
+	if err != nil {
+		return LoginCode{}, err
+	}
+
+	email = strings.ToLower(email)
+	err = s.repo.addEmailLoginCode(ctx, email, code)
+	if errors.Is(err, postgresql.ErrNoMatches) {
+		err = errors.Join(ErrNotFound, err)
+	}
+
+	return code, err
+}
+
+func (s Service) UserByEmailLoginCode(ctx context.Context, email, code string) (*User, error) {
+	if err := checkIfTokenNotEmpty(code); err != nil {
+		return nil, errors.Join(ErrLoginCodeRefused, err)
+	}
+
+	user, err := s.repo.byEmailLoginCode(ctx, strings.ToLower(email), code)
+	if err != nil {
+		return nil, errors.Join(ErrLoginCodeRefused, err)
+	}
+
+	return user, nil
+}
+
+func (s Service) UserByEmailLoginToken(ctx context.Context, token string) (*User, error) {
+	if err := checkIfTokenNotEmpty(token); err != nil {
+		return nil, errors.Join(ErrLoginCodeRefused, err)
+	}
+
+	user, err := s.repo.byEmailLoginToken(ctx, token)
+	if err != nil {
+		return nil, errors.Join(ErrLoginCodeRefused, err)
+	}
+
+	return user, nil
+}
+
 func (s Service) Status(ctx context.Context) error {
 	return servicechecker.Ping(ctx, s.repo, s.statusMetric, "userRepository")
 }
@@ -310,3 +630,20 @@ func checkIfTokenNotEmpty(input string) error {
 
 	return nil
 }
+
+func (s Service) RequestDataExport(ctx context.Context, language string) error {
+	userID, err := ID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.addDataExport(ctx, userID, language)
+}
+
+func (s Service) DataExportArchive(ctx context.Context, token string) ([]byte, error) {
+	if err := checkIfTokenNotEmpty(token); err != nil {
+		return nil, err
+	}
+
+	return s.repo.dataExportArchive(ctx, hashDataExportToken(token))
+}
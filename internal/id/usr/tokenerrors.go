@@ -33,4 +33,6 @@ var (
 	errTokenExpired          = InvalidTokenError{"expired"}
 	errTokenNotExists        = InvalidTokenError{"not exists"}
 	errTokenAlreadyUsed      = InvalidTokenError{"it has already been used"}
+	errWrongLoginCode        = InvalidTokenError{"code does not match"}
+	errTooManyCodeAttempts   = InvalidTokenError{"too many failed attempts to enter code"}
 )
@@ -11,8 +11,11 @@ import (
 
 	"github.com/zhuboris/never-expires/internal/reminder/api/endpoint"
 	"github.com/zhuboris/never-expires/internal/reminder/api/request"
+	"github.com/zhuboris/never-expires/internal/shared/health"
 	"github.com/zhuboris/never-expires/internal/shared/httpmux"
+	"github.com/zhuboris/never-expires/internal/shared/idempotency"
 	"github.com/zhuboris/never-expires/internal/shared/prometheusexporter"
+	"github.com/zhuboris/never-expires/internal/shared/ratelimit"
 	"github.com/zhuboris/never-expires/internal/shared/runapi"
 )
 
@@ -20,6 +23,8 @@ var errUnexpectedMethod = errors.New("server allowed method that is not supporte
 
 type requestCounterCreator interface {
 	NewRequestCounter() (*prometheusexporter.RequestCounter, error)
+	NewRequestDurationHistogram() (*prometheusexporter.RequestDurationHistogram, error)
+	NewThrottleCounter() (*prometheusexporter.ThrottleCounter, error)
 }
 
 type Server struct {
@@ -28,31 +33,41 @@ type Server struct {
 	storageService request.StorageService
 	itemService    request.ItemService
 	apnsService    request.ApnsService
+	healthChecker  *health.Checker
+	rateLimitStore ratelimit.Store
+	idemStore      idempotency.Store
 	logger         *zap.Logger
 	exporter       requestCounterCreator
 }
 
-func NewServer(listenAddress string, storageService request.StorageService, itemService request.ItemService, apnsService request.ApnsService, logger *zap.Logger, exporter requestCounterCreator) *Server {
+func NewServer(listenAddress string, storageService request.StorageService, itemService request.ItemService, apnsService request.ApnsService, healthChecker *health.Checker, rateLimitStore ratelimit.Store, idemStore idempotency.Store, logger *zap.Logger, exporter requestCounterCreator) *Server {
 	return &Server{
 		listenAddress:  listenAddress,
 		storageService: storageService,
 		itemService:    itemService,
 		apnsService:    apnsService,
+		healthChecker:  healthChecker,
+		rateLimitStore: rateLimitStore,
+		idemStore:      idemStore,
 		logger:         logger,
 		exporter:       exporter,
 	}
 }
 func (s *Server) RunWithCtx(ctx context.Context) error {
-	return runapi.WithContext(ctx, s.run, func() error {
-		return s.server.Shutdown(context.Background())
+	return runapi.WithContext(ctx, s.run, func(ctx context.Context) error {
+		return s.server.Shutdown(ctx)
 	})
 }
 
 func (s *Server) run() error {
 	const (
-		defaultTimeout = 10 * time.Second
+		defaultTimeout       = 10 * time.Second
+		idempotencyTTL       = 24 * time.Hour
+		apnsTokenMaxBodySize = 1 << 10
 	)
 
+	suggestionsLimit := ratelimit.PerMinute(120, 60)
+
 	mux, err := s.setupMux(defaultTimeout)
 	if err != nil {
 		return err
@@ -63,16 +78,16 @@ func (s *Server) run() error {
 		Handler: mux,
 	}
 
-	mux.HandleFuncWithMiddlewares(endpoint.Items, s.handleItems, []string{http.MethodGet, http.MethodPost}, httpmux.Authorize())
+	mux.HandleFuncWithMiddlewares(endpoint.Items, s.handleItems, []string{http.MethodGet, http.MethodPost}, httpmux.Authorize(), mux.Idempotent(idempotencyTTL))
 	mux.HandleFuncWithMiddlewares(endpoint.ItemsWithParam, s.handleItemsByID, []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete}, httpmux.Authorize())
-	mux.HandlePost(endpoint.ItemsMakeCopy, s.handleItemsMakeCopy, httpmux.Authorize())
+	mux.HandlePost(endpoint.ItemsMakeCopy, s.handleItemsMakeCopy, httpmux.Authorize(), mux.Idempotent(idempotencyTTL))
 	mux.HandlePost(endpoint.ItemsMakeCopyWithParam, s.handleItemsMakeCopyByID, httpmux.Authorize())
-	mux.HandleFuncWithMiddlewares(endpoint.Storages, s.handleStorages, []string{http.MethodGet, http.MethodPost}, httpmux.Authorize())
+	mux.HandleFuncWithMiddlewares(endpoint.Storages, s.handleStorages, []string{http.MethodGet, http.MethodPost}, httpmux.Authorize(), mux.Idempotent(idempotencyTTL))
 	mux.HandleFuncWithMiddlewares(endpoint.StoragesWithParam, s.handleStoragesByID, []string{http.MethodPost, http.MethodPut, http.MethodDelete}, httpmux.Authorize())
-	mux.HandleGet(endpoint.ItemsAutocompleteSuggestions, s.handleItemsAutocompleteSuggestions, httpmux.Authorize())
-	mux.HandlePost(endpoint.ApnsDeviceToken, s.handleApnsDeviceToken, httpmux.Authorize())
+	mux.HandleGet(endpoint.ItemsAutocompleteSuggestions, s.handleItemsAutocompleteSuggestions, httpmux.Authorize(), mux.RateLimit(suggestionsLimit, httpmux.ByUser))
+	mux.HandlePost(endpoint.ApnsDeviceToken, s.handleApnsDeviceToken, httpmux.Authorize(), httpmux.MaxBodySize(apnsTokenMaxBodySize))
 
-	mux.HandleStatus(s.itemService, s.storageService, s.apnsService)
+	mux.HandleHealth(s.healthChecker)
 	mux.HandleSwaggerBySpecification("./api/reminder/swagger.yml")
 
 	s.logger.Info("Server is up")
@@ -92,6 +107,22 @@ func (s *Server) setupMux(defaultTimeout time.Duration) (*httpmux.Mux, error) {
 	}
 
 	mux.SetRequestCounter(counter)
+
+	durationHistogram, err := s.exporter.NewRequestDurationHistogram()
+	if err != nil {
+		return nil, fmt.Errorf("error init request duration histogram: %w", err)
+	}
+
+	mux.SetRequestDurationHistogram(durationHistogram)
+
+	throttleCounter, err := s.exporter.NewThrottleCounter()
+	if err != nil {
+		return nil, fmt.Errorf("error init throttle counter: %w", err)
+	}
+
+	mux.SetThrottleCounter(throttleCounter)
+	mux.SetRateLimitStore(s.rateLimitStore)
+	mux.SetIdempotencyStore(s.idemStore)
 	return mux, nil
 }
 
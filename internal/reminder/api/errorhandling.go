@@ -10,8 +10,11 @@ import (
 	"github.com/zhuboris/never-expires/internal/reminder/item"
 	"github.com/zhuboris/never-expires/internal/reminder/queryerr"
 	"github.com/zhuboris/never-expires/internal/reminder/storage"
+	"github.com/zhuboris/never-expires/internal/shared/etag"
 	"github.com/zhuboris/never-expires/internal/shared/httpmux"
+	"github.com/zhuboris/never-expires/internal/shared/reqbody"
 	"github.com/zhuboris/never-expires/internal/shared/uuidformat"
+	"github.com/zhuboris/never-expires/internal/shared/validation"
 )
 
 const (
@@ -25,6 +28,7 @@ const (
 	StatusStorageNotFound          httpmux.StatusCode = 4002
 	StatusStorageNameAlreadyExists httpmux.StatusCode = 4003
 	StatusDeletingNotAllowed       httpmux.StatusCode = 4004
+	StatusVersionMismatch          httpmux.StatusCode = 4005
 )
 
 func handleResponseErrors(err error) httpmux.RequestingResult {
@@ -73,6 +77,46 @@ func handleResponseErrors(err error) httpmux.RequestingResult {
 			Build()
 	}
 
+	if errors.Is(err, httpmux.ErrTooManyRequests) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusTooManyRequests).
+			AddInternalErrorCode(httpmux.StatusRateLimited).
+			AddResponseMessage(httpmux.StatusRateLimited.ErrorMessage(httpmux.ErrTooManyRequests.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, httpmux.ErrInvalidIdempotencyKey) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(httpmux.StatusInvalidIdempotencyKey).
+			AddResponseMessage(httpmux.StatusInvalidIdempotencyKey.ErrorMessage(httpmux.ErrInvalidIdempotencyKey.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, httpmux.ErrIdempotencyKeyReused) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(httpmux.StatusIdempotencyKeyReused).
+			AddResponseMessage(httpmux.StatusIdempotencyKeyReused.ErrorMessage(httpmux.ErrIdempotencyKeyReused.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if errors.Is(err, httpmux.ErrIdempotencyKeyInFlight) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusConflict).
+			AddInternalErrorCode(httpmux.StatusIdempotencyKeyInFlight).
+			AddResponseMessage(httpmux.StatusIdempotencyKeyInFlight.ErrorMessage(httpmux.ErrIdempotencyKeyInFlight.Error())).
+			AddError(err).
+			Build()
+	}
+
 	if errors.Is(err, httpmux.ErrMethodNotAllowed) {
 		return httpmux.NewRequestingResultBuilder().
 			SetType(httpmux.Error).
@@ -81,6 +125,26 @@ func handleResponseErrors(err error) httpmux.RequestingResult {
 			Build()
 	}
 
+	if errors.Is(err, reqbody.ErrBodyTooLarge) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusRequestEntityTooLarge).
+			AddInternalErrorCode(httpmux.StatusBodyTooLarge).
+			AddResponseMessage(httpmux.StatusBodyTooLarge.ErrorMessage(reqbody.ErrBodyTooLarge.Error())).
+			AddError(err).
+			Build()
+	}
+
+	if fields, ok := validation.FieldErrors(err); ok {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusUnprocessableEntity).
+			AddInternalErrorCode(httpmux.StatusInvalidFields).
+			AddResponseMessage(httpmux.StatusInvalidFields.InvalidFieldsMessage(validation.ErrInvalidFields.Error(), fields)).
+			AddError(err).
+			Build()
+	}
+
 	if errors.Is(err, request.ErrInvalidBody) {
 		return httpmux.NewRequestingResultBuilder().
 			SetType(httpmux.Error).
@@ -144,15 +208,6 @@ func handleResponseErrors(err error) httpmux.RequestingResult {
 			Build()
 	}
 
-	if errors.Is(err, request.ErrInvalidUUIDInBody) {
-		return httpmux.NewRequestingResultBuilder().
-			SetType(httpmux.Error).
-			AddStatusCode(http.StatusUnprocessableEntity).
-			AddResponseMessage(StatusInvalidUUID.ErrorMessage(request.ErrInvalidUUIDInBody.Error())).
-			AddError(err).
-			Build()
-	}
-
 	if errors.Is(err, request.ErrNewUUIDNotUnique) {
 		return httpmux.NewRequestingResultBuilder().
 			SetType(httpmux.Error).
@@ -198,6 +253,16 @@ func handleResponseErrors(err error) httpmux.RequestingResult {
 			Build()
 	}
 
+	if errors.Is(err, queryerr.ErrVersionMismatch) || errors.Is(err, etag.ErrPreconditionFailed) {
+		return httpmux.NewRequestingResultBuilder().
+			SetType(httpmux.Error).
+			AddStatusCode(http.StatusPreconditionFailed).
+			AddInternalErrorCode(StatusVersionMismatch).
+			AddResponseMessage(StatusVersionMismatch.ErrorMessage(queryerr.ErrVersionMismatch.Error())).
+			AddError(err).
+			Build()
+	}
+
 	return httpmux.NewRequestingResultBuilder().
 		SetType(httpmux.Error).
 		AddStatusCode(http.StatusInternalServerError).
@@ -19,16 +19,12 @@ func NewAddItemRequest(items ItemService) *AddItemRequest {
 }
 
 func (req AddItemRequest) Handle(w http.ResponseWriter, r *http.Request) error {
-	body := new(itemData)
+	body := new(newItemData)
 	if err := reqbody.Decode(body, r.Body); err != nil {
 		return errors.Join(ErrInvalidBody, err)
 	}
 
-	if err := body.checkIfStorageIDValid(); err != nil {
-		return err
-	}
-
-	toAdd, err := body.toValidItemWithAddedDateRequired()
+	toAdd, err := body.toValidItem()
 	if err != nil {
 		return err
 	}
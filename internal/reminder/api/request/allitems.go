@@ -8,7 +8,7 @@ import (
 
 	"github.com/zhuboris/never-expires/internal/reminder/api/endpoint"
 	"github.com/zhuboris/never-expires/internal/reminder/item"
-	"github.com/zhuboris/never-expires/internal/shared/rwjson"
+	"github.com/zhuboris/never-expires/internal/shared/etag"
 	"github.com/zhuboris/never-expires/internal/shared/uuidformat"
 )
 
@@ -33,7 +33,7 @@ func (req GetAllItemsRequest) Handle(w http.ResponseWriter, r *http.Request) err
 		return err
 	}
 
-	return rwjson.WriteJSON(w, http.StatusOK, all.ToResponseFormat())
+	return etag.WriteContentJSON(w, r, http.StatusOK, all.ToResponseFormat())
 }
 
 func (req GetAllItemsRequest) requestedFilters(query url.Values) ([]item.Filter, error) {
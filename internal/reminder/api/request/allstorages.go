@@ -3,7 +3,7 @@ package request
 import (
 	"net/http"
 
-	"github.com/zhuboris/never-expires/internal/shared/rwjson"
+	"github.com/zhuboris/never-expires/internal/shared/etag"
 )
 
 type GetAllStoragesRequest struct {
@@ -22,5 +22,5 @@ func (req GetAllStoragesRequest) Handle(w http.ResponseWriter, r *http.Request)
 		return err
 	}
 
-	return rwjson.WriteJSON(w, http.StatusOK, all)
+	return etag.WriteContentJSON(w, r, http.StatusOK, all)
 }
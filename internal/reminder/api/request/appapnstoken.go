@@ -23,10 +23,6 @@ func (req AddApnsTokenRequest) Handle(w http.ResponseWriter, r *http.Request) er
 		return errors.Join(ErrInvalidBody, err)
 	}
 
-	if data.isMissing() {
-		return ErrMissingRequiredField
-	}
-
 	if err := req.apns.AddDeviceToken(r.Context(), data.Token); err != nil {
 		return err
 	}
@@ -4,7 +4,7 @@ import (
 	"net/http"
 
 	"github.com/zhuboris/never-expires/internal/reminder/api/endpoint"
-	"github.com/zhuboris/never-expires/internal/shared/rwjson"
+	"github.com/zhuboris/never-expires/internal/shared/etag"
 )
 
 type GetItemRequest struct {
@@ -28,5 +28,5 @@ func (req GetItemRequest) Handle(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	return rwjson.WriteJSON(w, http.StatusOK, item.ToResponseFormat())
+	return etag.WriteJSON(w, r, http.StatusOK, item.ToResponseFormat(), etag.FromVersion(item.Version))
 }
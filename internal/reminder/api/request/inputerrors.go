@@ -17,7 +17,6 @@ func (e InputError) Error() string {
 
 var (
 	ErrMissingParam         = InputError{"missing required parameter"}
-	ErrInvalidUUIDInBody    = InputError{"invalid uuid in body"}
 	ErrInvalidTimeFormat    = InputError{"invalid time format"}
 	ErrInvalidQuery         = InputError{"invalid query data"}
 	ErrOptionNotExists      = InputError{"option is not exist"}
@@ -10,31 +10,37 @@ import (
 
 type (
 	itemData struct {
-		Name              string      `json:"name"`
-		DateAdded         string      `json:"date_added"`
-		BestBefore        string      `json:"best_before"`
+		Name              string      `json:"name" validate:"required"`
+		DateAdded         string      `json:"date_added" validate:"rfc3339"`
+		BestBefore        string      `json:"best_before" validate:"required,rfc3339"`
 		IsOpened          bool        `json:"is_opened"`
-		HoursAfterOpening int         `json:"hours_after_opening"`
+		HoursAfterOpening int         `json:"hours_after_opening" validate:"min=0"`
 		Note              string      `json:"note"`
 		StorageID         pgtype.UUID `json:"storage_id"`
 	}
+	// newItemData is itemData with fields required to add an item, they are convertible to each other.
+	newItemData struct {
+		Name              string      `json:"name" validate:"required"`
+		DateAdded         string      `json:"date_added" validate:"required,rfc3339"`
+		BestBefore        string      `json:"best_before" validate:"required,rfc3339"`
+		IsOpened          bool        `json:"is_opened"`
+		HoursAfterOpening int         `json:"hours_after_opening" validate:"min=0"`
+		Note              string      `json:"note"`
+		StorageID         pgtype.UUID `json:"storage_id" validate:"required"`
+	}
 	copyData struct {
-		OriginalID pgtype.UUID `json:"original_id"`
-		DateAdded  string      `json:"date_added"`
+		OriginalID pgtype.UUID `json:"original_id" validate:"required"`
+		DateAdded  string      `json:"date_added" validate:"required,rfc3339"`
 	}
 	storageData struct {
-		Name string `json:"name"`
+		Name string `json:"name" validate:"required"`
 	}
 	apnsDeviceToken struct {
-		Token string `json:"token"`
+		Token string `json:"token" validate:"required"`
 	}
 )
 
 func (d itemData) toValidItem() (item.Item, error) {
-	if d.isMissingRequiredField() {
-		return item.Item{}, ErrMissingRequiredField
-	}
-
 	bestBefore, err := time.Parse(time.RFC3339, d.BestBefore)
 	if err != nil {
 		return item.Item{}, InvalidTimeFormatError(d.BestBefore)
@@ -49,12 +55,8 @@ func (d itemData) toValidItem() (item.Item, error) {
 	}, nil
 }
 
-func (d itemData) toValidItemWithAddedDateRequired() (item.Item, error) {
-	if d.DateAdded == "" {
-		return item.Item{}, ErrMissingRequiredField
-	}
-
-	parsedItem, err := d.toValidItem()
+func (d newItemData) toValidItem() (item.Item, error) {
+	parsedItem, err := itemData(d).toValidItem()
 	if err != nil {
 		return item.Item{}, err
 	}
@@ -68,23 +70,7 @@ func (d itemData) toValidItemWithAddedDateRequired() (item.Item, error) {
 	return parsedItem, nil
 }
 
-func (d itemData) isMissingRequiredField() bool {
-	return d.Name == "" || d.BestBefore == ""
-}
-
-func (d itemData) checkIfStorageIDValid() error {
-	return checkIsUUIDValid(d.StorageID)
-}
-
 func (d copyData) toValidCopyData() (item.ToCopy, error) {
-	if d.isMissingRequiredField() {
-		return item.ToCopy{}, ErrMissingRequiredField
-	}
-
-	if err := d.checkIfOriginalIDValid(); err != nil {
-		return item.ToCopy{}, err
-	}
-
 	dateAdded, err := time.Parse(time.RFC3339, d.DateAdded)
 	if err != nil {
 		return item.ToCopy{}, InvalidTimeFormatError(d.DateAdded)
@@ -95,31 +81,3 @@ func (d copyData) toValidCopyData() (item.ToCopy, error) {
 		DateAdded:  dateAdded.UTC(),
 	}, nil
 }
-
-func (d copyData) isMissingRequiredField() bool {
-	return d.DateAdded == ""
-}
-
-func (d copyData) checkIfOriginalIDValid() error {
-	return checkIsUUIDValid(d.OriginalID)
-}
-
-func (d storageData) isMissingRequiredField() bool {
-	return d.Name == ""
-}
-
-func checkIsUUIDValid(uuid pgtype.UUID) error {
-	if uuid == (pgtype.UUID{}) {
-		return ErrMissingRequiredField
-	}
-
-	if !uuid.Valid {
-		return ErrInvalidUUIDInBody
-	}
-
-	return nil
-}
-
-func (t apnsDeviceToken) isMissing() bool {
-	return t.Token == ""
-}
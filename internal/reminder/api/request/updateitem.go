@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/zhuboris/never-expires/internal/reminder/api/endpoint"
+	"github.com/zhuboris/never-expires/internal/shared/etag"
 	"github.com/zhuboris/never-expires/internal/shared/reqbody"
 
 	"github.com/zhuboris/never-expires/internal/shared/rwjson"
@@ -36,11 +37,18 @@ func (req UpdateItemRequest) Handle(w http.ResponseWriter, r *http.Request) erro
 		return err
 	}
 
+	version, err := etag.IfMatchVersion(r)
+	if err != nil {
+		return err
+	}
+
 	itemFromBody.ID = id
+	itemFromBody.Version = version
 	updatedItem, err := req.items.Update(r.Context(), itemFromBody)
 	if err != nil {
 		return err
 	}
 
+	etag.Set(w, etag.FromVersion(updatedItem.Version))
 	return rwjson.WriteJSON(w, http.StatusOK, updatedItem.ToResponseFormat())
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/zhuboris/never-expires/internal/reminder/api/endpoint"
 	"github.com/zhuboris/never-expires/internal/reminder/storage"
+	"github.com/zhuboris/never-expires/internal/shared/etag"
 	"github.com/zhuboris/never-expires/internal/shared/reqbody"
 	"github.com/zhuboris/never-expires/internal/shared/rwjson"
 )
@@ -32,6 +33,7 @@ func (req UpdateStorageRequest) Handle(w http.ResponseWriter, r *http.Request) e
 		return err
 	}
 
+	etag.Set(w, etag.FromVersion(result.Version))
 	return rwjson.WriteJSON(w, http.StatusOK, result)
 }
 
@@ -46,9 +48,15 @@ func (req UpdateStorageRequest) updatedStorage(r *http.Request) (storage.Storage
 		return storage.Storage{}, err
 	}
 
+	version, err := etag.IfMatchVersion(r)
+	if err != nil {
+		return storage.Storage{}, err
+	}
+
 	return storage.Storage{
-		ID:   id,
-		Name: body.Name,
+		ID:      id,
+		Name:    body.Name,
+		Version: version,
 	}, nil
 }
 
@@ -58,9 +66,5 @@ func (req UpdateStorageRequest) decodedBody(rawBody io.ReadCloser) (*storageData
 		return nil, errors.Join(ErrInvalidBody, err)
 	}
 
-	if body.isMissingRequiredField() {
-		return nil, ErrMissingRequiredField
-	}
-
 	return body, nil
 }
@@ -74,6 +74,76 @@ func (_c *MockmetricsExporter_NewAttemptsCounter_Call) RunAndReturn(run func(str
 	return _c
 }
 
+// NewLabeledCounter provides a mock function with given fields: name, help, labels
+func (_m *MockmetricsExporter) NewLabeledCounter(name string, help string, labels ...string) (*prometheusexporter.LabeledCounter, error) {
+	_va := make([]interface{}, len(labels))
+	for _i := range labels {
+		_va[_i] = labels[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, name, help)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *prometheusexporter.LabeledCounter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, ...string) (*prometheusexporter.LabeledCounter, error)); ok {
+		return rf(name, help, labels...)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, ...string) *prometheusexporter.LabeledCounter); ok {
+		r0 = rf(name, help, labels...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*prometheusexporter.LabeledCounter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, ...string) error); ok {
+		r1 = rf(name, help, labels...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockmetricsExporter_NewLabeledCounter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewLabeledCounter'
+type MockmetricsExporter_NewLabeledCounter_Call struct {
+	*mock.Call
+}
+
+// NewLabeledCounter is a helper method to define mock.On call
+//   - name string
+//   - help string
+//   - labels ...string
+func (_e *MockmetricsExporter_Expecter) NewLabeledCounter(name interface{}, help interface{}, labels ...interface{}) *MockmetricsExporter_NewLabeledCounter_Call {
+	return &MockmetricsExporter_NewLabeledCounter_Call{Call: _e.mock.On("NewLabeledCounter",
+		append([]interface{}{name, help}, labels...)...)}
+}
+
+func (_c *MockmetricsExporter_NewLabeledCounter_Call) Run(run func(name string, help string, labels ...string)) *MockmetricsExporter_NewLabeledCounter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(string), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockmetricsExporter_NewLabeledCounter_Call) Return(_a0 *prometheusexporter.LabeledCounter, _a1 error) *MockmetricsExporter_NewLabeledCounter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockmetricsExporter_NewLabeledCounter_Call) RunAndReturn(run func(string, string, ...string) (*prometheusexporter.LabeledCounter, error)) *MockmetricsExporter_NewLabeledCounter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewTimeRecorder provides a mock function with given fields: name
 func (_m *MockmetricsExporter) NewTimeRecorder(name string) (*prometheusexporter.TimeRecorded, error) {
 	ret := _m.Called(name)
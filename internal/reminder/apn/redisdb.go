@@ -2,8 +2,6 @@ package apn
 
 import (
 	"context"
-	"fmt"
-	"os"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -12,47 +10,22 @@ type RedisDB struct {
 	client *redis.Client
 }
 
-func NewRedisDB() (*RedisDB, error) {
-	options, err := redisOptions()
-	if err != nil {
-		return nil, err
-	}
-
-	return &RedisDB{
-		client: redis.NewClient(options),
-	}, nil
+type RedisConfig struct {
+	Address  string `env:"REDIS_ADDR" required:"true"`
+	Username string `env:"REDIS_USERNAME"`
+	Password string `env:"REDIS_PASSWORD" secret:"true"`
 }
 
-func redisOptions() (*redis.Options, error) {
-	const (
-		addrEnvKey     = "REDIS_ADDR"
-		usernameEnvKey = "REDIS_USERNAME"
-		passwordEnvKey = "REDIS_PASSWORD"
-	)
-
-	const errFormat = "key %q is missing in envs"
-
-	address := os.Getenv(addrEnvKey)
-	if address == "" {
-		return nil, fmt.Errorf(errFormat, addrEnvKey)
-	}
-
-	username := os.Getenv(usernameEnvKey)
-	if address == "" {
-		return nil, fmt.Errorf(errFormat, usernameEnvKey)
-	}
-
-	password := os.Getenv(passwordEnvKey)
-	if address == "" {
-		return nil, fmt.Errorf(errFormat, passwordEnvKey)
+func NewRedisDB(config RedisConfig) *RedisDB {
+	options := &redis.Options{
+		Addr:     config.Address,
+		Username: config.Username,
+		Password: config.Password,
 	}
 
-	options := &redis.Options{
-		Addr:     address,
-		Username: username,
-		Password: password,
+	return &RedisDB{
+		client: redis.NewClient(options),
 	}
-	return options, nil
 }
 
 const badTokensRepoKey = "apns_bad_token"
@@ -68,3 +41,9 @@ func (r RedisDB) popBadTokens(ctx context.Context, limit int64) ([]string, error
 		SPopN(ctx, badTokensRepoKey, limit).
 		Result()
 }
+
+func (r RedisDB) Ping(ctx context.Context) error {
+	return r.client.
+		Ping(ctx).
+		Err()
+}
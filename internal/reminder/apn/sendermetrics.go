@@ -1,6 +1,9 @@
 package apn
 
 import (
+	"strconv"
+
+	"github.com/sideshow/apns2"
 	"go.uber.org/zap"
 
 	"github.com/zhuboris/never-expires/internal/shared/prometheusexporter"
@@ -15,12 +18,16 @@ type (
 	metricsExporter interface {
 		NewTimeRecorder(name string) (*prometheusexporter.TimeRecorded, error)
 		NewAttemptsCounter(entityName string) (*prometheusexporter.AttemptsCounter, error)
+		NewLabeledCounter(name, help string, labels ...string) (*prometheusexporter.LabeledCounter, error)
 		WriteToFile(path string) error
 	}
 	sendingCounter interface {
 		IncrementSuccess()
 		IncrementFail()
 	}
+	responsesCounter interface {
+		Increment(labelValues ...string)
+	}
 )
 
 func (s *SenderService) saveMetricsToFile() {
@@ -51,3 +58,24 @@ func (s *SenderService) incrementCounter(isSuccess bool) {
 
 	s.counter.IncrementFail()
 }
+
+// countResponse counts responses by reason, so it is visible why pushes are rejected, requests without response are counted as failed.
+func (s *SenderService) countResponse(resp *apns2.Response) {
+	const (
+		noResponseCode   = "n/a"
+		noResponseReason = "RequestFailed"
+		successReason    = "Success"
+	)
+
+	if resp == nil {
+		s.responsesCounter.Increment(noResponseCode, noResponseReason)
+		return
+	}
+
+	reason := resp.Reason
+	if reason == "" && resp.Sent() {
+		reason = successReason
+	}
+
+	s.responsesCounter.Increment(strconv.Itoa(resp.StatusCode), reason)
+}
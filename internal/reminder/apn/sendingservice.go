@@ -9,10 +9,14 @@ import (
 
 	"github.com/sideshow/apns2"
 	"github.com/sideshow/apns2/token"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
 	"github.com/zhuboris/never-expires/internal/shared/appleconfig"
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
 )
 
 const (
@@ -26,6 +30,8 @@ const (
 
 const deviceTokenLogKey = "deviceToken"
 
+const apnsReasonAttributeKey = attribute.Key("apns.reason")
+
 type (
 	notificationDataRepo interface {
 		notifications(ctx context.Context, dataCh chan<- notificationData) error
@@ -43,14 +49,10 @@ type SenderService struct {
 	logger             *zap.Logger
 	exporter           metricsExporter
 	counter            sendingCounter
+	responsesCounter   responsesCounter
 }
 
-func NewSenderService(notificationsRepo notificationDataRepo, inactiveTokensRepo badTokensSavingRepo, logger *zap.Logger, exporter metricsExporter) (*SenderService, error) {
-	config, err := appleconfig.New()
-	if err != nil {
-		return nil, err
-	}
-
+func NewSenderService(config appleconfig.Config, notificationsRepo notificationDataRepo, inactiveTokensRepo badTokensSavingRepo, logger *zap.Logger, exporter metricsExporter) (*SenderService, error) {
 	client, err := makeClient(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create apn client: %w", err)
@@ -61,14 +63,20 @@ func NewSenderService(notificationsRepo notificationDataRepo, inactiveTokensRepo
 		return nil, err
 	}
 
+	responses, err := exporter.NewLabeledCounter("apns_responses_total", "Total number of APNs responses by status code and reason", "code", "reason")
+	if err != nil {
+		return nil, err
+	}
+
 	return &SenderService{
-		bundleID:           config.ClientID(),
+		bundleID:           config.BundleID,
 		notificationsRepo:  notificationsRepo,
 		inactiveTokensRepo: inactiveTokensRepo,
 		logger:             logger,
 		client:             client,
 		exporter:           exporter,
 		counter:            counter,
+		responsesCounter:   responses,
 	}, nil
 }
 
@@ -80,6 +88,9 @@ func (s *SenderService) RunWithCtx(ctx context.Context) error {
 
 	defer s.recordTimeMetric(finishTimeName)
 
+	ctx, span := tracing.Start(ctx, "apns notify all")
+	defer span.End()
+
 	dataCh := make(chan notificationData)
 	go func(ctx context.Context) {
 		err := s.notificationsRepo.notifications(ctx, dataCh)
@@ -124,12 +135,13 @@ func (s *SenderService) runWorker(ctx context.Context, dataCh <-chan notificatio
 	for {
 		select {
 		case data, isOpened := <-dataCh:
-			if !isOpened {
+			if !isOpened || ctx.Err() != nil {
 				return
 			}
 
-			ctx, cancel := context.WithTimeout(ctx, timeoutValue)
-			s.notify(ctx, data)
+			// push that is started is finished on shutdown, its timeout is less than the drain timeout
+			pushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeoutValue)
+			s.notify(pushCtx, data)
 			cancel()
 		case <-ctx.Done():
 			return
@@ -151,7 +163,9 @@ func (s *SenderService) notify(ctx context.Context, data notificationData) {
 		}
 	)
 
+	ctx, span := tracing.Start(ctx, "apns push", trace.WithSpanKind(trace.SpanKindClient))
 	resp, err := s.client.PushWithContext(ctx, notification)
+	endPushSpan(span, err, resp)
 
 	isSuccess := isSendWithSuccess(err, resp)
 	if !isSuccess && isTokenInactive(resp) {
@@ -159,6 +173,7 @@ func (s *SenderService) notify(ctx context.Context, data notificationData) {
 	}
 
 	s.incrementCounter(isSuccess)
+	s.countResponse(resp)
 	s.logResponse(err, resp, data.DeviceToken)
 }
 
@@ -200,7 +215,7 @@ func makeClient(config appleconfig.Config) (*apns2.Client, error) {
 }
 
 func tokenFromConfig(config appleconfig.Config) (*token.Token, error) {
-	keyBytes := []byte(config.PrivateKey())
+	keyBytes := []byte(config.PrivateKey)
 	authKey, err := token.AuthKeyFromBytes(keyBytes)
 	if err != nil {
 		return nil, err
@@ -208,13 +223,25 @@ func tokenFromConfig(config appleconfig.Config) (*token.Token, error) {
 
 	authToken := &token.Token{
 		AuthKey: authKey,
-		KeyID:   config.KeyID(),
-		TeamID:  config.TeamID(),
+		KeyID:   config.KeyID,
+		TeamID:  config.TeamID,
 	}
 
 	return authToken, nil
 }
 
+func endPushSpan(span trace.Span, err error, resp *apns2.Response) {
+	if resp != nil {
+		span.SetAttributes(semconv.HTTPStatusCode(resp.StatusCode), apnsReasonAttributeKey.String(resp.Reason))
+	}
+
+	if err == nil && resp != nil && !resp.Sent() {
+		err = fmt.Errorf("push rejected: %s", resp.Reason)
+	}
+
+	tracing.End(span, err)
+}
+
 func isSendWithSuccess(err error, resp *apns2.Response) bool {
 	return err == nil && resp.Sent()
 }
@@ -2,20 +2,14 @@ package reminder
 
 import "github.com/zhuboris/never-expires/internal/shared/postgresql"
 
-func DBConfig() (postgresql.Config, error) {
-	const (
-		dbUsernameKey = "REMINDER_POSTGRESQL_USER"
-		dbPasswordKey = "REMINDER_POSTGRESQL_PASSWORD"
-		dbHostKey     = "REMINDER_POSTGRESQL_HOST"
-		dbPortKey     = "REMINDER_POSTGRESQL_PORT"
-		dbNamedKey    = "REMINDER_POSTGRESQL_DB"
-	)
+type DBConfig struct {
+	Username string `env:"REMINDER_POSTGRESQL_USER" required:"true"`
+	Password string `env:"REMINDER_POSTGRESQL_PASSWORD" required:"true" secret:"true"`
+	Host     string `env:"REMINDER_POSTGRESQL_HOST" required:"true"`
+	Port     int    `env:"REMINDER_POSTGRESQL_PORT" required:"true"`
+	DBName   string `env:"REMINDER_POSTGRESQL_DB" required:"true"`
+}
 
-	return postgresql.NewConfig(postgresql.ConfigEnvsAddress{
-		UsernameKey: dbUsernameKey,
-		PasswordKey: dbPasswordKey,
-		HostKey:     dbHostKey,
-		PortKey:     dbPortKey,
-		DBNameKey:   dbNamedKey,
-	})
+func (c DBConfig) Postgres() postgresql.Config {
+	return postgresql.Config(c)
 }
@@ -16,6 +16,8 @@ type (
 		HoursAfterOpening int         `json:"hours_after_opening"`
 		DateAdded         time.Time   `json:"date_added"`
 		Note              string      `json:"note"`
+		// Version is increased on every update, it is sent in ETag header instead of the body.
+		Version int `json:"-"`
 	}
 	ResponseItem struct {
 		ID                pgtype.UUID `json:"id"`
@@ -83,6 +85,7 @@ type Entity struct {
 	HoursAfterOpening *int         `json:"hours_after_opening"`
 	DateAdded         *time.Time   `json:"date_added"`
 	Note              *string      `json:"note"`
+	Version           *int         `json:"-"`
 }
 
 func newFromItem(item Item) *Entity {
@@ -95,6 +98,7 @@ func newFromItem(item Item) *Entity {
 		HoursAfterOpening: &item.HoursAfterOpening,
 		DateAdded:         &item.DateAdded,
 		Note:              &item.Note,
+		Version:           &item.Version,
 	}
 }
 
@@ -131,6 +135,10 @@ func (e Entity) item() *Item {
 		item.Note = *e.Note
 	}
 
+	if e.Version != nil {
+		item.Version = *e.Version
+	}
+
 	return &item
 }
 
@@ -37,7 +37,8 @@ func (r PostgresqlRepository) byID(ctx context.Context, userID, id pgtype.UUID)
 			expiration_date,
 			hours_after_opening,
 			added_date,
-			note
+			note,
+			version
 		FROM items_info
 		WHERE id = $2
 		AND id IN (SELECT id FROM users_items);
@@ -45,7 +46,7 @@ func (r PostgresqlRepository) byID(ctx context.Context, userID, id pgtype.UUID)
 
 	item := new(Item)
 	err := r.pool.QueryRow(ctx, sql, userID, id).
-		Scan(&item.Name, &item.IsOpened, &item.BestBefore, &item.ExpirationDate, &item.HoursAfterOpening, &item.DateAdded, &item.Note)
+		Scan(&item.Name, &item.IsOpened, &item.BestBefore, &item.ExpirationDate, &item.HoursAfterOpening, &item.DateAdded, &item.Note, &item.Version)
 	return item, err
 }
 
@@ -59,7 +60,8 @@ func (r PostgresqlRepository) all(ctx context.Context, userID pgtype.UUID, filte
 			ii.expiration_date,
 			ii.hours_after_opening,
 			ii.added_date,
-			ii.note
+			ii.note,
+			ii.version
 		FROM items_info ii
 		LEFT JOIN items i on i.id = ii.id
 		WHERE i.storage_id IN (SELECT id FROM storages WHERE owner_id = $1)
@@ -83,7 +85,7 @@ func (r PostgresqlRepository) all(ctx context.Context, userID pgtype.UUID, filte
 	items := make(Items, 0)
 	for rows.Next() {
 		var item Item
-		err := rows.Scan(&item.ID, &item.Name, &item.IsOpened, &item.BestBefore, &item.ExpirationDate, &item.HoursAfterOpening, &item.DateAdded, &item.Note)
+		err := rows.Scan(&item.ID, &item.Name, &item.IsOpened, &item.BestBefore, &item.ExpirationDate, &item.HoursAfterOpening, &item.DateAdded, &item.Note, &item.Version)
 		if err != nil {
 			return nil, err
 		}
@@ -119,19 +121,20 @@ func (r PostgresqlRepository) add(ctx context.Context, userID, storageID pgtype.
 		    INSERT INTO items_info (id, name, is_opened, added_date, best_before, hours_after_opening, note)
 			SELECT id, $1, $5, $6, $7, $8, $9
 			FROM new_item
-		    RETURNING id, name, is_opened, best_before, expiration_date, hours_after_opening, added_date, note
+		    RETURNING id, name, is_opened, best_before, expiration_date, hours_after_opening, added_date, note, version
 		)
 		SELECT  
 		    EXISTS(SELECT 1 FROM existing_storage) AS storage_exists,
 		    EXISTS(SELECT 1 FROM new_item) AS is_added,
 		    (SELECT id FROM inserted_item),
 		  	(SELECT expiration_date FROM inserted_item),
-		    (SELECT added_date FROM inserted_item);
+		    (SELECT added_date FROM inserted_item),
+		    (SELECT version FROM inserted_item);
 	`
 
 	scannedItem := newFromItem(toAdd)
 	err = r.pool.QueryRow(ctx, sql, toAdd.Name, userID, storageID, toAdd.ID, toAdd.IsOpened, toAdd.DateAdded, toAdd.BestBefore, toAdd.HoursAfterOpening, toAdd.Note).
-		Scan(&isStorageExist, &isAdded, &scannedItem.ID, &scannedItem.ExpirationDate, &scannedItem.DateAdded)
+		Scan(&isStorageExist, &isAdded, &scannedItem.ID, &scannedItem.ExpirationDate, &scannedItem.DateAdded, &scannedItem.Version)
 	return isStorageExist, isAdded, scannedItem.item(), err
 }
 
@@ -149,9 +152,11 @@ func (r PostgresqlRepository) update(ctx context.Context, userID pgtype.UUID, it
 		        best_before = $3,
 			    expiration_date = $4,
 			    hours_after_opening = $5,
-		        note = $6
+		        note = $6,
+		        version = version + 1
 		    WHERE id IN (SELECT id FROM users_items)
 			AND id = $7
+			AND version = $8
 
 		    RETURNING 1
 		)
@@ -159,7 +164,7 @@ func (r PostgresqlRepository) update(ctx context.Context, userID pgtype.UUID, it
 	`
 
 	var isUpdated bool
-	err := r.pool.QueryRow(ctx, sql, userID, item.IsOpened, item.BestBefore, item.ExpirationDate, item.HoursAfterOpening, item.Note, item.ID).
+	err := r.pool.QueryRow(ctx, sql, userID, item.IsOpened, item.BestBefore, item.ExpirationDate, item.HoursAfterOpening, item.Note, item.ID, item.Version).
 		Scan(&isUpdated)
 
 	return isUpdated, err
@@ -210,7 +215,7 @@ func (r PostgresqlRepository) copy(ctx context.Context, userID pgtype.UUID, toCo
 		    SELECT ni.id AS new_id, name, is_opened, $4, best_before, expiration_date, hours_after_opening, note
 		    FROM existing_item AS ei, new_item AS ni
 			
-			RETURNING id, name, is_opened, best_before, expiration_date, hours_after_opening, added_date, note
+			RETURNING id, name, is_opened, best_before, expiration_date, hours_after_opening, added_date, note, version
 		)
 		SELECT 
 		    EXISTS(SELECT 1 FROM existing_item) AS storage_exists,
@@ -222,7 +227,8 @@ func (r PostgresqlRepository) copy(ctx context.Context, userID pgtype.UUID, toCo
 		    (SELECT expiration_date FROM inserted_item),
 		    (SELECT hours_after_opening FROM inserted_item),
 		    (SELECT added_date FROM inserted_item),
-		    (SELECT note FROM inserted_item);
+		    (SELECT note FROM inserted_item),
+		    (SELECT version FROM inserted_item);
 	`
 
 	scannedItem := new(Entity)
@@ -238,6 +244,7 @@ func (r PostgresqlRepository) copy(ctx context.Context, userID pgtype.UUID, toCo
 			&scannedItem.HoursAfterOpening,
 			&scannedItem.DateAdded,
 			&scannedItem.Note,
+			&scannedItem.Version,
 		)
 	return isItemExistExist, isCopied, scannedItem.item(), err
 }
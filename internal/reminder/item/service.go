@@ -91,6 +91,8 @@ func (s Service) Add(ctx context.Context, storageID pgtype.UUID, toAdd Item) (*I
 	return newItem, nil
 }
 
+// Update overwrites the item only if updatedItem.Version is equal to the saved one, zero version overwrites any.
+// Changes made by other requests between reading and writing are not overwritten too.
 func (s Service) Update(ctx context.Context, updatedItem Item) (*Item, error) {
 	userID, err := s.usrID.Decode(ctx)
 	if err != nil {
@@ -106,15 +108,28 @@ func (s Service) Update(ctx context.Context, updatedItem Item) (*Item, error) {
 		return nil, err
 	}
 
+	if updatedItem.Version != 0 && updatedItem.Version != oldItem.Version {
+		return nil, queryerr.ErrVersionMismatch
+	}
+
 	if updatedItem.isEqual(oldItem) {
 		return oldItem, nil
 	}
 
 	updatedItem.updateExpirationDate(*oldItem)
 	updatedItem.DateAdded = oldItem.DateAdded
-	_, err = s.repo.update(ctx, userID, updatedItem)
+	updatedItem.Version = oldItem.Version
+	isUpdated, err := s.repo.update(ctx, userID, updatedItem)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isUpdated {
+		return nil, queryerr.ErrVersionMismatch
+	}
 
-	return &updatedItem, err
+	updatedItem.Version++
+	return &updatedItem, nil
 }
 
 func (s Service) Delete(ctx context.Context, itemID pgtype.UUID) error {
@@ -632,6 +632,10 @@ func TestService_Update(t *testing.T) {
 			Bytes: [16]byte{3},
 			Valid: true,
 		}
+		existingIDOfConcurrentlyChangedItem = pgtype.UUID{
+			Bytes: [16]byte{4},
+			Valid: true,
+		}
 
 		bestBefore        = time.Now().Add(48 * time.Hour)
 		updatedBestBefore = time.Now().Add(96 * time.Hour)
@@ -645,6 +649,7 @@ func TestService_Update(t *testing.T) {
 			HoursAfterOpening: 10,
 			DateAdded:         time.Now(),
 			Note:              "note",
+			Version:           3,
 		}
 		openedItemInDB = Item{
 			ID:                existingIDOfOpenedItem,
@@ -661,7 +666,9 @@ func TestService_Update(t *testing.T) {
 	repoMock := NewMockrepository(t)
 	repoMock.EXPECT().
 		update(mock.Anything, mock.Anything, mock.Anything).
-		Return(true, nil).Maybe()
+		RunAndReturn(func(ctx context.Context, userID pgtype.UUID, item Item) (bool, error) {
+			return item.ID != existingIDOfConcurrentlyChangedItem, nil
+		}).Maybe()
 	repoMock.EXPECT().
 		byID(mock.Anything, mock.Anything, mock.Anything).
 		RunAndReturn(func(ctx context.Context, userID pgtype.UUID, itemID pgtype.UUID) (*Item, error) {
@@ -674,6 +681,10 @@ func TestService_Update(t *testing.T) {
 				return &closedItemInDB, nil
 			case existingIDOfOpenedItem:
 				return &openedItemInDB, nil
+			case existingIDOfConcurrentlyChangedItem:
+				changedItem := closedItemInDB
+				changedItem.ID = existingIDOfConcurrentlyChangedItem
+				return &changedItem, nil
 			default:
 				return nil, pgx.ErrNoRows
 			}
@@ -705,6 +716,20 @@ func TestService_Update(t *testing.T) {
 			requireError:  require.Error,
 			expectedError: ErrItemNotExists,
 		},
+		{
+			name:          "item version not matched",
+			idDecoderMock: newDecoderOfValidID(t),
+			updatedItem:   Item{ID: existingIDOfClosedItem, Name: "newName", Version: 2},
+			requireError:  require.Error,
+			expectedError: queryerr.ErrVersionMismatch,
+		},
+		{
+			name:          "item changed between reading and writing",
+			idDecoderMock: newDecoderOfValidID(t),
+			updatedItem:   Item{ID: existingIDOfConcurrentlyChangedItem, Name: "newName"},
+			requireError:  require.Error,
+			expectedError: queryerr.ErrVersionMismatch,
+		},
 		{
 			name:          "item not changed",
 			idDecoderMock: newDecoderOfValidID(t),
@@ -731,6 +756,7 @@ func TestService_Update(t *testing.T) {
 				HoursAfterOpening: openedItemInDB.HoursAfterOpening,
 				DateAdded:         openedItemInDB.DateAdded,
 				Note:              openedItemInDB.Note,
+				Version:           closedItemInDB.Version + 1,
 			},
 			idDecoderMock: newDecoderOfValidID(t),
 			requireError:  require.NoError,
@@ -755,6 +781,7 @@ func TestService_Update(t *testing.T) {
 				HoursAfterOpening: closedItemInDB.HoursAfterOpening,
 				DateAdded:         closedItemInDB.DateAdded,
 				Note:              closedItemInDB.Note,
+				Version:           openedItemInDB.Version + 1,
 			},
 			idDecoderMock: newDecoderOfValidID(t),
 			requireError:  require.NoError,
@@ -778,6 +805,7 @@ func TestService_Update(t *testing.T) {
 				HoursAfterOpening: closedItemInDB.HoursAfterOpening,
 				DateAdded:         closedItemInDB.DateAdded,
 				Note:              closedItemInDB.Note,
+				Version:           closedItemInDB.Version + 1,
 			},
 			idDecoderMock: newDecoderOfValidID(t),
 			requireError:  require.NoError,
@@ -791,6 +819,7 @@ func TestService_Update(t *testing.T) {
 				BestBefore:        closedItemInDB.BestBefore,
 				HoursAfterOpening: closedItemInDB.HoursAfterOpening,
 				Note:              "",
+				Version:           closedItemInDB.Version,
 			},
 			wantItem: &Item{
 				ID:                closedItemInDB.ID,
@@ -801,6 +830,7 @@ func TestService_Update(t *testing.T) {
 				HoursAfterOpening: closedItemInDB.HoursAfterOpening,
 				DateAdded:         closedItemInDB.DateAdded,
 				Note:              "",
+				Version:           closedItemInDB.Version + 1,
 			},
 			idDecoderMock: newDecoderOfValidID(t),
 			requireError:  require.NoError,
@@ -830,6 +860,10 @@ func TestService_Update(t *testing.T) {
 			result, err := service.Update(context.Background(), tt.updatedItem)
 
 			tt.requireError(t, err)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			}
+
 			if err == nil {
 				assertItemsEqual(t, *tt.wantItem, *result)
 			}
@@ -879,6 +913,7 @@ func assertItemsEqual(t *testing.T, first, second Item) {
 	assert.Equal(t, first.Note, second.Note)
 	assert.Equal(t, first.IsOpened, second.IsOpened)
 	assert.Equal(t, first.HoursAfterOpening, second.HoursAfterOpening)
+	assert.Equal(t, first.Version, second.Version)
 	assertTimeHaveSameDate(t, first.BestBefore, second.BestBefore)
 	assertTimeHaveSameDate(t, first.ExpirationDate, second.ExpirationDate)
 	assertTimeHaveSameDate(t, first.DateAdded, second.DateAdded)
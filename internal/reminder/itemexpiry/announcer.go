@@ -0,0 +1,88 @@
+package itemexpiry
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/zhuboris/never-expires/internal/shared/eventbus"
+)
+
+const (
+	checkInterval = 10 * time.Minute
+	batchSize     = 100
+)
+
+type (
+	repository interface {
+		expiredItems(ctx context.Context, limit int) ([]eventbus.ItemExpired, error)
+		markAnnounced(ctx context.Context, item eventbus.ItemExpired) error
+	}
+	eventPublisher interface {
+		PublishWithID(ctx context.Context, id string, event eventbus.Event) error
+	}
+)
+
+type Announcer struct {
+	repo   repository
+	events eventPublisher
+	logger *zap.Logger
+}
+
+func New(repo repository, events eventPublisher, logger *zap.Logger) *Announcer {
+	return &Announcer{
+		repo:   repo,
+		events: events,
+		logger: logger,
+	}
+}
+
+func (a *Announcer) RunWithCtx(ctx context.Context) error {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.announceExpired(ctx); err != nil {
+			a.logger.Error("Failed to announce expired items", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// announceExpired stops on the first failure, the rest of items are announced on the next check.
+// Items are marked announced only after the broker accepted their events.
+func (a *Announcer) announceExpired(ctx context.Context) error {
+	for {
+		items, err := a.repo.expiredItems(ctx, batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if err := a.events.PublishWithID(ctx, eventID(item), item); err != nil {
+				return err
+			}
+
+			if err := a.repo.markAnnounced(ctx, item); err != nil {
+				return err
+			}
+		}
+
+		if len(items) < batchSize {
+			return nil
+		}
+	}
+}
+
+// eventID is the same for every attempt to announce the expiration, so the event published again after failed marking is skipped by subscribers.
+func eventID(item eventbus.ItemExpired) string {
+	key := eventbus.ItemExpiredType + ":" + item.ItemID + ":" + item.ExpirationDate.UTC().Format(time.RFC3339)
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(key)).String()
+}
@@ -0,0 +1,85 @@
+package itemexpiry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+
+	"github.com/zhuboris/never-expires/internal/shared/eventbus"
+)
+
+func TestAnnouncer_announceExpired(t *testing.T) {
+	var (
+		errPublish = errors.New("broker is down")
+		first      = eventbus.ItemExpired{ItemID: "first"}
+		second     = eventbus.ItemExpired{ItemID: "second"}
+	)
+
+	tests := []struct {
+		name          string
+		batches       [][]eventbus.ItemExpired
+		publishErr    error
+		wantAnnounced []eventbus.ItemExpired
+		wantErr       error
+	}{
+		{
+			name:          "all expired items are announced",
+			batches:       [][]eventbus.ItemExpired{{first, second}},
+			wantAnnounced: []eventbus.ItemExpired{first, second},
+		},
+		{
+			name:          "full batch is followed by the next one",
+			batches:       [][]eventbus.ItemExpired{fullBatch(), {first}},
+			wantAnnounced: append(fullBatch(), first),
+		},
+		{
+			name:       "item is not marked announced when the broker did not accept its event",
+			batches:    [][]eventbus.ItemExpired{{first}},
+			publishErr: errPublish,
+			wantErr:    errPublish,
+		},
+		{
+			name:       "publishing failure stops announcing",
+			batches:    [][]eventbus.ItemExpired{{first, second}},
+			publishErr: errPublish,
+			wantErr:    errPublish,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := NewMockrepository(t)
+			for _, batch := range tt.batches {
+				repo.EXPECT().expiredItems(ctx, batchSize).Return(batch, nil).Once()
+			}
+
+			events := NewMockeventPublisher(t)
+			if tt.publishErr != nil {
+				events.EXPECT().PublishWithID(ctx, mock.Anything, mock.Anything).Return(tt.publishErr).Once()
+			}
+
+			for _, item := range tt.wantAnnounced {
+				events.EXPECT().PublishWithID(ctx, eventID(item), item).Return(nil).Once()
+				repo.EXPECT().markAnnounced(ctx, item).Return(nil).Once()
+			}
+
+			err := New(repo, events, zap.NewNop()).announceExpired(ctx)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func fullBatch() []eventbus.ItemExpired {
+	items := make([]eventbus.ItemExpired, batchSize)
+	for i := range items {
+		items[i] = eventbus.ItemExpired{ItemID: fmt.Sprintf("item-%d", i)}
+	}
+
+	return items
+}
@@ -0,0 +1,71 @@
+package itemexpiry
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zhuboris/never-expires/internal/shared/eventbus"
+	"github.com/zhuboris/never-expires/internal/shared/postgresql"
+)
+
+type PostgresqlRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresqlRepository(pool *pgxpool.Pool) (*PostgresqlRepository, error) {
+	if pool == nil {
+		return nil, postgresql.ErrPoolInitRequired
+	}
+
+	return &PostgresqlRepository{
+		pool: pool,
+	}, nil
+}
+
+// expiredItems skips items expired long ago, so they are not announced at once after the first start.
+func (r PostgresqlRepository) expiredItems(ctx context.Context, limit int) ([]eventbus.ItemExpired, error) {
+	const announcingWindow = "7 days"
+
+	const sql = `
+		SELECT ii.id::TEXT, s.id::TEXT, s.owner_id::TEXT, ii.name, ii.expiration_date
+		FROM items_info ii
+		INNER JOIN items i ON i.id = ii.id
+		INNER JOIN storages s ON s.id = i.storage_id
+		LEFT JOIN announced_item_expirations a ON a.item_id = ii.id AND a.expiration_date = ii.expiration_date
+		WHERE ii.expiration_date BETWEEN (NOW() - $1::INTERVAL) AND NOW()
+		AND a.item_id IS NULL
+		ORDER BY ii.expiration_date
+		LIMIT $2;
+	`
+
+	rows, err := r.pool.Query(ctx, sql, announcingWindow, limit)
+	if err != nil {
+		return nil, postgresql.HandleQueryErr(err)
+	}
+
+	defer rows.Close()
+
+	var items []eventbus.ItemExpired
+	for rows.Next() {
+		var item eventbus.ItemExpired
+		if err := rows.Scan(&item.ItemID, &item.StorageID, &item.OwnerID, &item.Name, &item.ExpirationDate); err != nil {
+			return nil, postgresql.HandleQueryErr(err)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, postgresql.HandleQueryErr(rows.Err())
+}
+
+func (r PostgresqlRepository) markAnnounced(ctx context.Context, item eventbus.ItemExpired) error {
+	const sql = `
+		INSERT INTO announced_item_expirations (item_id, expiration_date)
+		VALUES ($1, $2)
+		ON CONFLICT (item_id) DO UPDATE SET expiration_date = EXCLUDED.expiration_date;
+	`
+
+	_, err := r.pool.Exec(ctx, sql, item.ItemID, item.ExpirationDate)
+	return postgresql.HandleQueryErr(err)
+}
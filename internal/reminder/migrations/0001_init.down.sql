@@ -0,0 +1,12 @@
+DROP TRIGGER IF EXISTS add_item_trigger ON items_info;
+DROP FUNCTION IF EXISTS set_expiration_date();
+
+DROP TABLE IF EXISTS ios_devices;
+DROP TABLE IF EXISTS processed_events;
+DROP TABLE IF EXISTS announced_item_expirations;
+DROP TABLE IF EXISTS items_info;
+DROP TABLE IF EXISTS items;
+DROP TABLE IF EXISTS users_default_storages;
+DROP TABLE IF EXISTS storages;
+DROP TABLE IF EXISTS private_types_of_items;
+DROP TABLE IF EXISTS shared_types_of_items;
@@ -9,8 +9,8 @@ CREATE TABLE IF NOT EXISTS private_types_of_items (
     user_id UUID NOT NULL
 );
 
-CREATE UNIQUE INDEX idx_lower_name_shared_types_of_items ON shared_types_of_items (LOWER(name));
-CREATE UNIQUE INDEX idx_lower_name_private_types_of_items ON private_types_of_items (LOWER(name), user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_lower_name_shared_types_of_items ON shared_types_of_items (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_lower_name_private_types_of_items ON private_types_of_items (LOWER(name), user_id);
 
 CREATE TABLE IF NOT EXISTS storages (
     id UUID UNIQUE NOT NULL DEFAULT gen_random_uuid(),
@@ -29,7 +29,7 @@ CREATE TABLE IF NOT EXISTS users_default_storages (
     CONSTRAINT storage_fk FOREIGN KEY (user_id, storage_id) REFERENCES storages(owner_id, id)
 );
 
-CREATE TABLE items (
+CREATE TABLE IF NOT EXISTS items (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     storage_id UUID NOT NULL,
 
@@ -49,7 +49,24 @@ CREATE TABLE IF NOT EXISTS items_info (
     CONSTRAINT id_fk FOREIGN KEY (id) REFERENCES items(id) ON DELETE CASCADE
 );
 
-CREATE INDEX idx_added_date ON items_info (added_date);
+CREATE INDEX IF NOT EXISTS idx_added_date ON items_info (added_date);
+
+CREATE TABLE IF NOT EXISTS announced_item_expirations (
+    item_id UUID PRIMARY KEY,
+    expiration_date TIMESTAMPTZ NOT NULL,
+
+    CONSTRAINT item_fk FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_expiration_date ON items_info (expiration_date);
+
+CREATE TABLE IF NOT EXISTS processed_events (
+    consumer VARCHAR(50) NOT NULL,
+    event_id UUID NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (consumer, event_id)
+);
 
 CREATE TABLE IF NOT EXISTS ios_devices (
     token TEXT PRIMARY KEY,
@@ -0,0 +1,933 @@
+INSERT INTO shared_types_of_items (name)
+VALUES
+    ('Mascarpone cheese'),
+    ('Turkish Delight'),
+    ('aioli'),
+    ('almonds'),
+    ('amaranth'),
+    ('anchovy'),
+    ('anise seed'),
+    ('apple sauce'),
+    ('apples'),
+    ('apricots'),
+    ('arugula'),
+    ('asparagus'),
+    ('avocado'),
+    ('bacon'),
+    ('baguette'),
+    ('banana bread'),
+    ('bananas'),
+    ('bar'),
+    ('barley'),
+    ('basil'),
+    ('beans'),
+    ('beans French'),
+    ('beans long'),
+    ('beef'),
+    ('beer'),
+    ('beer alcohol free'),
+    ('beetroot'),
+    ('bilberries'),
+    ('biscuit'),
+    ('biscuits salted'),
+    ('blackberries'),
+    ('blackcurrants'),
+    ('blancmange'),
+    ('blueberries'),
+    ('bologna'),
+    ('bouillon'),
+    ('bran'),
+    ('bread'),
+    ('bread brown'),
+    ('bread crumbs'),
+    ('bread rye'),
+    ('bread sticks'),
+    ('bread toasted'),
+    ('bread white'),
+    ('bread wholemeal'),
+    ('brioche'),
+    ('brisket'),
+    ('broccoli'),
+    ('brownie'),
+    ('brussels sprouts'),
+    ('buckwheat groats'),
+    ('bulgur'),
+    ('bun'),
+    ('burger'),
+    ('butter'),
+    ('buttermilk'),
+    ('cabbage'),
+    ('cabbage chinese'),
+    ('cabbage green'),
+    ('cabbage red'),
+    ('cabbage sauerkraut'),
+    ('cabbage white'),
+    ('cake'),
+    ('cake apple'),
+    ('cake chocolate'),
+    ('carambola'),
+    ('carbonara'),
+    ('carp'),
+    ('carrot'),
+    ('carrot cake'),
+    ('cashew'),
+    ('cauliflower'),
+    ('caviar'),
+    ('celeriac'),
+    ('celery'),
+    ('challah'),
+    ('chanterelles'),
+    ('chard'),
+    ('cheese'),
+    ('cheese Bel Paese'),
+    ('cheese Bluefort'),
+    ('cheese Brie'),
+    ('cheese Camembert'),
+    ('cheese Cheddar'),
+    ('cheese Edam'),
+    ('cheese Emmental'),
+    ('cheese Feta'),
+    ('cheese Gorgonzola'),
+    ('cheese Gouda'),
+    ('cheese Gruyere'),
+    ('cheese Limburger'),
+    ('cheese Mozzarella'),
+    ('cheese Parmesan'),
+    ('cheese Rambol'),
+    ('cheese Ricotta'),
+    ('cheese Roquefort'),
+    ('cheese Saint-Paulin'),
+    ('cheese Stilton'),
+    ('cheese Swiss'),
+    ('cheese cottage'),
+    ('cheese cream'),
+    ('cheese goat'),
+    ('cheese sauce'),
+    ('cheese sheep'),
+    ('cheese smoked'),
+    ('cheesecake'),
+    ('cherries'),
+    ('chervil'),
+    ('chestnuts'),
+    ('chia seeds'),
+    ('chicken'),
+    ('chicken sticks'),
+    ('chickpeas'),
+    ('chicory'),
+    ('chili pepper'),
+    ('chilli sauce'),
+    ('chives'),
+    ('chocolate'),
+    ('chocolate butter'),
+    ('chocolate dark'),
+    ('chocolate milk'),
+    ('chocolate white'),
+    ('churros'),
+    ('ciabatta'),
+    ('cinnamon'),
+    ('clams'),
+    ('cloves'),
+    ('coconut'),
+    ('coconut milk'),
+    ('coconut water'),
+    ('codfish'),
+    ('coffee'),
+    ('cola'),
+    ('cookies'),
+    ('cornbread'),
+    ('corned beef'),
+    ('cornflakes'),
+    ('cornmeal'),
+    ('cornstarch'),
+    ('cottage cheese'),
+    ('courgettes raw'),
+    ('couscous'),
+    ('cow-berries'),
+    ('crab'),
+    ('crackers'),
+    ('cranberries'),
+    ('cranberry'),
+    ('cream'),
+    ('cream sour'),
+    ('cream whipped'),
+    ('crispbreads'),
+    ('crisps'),
+    ('croissant'),
+    ('croutons'),
+    ('cucumber'),
+    ('cumin seed'),
+    ('cup cake'),
+    ('currants'),
+    ('curry sauce'),
+    ('cutlets'),
+    ('dates'),
+    ('dill'),
+    ('donut'),
+    ('dorado'),
+    ('dried fruits'),
+    ('duck'),
+    ('dumplings'),
+    ('eclair'),
+    ('eel'),
+    ('eggplant'),
+    ('eggs'),
+    ('endive'),
+    ('energy bar'),
+    ('energy drink'),
+    ('falafel'),
+    ('figs'),
+    ('fillet'),
+    ('fish fingers'),
+    ('flan'),
+    ('flour'),
+    ('flour buckwheat'),
+    ('flour cassava'),
+    ('flour rice'),
+    ('flour rye'),
+    ('flour soy'),
+    ('flour wheat'),
+    ('focaccia'),
+    ('foie gras'),
+    ('frankfurters'),
+    ('fritter'),
+    ('fruit juice'),
+    ('garlic'),
+    ('garlic sauce'),
+    ('gateau'),
+    ('gelatin'),
+    ('gherkins'),
+    ('ginger'),
+    ('ginger root'),
+    ('gingerbread'),
+    ('gooseberries'),
+    ('goulash'),
+    ('grapefruit'),
+    ('grapes'),
+    ('guacamole'),
+    ('guava'),
+    ('halibut'),
+    ('ham'),
+    ('hamburger'),
+    ('hazelnuts'),
+    ('herring'),
+    ('honey'),
+    ('horse meat'),
+    ('horseradish'),
+    ('hot chocolate'),
+    ('hummus'),
+    ('ice cream'),
+    ('ice tea'),
+    ('iceberg lettuce'),
+    ('jam'),
+    ('jam apple'),
+    ('jelly'),
+    ('jerusalem artichoke'),
+    ('juice'),
+    ('juice apple'),
+    ('juice carrot'),
+    ('juice grape'),
+    ('juice grapefruit'),
+    ('juice multi-fruit'),
+    ('juice orange'),
+    ('juice pear'),
+    ('juice pineapple'),
+    ('juice redcurrant'),
+    ('kale'),
+    ('kefir'),
+    ('ketchup'),
+    ('ketchup curry'),
+    ('ketchup hot chilli'),
+    ('ketchup tomato'),
+    ('kidney bean'),
+    ('kiwi'),
+    ('kohlrabi'),
+    ('kumquat'),
+    ('lamb'),
+    ('lard'),
+    ('leek'),
+    ('lemon'),
+    ('limes'),
+    ('linseeds'),
+    ('liquorice'),
+    ('liverwurst'),
+    ('lobster'),
+    ('lollipop'),
+    ('lychees'),
+    ('macaroni'),
+    ('macaroons'),
+    ('mackerel'),
+    ('mandarins'),
+    ('mango'),
+    ('margarine'),
+    ('marjoram'),
+    ('marshmallows'),
+    ('marzipan'),
+    ('mayonnaise'),
+    ('meatballs'),
+    ('melon'),
+    ('meringue'),
+    ('milk'),
+    ('milk chocolate'),
+    ('milk condensed'),
+    ('milkshake'),
+    ('millet'),
+    ('mincemeat'),
+    ('mineral water'),
+    ('mint'),
+    ('mousse'),
+    ('mousse chocolate'),
+    ('muesli'),
+    ('muffin'),
+    ('muffin chocolate'),
+    ('mushroom tinned'),
+    ('mushrooms'),
+    ('mussels'),
+    ('mustard'),
+    ('mutton'),
+    ('nectarines'),
+    ('nightshade'),
+    ('noodles'),
+    ('nougat'),
+    ('nuggets'),
+    ('nut paste'),
+    ('oatmeal'),
+    ('octopus'),
+    ('oil coconut'),
+    ('oil linseed'),
+    ('oil olive'),
+    ('oil palm'),
+    ('oil peanut'),
+    ('oil sesame'),
+    ('oil soy'),
+    ('oil sunflower seed'),
+    ('olives'),
+    ('olivier salad'),
+    ('onions'),
+    ('oranges'),
+    ('oregano'),
+    ('oysters'),
+    ('pancakes'),
+    ('papaya'),
+    ('paprika'),
+    ('parsley'),
+    ('partridge'),
+    ('passion fruit'),
+    ('pasta'),
+    ('pate'),
+    ('peaches'),
+    ('peanut butter'),
+    ('peanut sauce'),
+    ('peanuts'),
+    ('pears'),
+    ('peas'),
+    ('pecan'),
+    ('pepper'),
+    ('pepper black'),
+    ('pepper white'),
+    ('perch'),
+    ('persimmon'),
+    ('pesto'),
+    ('pheasant'),
+    ('piccalilli'),
+    ('pickles'),
+    ('pie'),
+    ('pine nuts'),
+    ('pineapple'),
+    ('pistachio'),
+    ('pita'),
+    ('pitta bread'),
+    ('pizza'),
+    ('plaice'),
+    ('plantain'),
+    ('plums'),
+    ('pomegranate'),
+    ('popcorn'),
+    ('poppy'),
+    ('pork'),
+    ('porridge'),
+    ('potato crisps'),
+    ('prawns'),
+    ('pretzel sticks'),
+    ('prunes'),
+    ('pudding'),
+    ('pumpkin'),
+    ('quail'),
+    ('quinoa'),
+    ('rabbit'),
+    ('radish'),
+    ('ragout'),
+    ('raisins'),
+    ('raspberries'),
+    ('ratatouille'),
+    ('redcurrants'),
+    ('rhubarb'),
+    ('rib eye steak'),
+    ('rice'),
+    ('rice brown'),
+    ('rice white'),
+    ('risotto'),
+    ('roastbeef'),
+    ('roe'),
+    ('rolls'),
+    ('rosemary'),
+    ('salad'),
+    ('salami'),
+    ('salmon'),
+    ('salsa sauce'),
+    ('sandwich'),
+    ('sardines'),
+    ('sauce barbecue'),
+    ('sauce cocktail'),
+    ('sauce oyster'),
+    ('sausage'),
+    ('sausage cooked'),
+    ('saveloy'),
+    ('scallops'),
+    ('seaweed'),
+    ('seaweed agar agar'),
+    ('seaweed kelp'),
+    ('seaweed nori'),
+    ('seitan'),
+    ('semolina'),
+    ('sesame'),
+    ('shashlik'),
+    ('shawarma'),
+    ('shortbread'),
+    ('shrimp paste'),
+    ('silver‐skin onion'),
+    ('skyr'),
+    ('smoothie'),
+    ('snails'),
+    ('soup'),
+    ('soy sauce'),
+    ('spaghetti'),
+    ('spinach'),
+    ('sports drink'),
+    ('sprats'),
+    ('squash caviar'),
+    ('squid'),
+    ('squid rings'),
+    ('starch potato'),
+    ('steak'),
+    ('stock cubes'),
+    ('stockfish'),
+    ('stracciatella'),
+    ('strawberries'),
+    ('strudel'),
+    ('sturgeon'),
+    ('surimi'),
+    ('sushi'),
+    ('sushi roll'),
+    ('swede'),
+    ('sweet pepper green'),
+    ('sweet pepper orange'),
+    ('sweet pepper red'),
+    ('sweet pepper yellow'),
+    ('sweetcorn'),
+    ('sweets'),
+    ('syrup'),
+    ('tahini'),
+    ('tamarind'),
+    ('tapioca'),
+    ('tartare'),
+    ('tarts'),
+    ('tea'),
+    ('teriyaki sauce'),
+    ('tiramisu'),
+    ('toffees'),
+    ('tofu'),
+    ('tomato cherry raw'),
+    ('tomato juice'),
+    ('tomato puree'),
+    ('tomato sauce'),
+    ('tomatoes'),
+    ('tomatoes tinned'),
+    ('topping'),
+    ('tortellini'),
+    ('tortilla chips'),
+    ('trout'),
+    ('tuna'),
+    ('turkey'),
+    ('turnip'),
+    ('tzatziki'),
+    ('veal'),
+    ('vegetable mix'),
+    ('venison'),
+    ('vermicelli'),
+    ('vinaigrette'),
+    ('vinegar'),
+    ('waffles'),
+    ('walnuts'),
+    ('wasabi'),
+    ('water'),
+    ('watermelon'),
+    ('weenies'),
+    ('white fish fillet'),
+    ('yoghurt'),
+    ('yogurt greek'),
+    ('абрикосы'),
+    ('авокадо'),
+    ('айоли'),
+    ('амаранта'),
+    ('ананас'),
+    ('ананасовый сок'),
+    ('анчоусы'),
+    ('апельсиновый сок'),
+    ('апельсины'),
+    ('арахис'),
+    ('арахисовая паста'),
+    ('арахисовое масло'),
+    ('арбуз'),
+    ('багет'),
+    ('базилик'),
+    ('баклажан'),
+    ('банановые'),
+    ('бананы'),
+    ('баранина'),
+    ('батончик'),
+    ('безе'),
+    ('бекон'),
+    ('белый шоколад'),
+    ('бланманже'),
+    ('блинчики'),
+    ('бобы'),
+    ('болонская колбаса'),
+    ('брауни'),
+    ('бриошь'),
+    ('брокколи'),
+    ('брусника'),
+    ('брюква'),
+    ('булгур'),
+    ('булка'),
+    ('бульон'),
+    ('бульонные кубики'),
+    ('бургер'),
+    ('бутерброд'),
+    ('вареная колбаса'),
+    ('варенье'),
+    ('васаби'),
+    ('вафли'),
+    ('вермишель'),
+    ('ветчина'),
+    ('взбитые сливки'),
+    ('винегрет'),
+    ('виноград'),
+    ('виноградный сок'),
+    ('вишня'),
+    ('вода'),
+    ('водоросли'),
+    ('водоросли агар-агар'),
+    ('водоросли ламинария'),
+    ('водоросли нори'),
+    ('вяленая рыба'),
+    ('галета'),
+    ('гамбургер'),
+    ('гвоздика'),
+    ('говядина'),
+    ('горох'),
+    ('горчица'),
+    ('горячий шоколад'),
+    ('гранат'),
+    ('гребешки'),
+    ('грейпфрут'),
+    ('грейпфрутовый сок'),
+    ('грецкие орехи'),
+    ('греческий йогурт'),
+    ('гречневая крупа'),
+    ('грибы'),
+    ('грибы консервированные'),
+    ('грудинка'),
+    ('грушевый сок'),
+    ('груши'),
+    ('гуава'),
+    ('гуакамоле'),
+    ('гуляш'),
+    ('дзадзики'),
+    ('дорадо'),
+    ('дыня'),
+    ('ежжевика'),
+    ('жареный эклер'),
+    ('желатин'),
+    ('желе'),
+    ('зеленая капуста'),
+    ('зефир'),
+    ('изюм'),
+    ('икра'),
+    ('икра чёрная'),
+    ('имбирь'),
+    ('индейка'),
+    ('инжир'),
+    ('ириски'),
+    ('йогурт'),
+    ('кабачковая икра'),
+    ('кальмар'),
+    ('камбала'),
+    ('капкейк'),
+    ('капуста'),
+    ('капуста белая'),
+    ('капуста брюссельская'),
+    ('капуста квашеная'),
+    ('капуста красная'),
+    ('капуста листовая'),
+    ('капуста цветная'),
+    ('карамбола'),
+    ('карп'),
+    ('каша'),
+    ('каштаны'),
+    ('кедровые орехи'),
+    ('кекс'),
+    ('кекс шоколадный'),
+    ('кекс яблочный'),
+    ('кервель'),
+    ('кетчуп'),
+    ('кетчуп карри'),
+    ('кетчуп острый чили'),
+    ('кетчуп томатный'),
+    ('кефир'),
+    ('кешью'),
+    ('киви'),
+    ('киноа'),
+    ('китайская капуста'),
+    ('клубника'),
+    ('клюква'),
+    ('клюквенный'),
+    ('кокос'),
+    ('кокосовая вода'),
+    ('кокосовое масло'),
+    ('кокосовое молоко'),
+    ('кола'),
+    ('колбаса'),
+    ('кольраби'),
+    ('кольца кальмара'),
+    ('конина'),
+    ('конфеты'),
+    ('корень имбиря'),
+    ('корица'),
+    ('корнишоны'),
+    ('котлеты'),
+    ('кофе'),
+    ('краб'),
+    ('крахмал картофельный'),
+    ('крахмал кукурузный'),
+    ('креветки'),
+    ('креветочная паста'),
+    ('крекеры'),
+    ('крекеры соленые'),
+    ('крендель'),
+    ('кролик'),
+    ('круассан'),
+    ('крыжовник'),
+    ('кукуруза'),
+    ('кумкват'),
+    ('кунжута'),
+    ('кунжутное масло'),
+    ('куриные палочки'),
+    ('курица'),
+    ('куропатка'),
+    ('кускус'),
+    ('лаваш'),
+    ('лаймы'),
+    ('лакрица'),
+    ('лапша'),
+    ('леденец'),
+    ('ливерная колбаса'),
+    ('лимон'),
+    ('лисички'),
+    ('личи'),
+    ('лобстер'),
+    ('лосось'),
+    ('лук'),
+    ('лук зеленый'),
+    ('лук серебристый'),
+    ('лук-порей'),
+    ('льняное масло'),
+    ('майонез'),
+    ('майоран'),
+    ('мака'),
+    ('макароны'),
+    ('малина'),
+    ('манго'),
+    ('мангольд'),
+    ('мандарины'),
+    ('манная каша'),
+    ('манная крупа'),
+    ('маракуйя'),
+    ('маргарин'),
+    ('марципан'),
+    ('масло'),
+    ('маффин'),
+    ('маффин с шоколадом'),
+    ('мед'),
+    ('мидии'),
+    ('миндаль'),
+    ('минеральная вода'),
+    ('моллюски'),
+    ('молоко'),
+    ('молоко сгущенное'),
+    ('молоко шоколадное'),
+    ('молочный коктейль'),
+    ('молочный шоколад'),
+    ('морковный сок'),
+    ('морковь'),
+    ('мороженое'),
+    ('мука'),
+    ('мука гречневая'),
+    ('мука кассавы'),
+    ('мука кукурузная'),
+    ('мука пшеничная'),
+    ('мука ржаная'),
+    ('мука рисовая'),
+    ('мука соевая'),
+    ('мультифруктовый сок'),
+    ('мусс'),
+    ('мусс шоколадный'),
+    ('мюсли'),
+    ('мята'),
+    ('наггетсы'),
+    ('нектарины'),
+    ('нуга'),
+    ('нут'),
+    ('овсянка'),
+    ('огурцы'),
+    ('огурцы маринованные'),
+    ('окунь'),
+    ('оленина'),
+    ('оливки'),
+    ('оливковое масло'),
+    ('оливье'),
+    ('орегано'),
+    ('ореховая паста'),
+    ('осетр'),
+    ('осьминог'),
+    ('отруби'),
+    ('палтус'),
+    ('пальмовое масло'),
+    ('папайя'),
+    ('паприка'),
+    ('паслен'),
+    ('паста'),
+    ('паста карбонара'),
+    ('паштет'),
+    ('пекан'),
+    ('пельмени'),
+    ('перепелка'),
+    ('перец'),
+    ('перец белый'),
+    ('перец сладкий желтый'),
+    ('перец сладкий зеленый'),
+    ('перец сладкий красный'),
+    ('перец сладкий оранжевый'),
+    ('перец черный'),
+    ('персики'),
+    ('песто'),
+    ('петрушка'),
+    ('печенье'),
+    ('печенье песочное'),
+    ('пиво'),
+    ('пиво безалкогольное'),
+    ('пиккалилли'),
+    ('пирог'),
+    ('пирожные'),
+    ('пита'),
+    ('пицца'),
+    ('плантан'),
+    ('подсолнечное масло'),
+    ('помидоры'),
+    ('помидоры консервированные'),
+    ('помидоры черри'),
+    ('пончик'),
+    ('попкорн'),
+    ('просо'),
+    ('простокваша'),
+    ('пряники'),
+    ('пудинг'),
+    ('рагу'),
+    ('рататуй'),
+    ('рахат-лукум'),
+    ('ревень'),
+    ('редис'),
+    ('репа'),
+    ('ризотто'),
+    ('рис'),
+    ('рис белый'),
+    ('рис коричневый'),
+    ('розмарин'),
+    ('роллы'),
+    ('ростбиф'),
+    ('рукола'),
+    ('рыбные палочки'),
+    ('салат'),
+    ('салат айсберг'),
+    ('сало'),
+    ('сальса'),
+    ('салями'),
+    ('сардины'),
+    ('свекла'),
+    ('свинина'),
+    ('сейтан'),
+    ('сельдерей'),
+    ('сельдь'),
+    ('семена аниса'),
+    ('семена льна'),
+    ('семена тмина'),
+    ('семена чиа'),
+    ('сервелат'),
+    ('сироп'),
+    ('скир'),
+    ('скумбрия'),
+    ('сливки'),
+    ('сливочный сыр'),
+    ('сливы'),
+    ('смесь овощей'),
+    ('сметана'),
+    ('смородина'),
+    ('смородина красная'),
+    ('смородина чёрная'),
+    ('смузи'),
+    ('соевое масло'),
+    ('сок'),
+    ('сок из красной смородины'),
+    ('сосиски'),
+    ('сосиски франкфуртские'),
+    ('соус арахисовый'),
+    ('соус барбекю'),
+    ('соус карри'),
+    ('соус коктейльный'),
+    ('соус соевый'),
+    ('соус сырный'),
+    ('соус терияки'),
+    ('соус томатный'),
+    ('соус устричный'),
+    ('соус чесночный'),
+    ('соус чилли'),
+    ('спагетти'),
+    ('спаржа'),
+    ('спортивный напиток'),
+    ('стейк'),
+    ('стейк рибай'),
+    ('страчателла'),
+    ('суп'),
+    ('сурими'),
+    ('сухари'),
+    ('сухарики'),
+    ('сухофрукты'),
+    ('суш'),
+    ('суши роллы'),
+    ('сыр'),
+    ('сыр Бель-паэзе'),
+    ('сыр Бри'),
+    ('сыр Гауда'),
+    ('сыр Горгондзола'),
+    ('сыр Грюйер'),
+    ('сыр Дор Блю'),
+    ('сыр Камамбер'),
+    ('сыр Лимбургер'),
+    ('сыр Маскарпоне'),
+    ('сыр Моцарелла'),
+    ('сыр Пармезан'),
+    ('сыр Рамболь'),
+    ('сыр Рикотта'),
+    ('сыр Рокфор'),
+    ('сыр Сен-Полен'),
+    ('сыр Стилтон'),
+    ('сыр Фета'),
+    ('сыр Чеддер'),
+    ('сыр Швейцарский'),
+    ('сыр Эдам'),
+    ('сыр Эмменталь'),
+    ('сыр козий'),
+    ('сыр копченый'),
+    ('сыр овечий'),
+    ('сырный творог'),
+    ('тамаринд'),
+    ('тапиока'),
+    ('тарталетки'),
+    ('тартар'),
+    ('тахани'),
+    ('творог'),
+    ('телятина'),
+    ('темный шоколад'),
+    ('тирамису'),
+    ('томатная паста'),
+    ('томатный сок'),
+    ('топинамбур'),
+    ('топпинг'),
+    ('торт'),
+    ('торт морковный'),
+    ('торт шоколадный'),
+    ('тортеллини'),
+    ('тофу'),
+    ('треск'),
+    ('тунец'),
+    ('тушенка'),
+    ('тыква'),
+    ('угорь'),
+    ('укроп'),
+    ('уксус'),
+    ('улитки'),
+    ('устрицы'),
+    ('утка'),
+    ('фазан'),
+    ('фалафель'),
+    ('фарш'),
+    ('фасоль'),
+    ('фасоль стручковая'),
+    ('фасоль французская'),
+    ('филе'),
+    ('филе белой рыбы'),
+    ('финики'),
+    ('фисташки'),
+    ('флан'),
+    ('фокачча'),
+    ('форель'),
+    ('фрикадельки'),
+    ('фруктовый сок'),
+    ('фуа-гра'),
+    ('фундук'),
+    ('хала'),
+    ('хлеб'),
+    ('хлеб банановый'),
+    ('хлеб белый'),
+    ('хлеб кукурузный'),
+    ('хлеб ржаной'),
+    ('хлеб тостовый'),
+    ('хлеб цельнозерновой'),
+    ('хлеб черный'),
+    ('хлебные палочки'),
+    ('хлебцы'),
+    ('хлопья кукурузные'),
+    ('холодный чай'),
+    ('хрен'),
+    ('хумус'),
+    ('хурма'),
+    ('цикорий'),
+    ('цуккини'),
+    ('чай'),
+    ('черника'),
+    ('чернослив'),
+    ('чеснок'),
+    ('чиабатта'),
+    ('чизкейк'),
+    ('чили перец'),
+    ('чипсы'),
+    ('чипсы картофельные'),
+    ('чипсы кукурузные'),
+    ('чуррос'),
+    ('шаурма'),
+    ('шашлык'),
+    ('шоколад'),
+    ('шоколадное масло'),
+    ('шпинат'),
+    ('шпроты'),
+    ('штрудель'),
+    ('эндивий'),
+    ('энергетический батончик'),
+    ('энергетический напиток'),
+    ('яблоки'),
+    ('яблочное варенье'),
+    ('яблочное пюре'),
+    ('яблочный сок'),
+    ('ягненок'),
+    ('яйца'),
+    ('ячмень')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+ALTER TABLE storages DROP COLUMN IF EXISTS version;
+ALTER TABLE items_info DROP COLUMN IF EXISTS version;
@@ -0,0 +1,2 @@
+ALTER TABLE items_info ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE storages ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -2,4 +2,7 @@ package queryerr
 
 import "errors"
 
-var ErrStorageNotExists = errors.New("user do not own storage with given id")
+var (
+	ErrStorageNotExists = errors.New("user do not own storage with given id")
+	ErrVersionMismatch  = errors.New("resource was changed after the requested version")
+)
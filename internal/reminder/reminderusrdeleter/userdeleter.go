@@ -5,6 +5,8 @@ import (
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/zhuboris/never-expires/internal/shared/eventbus"
 )
 
 type repository interface {
@@ -23,9 +25,19 @@ func New(repo repository, logger *zap.Logger) *UserDeleter {
 	}
 }
 
-func (d UserDeleter) DeleteUsers(ctx context.Context, usersIDs []string) {
+func (d UserDeleter) DeleteUsers(ctx context.Context, usersIDs []string) error {
 	err := d.repo.deleteUsersData(ctx, usersIDs)
 	d.logDeleting(err, usersIDs)
+	return err
+}
+
+func (d UserDeleter) HandleUserDeleted(ctx context.Context, envelope eventbus.Envelope) error {
+	var event eventbus.UserDeleted
+	if err := envelope.Decode(&event); err != nil {
+		return err
+	}
+
+	return d.DeleteUsers(ctx, []string{event.UserID})
 }
 
 func (d UserDeleter) logDeleting(err error, usersIDs []string) {
@@ -0,0 +1,57 @@
+package reminderusrdeleter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/zhuboris/never-expires/internal/shared/eventbus"
+)
+
+func TestUserDeleter_HandleUserDeleted(t *testing.T) {
+	errDelete := errors.New("db is down")
+
+	tests := []struct {
+		name      string
+		payload   json.RawMessage
+		deleteErr error
+		wantErr   error
+		wantCall  bool
+	}{
+		{
+			name:     "user data is deleted",
+			payload:  json.RawMessage(`{"user_id":"user-id"}`),
+			wantCall: true,
+		},
+		{
+			name:      "deletion error is returned",
+			payload:   json.RawMessage(`{"user_id":"user-id"}`),
+			deleteErr: errDelete,
+			wantErr:   errDelete,
+			wantCall:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := NewMockrepository(t)
+			if tt.wantCall {
+				repo.EXPECT().deleteUsersData(ctx, []string{"user-id"}).Return(tt.deleteErr)
+			}
+
+			err := New(repo, zap.NewNop()).HandleUserDeleted(ctx, eventbus.Envelope{Payload: tt.payload})
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("malformed payload", func(t *testing.T) {
+		err := New(NewMockrepository(t), zap.NewNop()).HandleUserDeleted(context.Background(), eventbus.Envelope{Payload: json.RawMessage(`[]`)})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,56 @@
+package reminderusrexporter
+
+import (
+	"strconv"
+	"time"
+)
+
+type (
+	storage struct {
+		ID        string `json:"id"`
+		Name      string `json:"name"`
+		IsDefault bool   `json:"is_default"`
+	}
+	item struct {
+		ID                string
+		StorageName       string
+		Name              string
+		IsOpened          bool
+		BestBefore        time.Time
+		ExpirationDate    time.Time
+		HoursAfterOpening int
+		AddedDate         time.Time
+		Note              *string
+	}
+)
+
+var itemsHeader = []string{
+	"id",
+	"storage",
+	"name",
+	"is_opened",
+	"best_before",
+	"expiration_date",
+	"hours_after_opening",
+	"added_date",
+	"note",
+}
+
+func (i item) row() []string {
+	var note string
+	if i.Note != nil {
+		note = *i.Note
+	}
+
+	return []string{
+		i.ID,
+		i.StorageName,
+		i.Name,
+		strconv.FormatBool(i.IsOpened),
+		i.BestBefore.Format(time.RFC3339),
+		i.ExpirationDate.Format(time.RFC3339),
+		strconv.Itoa(i.HoursAfterOpening),
+		i.AddedDate.Format(time.RFC3339),
+		note,
+	}
+}
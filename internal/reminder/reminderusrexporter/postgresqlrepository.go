@@ -0,0 +1,110 @@
+package reminderusrexporter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zhuboris/never-expires/internal/shared/postgresql"
+)
+
+type PostgresqlRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresqlRepository(pool *pgxpool.Pool) (*PostgresqlRepository, error) {
+	if pool == nil {
+		return nil, postgresql.ErrPoolInitRequired
+	}
+
+	return &PostgresqlRepository{
+		pool: pool,
+	}, nil
+}
+
+func (r PostgresqlRepository) storages(ctx context.Context, userID string) ([]storage, error) {
+	const sql = `
+		SELECT s.id, s.name, uds.storage_id IS NOT NULL
+		FROM storages s
+		LEFT JOIN users_default_storages uds ON uds.storage_id = s.id
+		WHERE s.owner_id = $1
+		ORDER BY s.name;
+	`
+
+	rows, err := r.pool.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, postgresql.HandleQueryErr(err)
+	}
+
+	var storages []storage
+	for rows.Next() {
+		var result storage
+		if scanError := rows.Scan(&result.ID, &result.Name, &result.IsDefault); scanError != nil {
+			err = errors.Join(scanError, err)
+			continue
+		}
+
+		storages = append(storages, result)
+	}
+
+	return storages, postgresql.HandleQueryErr(errors.Join(err, rows.Err()))
+}
+
+func (r PostgresqlRepository) items(ctx context.Context, userID string) ([]item, error) {
+	const sql = `
+		SELECT i.id, s.name, ii.name, ii.is_opened, ii.best_before, ii.expiration_date,
+		       ii.hours_after_opening, ii.added_date, ii.note
+		FROM items i
+		JOIN storages s ON s.id = i.storage_id
+		JOIN items_info ii ON ii.id = i.id
+		WHERE s.owner_id = $1
+		ORDER BY ii.added_date;
+	`
+
+	rows, err := r.pool.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, postgresql.HandleQueryErr(err)
+	}
+
+	var items []item
+	for rows.Next() {
+		var result item
+		scanError := rows.Scan(&result.ID, &result.StorageName, &result.Name, &result.IsOpened, &result.BestBefore,
+			&result.ExpirationDate, &result.HoursAfterOpening, &result.AddedDate, &result.Note)
+		if scanError != nil {
+			err = errors.Join(scanError, err)
+			continue
+		}
+
+		items = append(items, result)
+	}
+
+	return items, postgresql.HandleQueryErr(errors.Join(err, rows.Err()))
+}
+
+func (r PostgresqlRepository) privateItemNames(ctx context.Context, userID string) ([]string, error) {
+	const sql = `
+		SELECT name FROM private_types_of_items
+		WHERE user_id = $1
+		ORDER BY name;
+	`
+
+	rows, err := r.pool.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, postgresql.HandleQueryErr(err)
+	}
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if scanError := rows.Scan(&name); scanError != nil {
+			err = errors.Join(scanError, err)
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	return names, postgresql.HandleQueryErr(errors.Join(err, rows.Err()))
+}
@@ -0,0 +1,64 @@
+package reminderusrexporter
+
+import (
+	"context"
+
+	"github.com/zhuboris/never-expires/internal/shared/dataexport"
+)
+
+const exportDir = "reminder"
+
+type repository interface {
+	storages(ctx context.Context, userID string) ([]storage, error)
+	items(ctx context.Context, userID string) ([]item, error)
+	privateItemNames(ctx context.Context, userID string) ([]string, error)
+}
+
+type UserExporter struct {
+	repo repository
+}
+
+func New(repo repository) *UserExporter {
+	return &UserExporter{
+		repo: repo,
+	}
+}
+
+func (e UserExporter) ExportUserData(ctx context.Context, userID string) ([]dataexport.File, error) {
+	storages, err := e.repo.storages(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := e.repo.items(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := e.repo.privateItemNames(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	storagesFile, err := dataexport.JSONFile("storages.json", storages)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, item.row())
+	}
+
+	itemsFile, err := dataexport.CSVFile("items.csv", itemsHeader, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	namesFile, err := dataexport.JSONFile("private_item_names.json", names)
+	if err != nil {
+		return nil, err
+	}
+
+	return dataexport.InDir(exportDir, storagesFile, itemsFile, namesFile), nil
+}
@@ -0,0 +1,79 @@
+package reminderusrexporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testUserID = "user-id"
+
+func TestUserExporter_ExportUserData(t *testing.T) {
+	var (
+		date = time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+		note = "half left"
+	)
+
+	tests := []struct {
+		name          string
+		itemsErr      error
+		items         []item
+		expectedItems string
+	}{
+		{
+			name:          "no items",
+			expectedItems: "id,storage,name,is_opened,best_before,expiration_date,hours_after_opening,added_date,note\n",
+		},
+		{
+			name: "item with note",
+			items: []item{
+				{
+					ID:                "item-id",
+					StorageName:       "Fridge",
+					Name:              "Milk",
+					IsOpened:          true,
+					BestBefore:        date,
+					ExpirationDate:    date,
+					HoursAfterOpening: 72,
+					AddedDate:         date,
+					Note:              &note,
+				},
+			},
+			expectedItems: "id,storage,name,is_opened,best_before,expiration_date,hours_after_opening,added_date,note\n" +
+				"item-id,Fridge,Milk,true,2023-01-01T12:00:00Z,2023-01-01T12:00:00Z,72,2023-01-01T12:00:00Z,half left\n",
+		},
+		{
+			name:     "repository error",
+			itemsErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockrepository(t)
+			repo.EXPECT().storages(mock.Anything, testUserID).Return([]storage{{ID: "storage-id", Name: "Fridge", IsDefault: true}}, nil)
+			repo.EXPECT().items(mock.Anything, testUserID).Return(tt.items, tt.itemsErr)
+			if tt.itemsErr == nil {
+				repo.EXPECT().privateItemNames(mock.Anything, testUserID).Return([]string{"Homemade jam"}, nil)
+			}
+
+			files, err := New(repo).ExportUserData(context.Background(), testUserID)
+			if tt.itemsErr != nil {
+				require.ErrorIs(t, err, tt.itemsErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, files, 3)
+			assert.Equal(t, "reminder/storages.json", files[0].Name)
+			assert.Equal(t, "reminder/items.csv", files[1].Name)
+			assert.Equal(t, tt.expectedItems, string(files[1].Content))
+			assert.Equal(t, "reminder/private_item_names.json", files[2].Name)
+		})
+	}
+}
@@ -7,6 +7,8 @@ type Storage struct {
 	Name       string      `json:"name"`
 	ItemsCount int         `json:"items_count"`
 	IsDefault  bool        `json:"is_default"`
+	// Version is increased on every update, it is sent in ETag header instead of the body.
+	Version int `json:"-"`
 }
 
 type Entity struct {
@@ -14,6 +16,7 @@ type Entity struct {
 	Name       *string      `json:"name"`
 	ItemsCount *int         `json:"items_count"`
 	IsDefault  *bool        `json:"is_default"`
+	Version    *int         `json:"-"`
 }
 
 func (e Entity) storage() *Storage {
@@ -34,5 +37,9 @@ func (e Entity) storage() *Storage {
 		result.IsDefault = *e.IsDefault
 	}
 
+	if e.Version != nil {
+		result.Version = *e.Version
+	}
+
 	return &result
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/zhuboris/never-expires/internal/reminder/queryerr"
 	"github.com/zhuboris/never-expires/internal/shared/postgresql"
 )
 
@@ -30,7 +31,7 @@ func (r PostgresqlRepository) allByOwnerID(ctx context.Context, ownerID pgtype.U
 			VALUES ($2, $1), ($3, $1), ($4, $1)
 			ON CONFLICT (name, owner_id) DO NOTHING
 			        
-			RETURNING id, name, owner_id
+			RETURNING id, name, owner_id, version
 		), saved_default AS (
 			INSERT INTO users_default_storages (user_id, storage_id) 
 			SELECT owner_id, id
@@ -43,6 +44,7 @@ func (r PostgresqlRepository) allByOwnerID(ctx context.Context, ownerID pgtype.U
 		SELECT
     		id,
     		name,
+    		version,
     		(SELECT COUNT(*) FROM items WHERE storage_id = s.id) AS items_contain,
     		EXISTS (
        			SELECT 1 FROM users_default_storages ds
@@ -54,10 +56,10 @@ func (r PostgresqlRepository) allByOwnerID(ctx context.Context, ownerID pgtype.U
             		WHERE s.id = sd.storage_id
         		) AS is_default
 		FROM (
-    		(SELECT id, name, owner_id FROM storages
+    		(SELECT id, name, owner_id, version FROM storages
      		WHERE owner_id = $1)
     		UNION ALL
-    		(SELECT id, name, owner_id FROM defaults)
+    		(SELECT id, name, owner_id, version FROM defaults)
 		)  AS s
 		ORDER BY items_contain DESC;
 	`
@@ -70,7 +72,7 @@ func (r PostgresqlRepository) allByOwnerID(ctx context.Context, ownerID pgtype.U
 	storages := make([]*Storage, 0)
 	for rows.Next() {
 		storage := new(Storage)
-		err := rows.Scan(&storage.ID, &storage.Name, &storage.ItemsCount, &storage.IsDefault)
+		err := rows.Scan(&storage.ID, &storage.Name, &storage.Version, &storage.ItemsCount, &storage.IsDefault)
 		if err != nil {
 			return nil, postgresql.HandleQueryErr(err)
 		}
@@ -88,12 +90,13 @@ func (r PostgresqlRepository) add(ctx context.Context, toAdd Storage, ownerID pg
 			VALUES ($1, $2, $3)
 			ON CONFLICT (name, owner_id) DO NOTHING
 			    
-			RETURNING id, name
+			RETURNING id, name, version
 		) 
 		SELECT 
 		    EXISTS (SELECT 1 FROM inserted_storage) AS is_added , 
 		    s.id, 
-		    s.name
+		    s.name,
+		    s.version
 		FROM (SELECT 1) as dummy
 		LEFT JOIN inserted_storage s ON TRUE;
 	`
@@ -104,7 +107,7 @@ func (r PostgresqlRepository) add(ctx context.Context, toAdd Storage, ownerID pg
 	)
 
 	err := r.pool.QueryRow(ctx, sql, toAdd.ID, toAdd.Name, ownerID).
-		Scan(&isAdded, &storage.ID, &storage.Name)
+		Scan(&isAdded, &storage.ID, &storage.Name, &storage.Version)
 	if err != nil {
 		err = postgresql.CheckErrorForUniqueViolation(err)
 		return false, nil, postgresql.HandleQueryErr(err)
@@ -113,20 +116,27 @@ func (r PostgresqlRepository) add(ctx context.Context, toAdd Storage, ownerID pg
 	return isAdded, storage.storage(), nil
 }
 
+// update changes the storage only if updated.Version is equal to the saved one, zero version changes any.
+// It returns queryerr.ErrVersionMismatch if the storage exists, but has another version.
 func (r PostgresqlRepository) update(ctx context.Context, updated Storage, ownerID pgtype.UUID) (bool, *Storage, error) {
 	const sql = `
 		WITH updated AS (
 			UPDATE storages
-			SET name = $1
+			SET 
+			    name = $1,
+			    version = version + 1
 			WHERE id = $2
 			AND owner_id = $3
+			AND ($4 = 0 OR version = $4)
 		
 			RETURNING *
 		)
 		SELECT 
+			EXISTS (SELECT 1 FROM storages WHERE id = $2 AND owner_id = $3) AS is_found,
 			EXISTS (SELECT 1 FROM updated) AS is_updated,
 		    u.id,
 		    u.name,
+		    u.version,
 		    (SELECT COUNT(*) FROM items WHERE storage_id = u.id) AS items_contains,
 			EXISTS (
 		    	SELECT 1 FROM users_default_storages ds 
@@ -138,16 +148,21 @@ func (r PostgresqlRepository) update(ctx context.Context, updated Storage, owner
 	`
 
 	var (
+		isFound   bool
 		isUpdated bool
 		storage   = new(Entity)
 	)
-	err := r.pool.QueryRow(ctx, sql, updated.Name, updated.ID, ownerID).
-		Scan(&isUpdated, &storage.ID, &storage.Name, &storage.ItemsCount, &storage.IsDefault)
+	err := r.pool.QueryRow(ctx, sql, updated.Name, updated.ID, ownerID, updated.Version).
+		Scan(&isFound, &isUpdated, &storage.ID, &storage.Name, &storage.Version, &storage.ItemsCount, &storage.IsDefault)
 	if err != nil {
 		err = postgresql.CheckErrorForUniqueViolation(err)
 		return false, nil, postgresql.HandleQueryErr(err)
 	}
 
+	if isFound && !isUpdated {
+		return false, nil, queryerr.ErrVersionMismatch
+	}
+
 	return isUpdated, storage.storage(), nil
 }
 
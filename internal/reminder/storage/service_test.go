@@ -545,6 +545,10 @@ func TestService_Update(t *testing.T) {
 		existingStorage = Storage{
 			Name: "existing",
 		}
+		changedStorage = Storage{
+			Name:    "changed",
+			Version: 1,
+		}
 	)
 
 	repoMock := NewMockrepository(t)
@@ -557,6 +561,9 @@ func TestService_Update(t *testing.T) {
 	repoMock.EXPECT().
 		update(mock.Anything, notExistingStorage, mock.Anything).
 		Return( /*idUpdated*/ false, nil, nil)
+	repoMock.EXPECT().
+		update(mock.Anything, changedStorage, mock.Anything).
+		Return( /*idUpdated*/ false, nil, queryerr.ErrVersionMismatch)
 
 	type fields struct {
 		repoMock      repository
@@ -599,6 +606,16 @@ func TestService_Update(t *testing.T) {
 			wantError:     true,
 			expectedError: ErrStorageNameNotUnique,
 		},
+		{
+			name: "update storage changed by another request",
+			fields: fields{
+				repoMock:      repoMock,
+				idDecoderMock: newDecoderOfValidID(t),
+			},
+			storage:       changedStorage,
+			wantError:     true,
+			expectedError: queryerr.ErrVersionMismatch,
+		},
 		{
 			name: "update existing storage",
 			fields: fields{
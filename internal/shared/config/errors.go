@@ -0,0 +1,63 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidConfig    = errors.New("configuration is invalid")
+	ErrUnsupportedField = errors.New("config field type is not supported")
+
+	errInvalidSecret = errors.New("secret value has invalid format")
+)
+
+// Report collects every missing and invalid value, so all of them are shown at once.
+type Report struct {
+	Missing []string
+	Invalid []string
+}
+
+func (r *Report) Error() string {
+	var builder strings.Builder
+	builder.WriteString(ErrInvalidConfig.Error())
+
+	if len(r.Missing) != 0 {
+		builder.WriteString("\n  missing: ")
+		builder.WriteString(strings.Join(r.Missing, ", "))
+	}
+
+	for _, invalid := range r.Invalid {
+		builder.WriteString("\n  invalid: ")
+		builder.WriteString(invalid)
+	}
+
+	return builder.String()
+}
+
+func (r *Report) Unwrap() error {
+	return ErrInvalidConfig
+}
+
+func (r *Report) addMissing(key string) {
+	for _, missing := range r.Missing {
+		if missing == key {
+			return
+		}
+	}
+
+	r.Missing = append(r.Missing, key)
+}
+
+func (r *Report) addInvalid(key string, err error) {
+	r.Invalid = append(r.Invalid, fmt.Sprintf("%s: %s", key, err))
+}
+
+func (r *Report) errOrNil() error {
+	if len(r.Missing) == 0 && len(r.Invalid) == 0 {
+		return nil
+	}
+
+	return r
+}
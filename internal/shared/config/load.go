@@ -0,0 +1,188 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	envTag      = "env"
+	defaultTag  = "default"
+	requiredTag = "required"
+	secretTag   = "secret"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Load fills structs passed by pointers from the default Source. Fields are described with tags:
+// env is the key to look up, default is used when value is not set, required:"true" reports missing value
+// and secret:"true" hides value in Redact. Nested structs without env tag are loaded recursively.
+// All missing and invalid values are returned together in *Report.
+func Load(dst ...any) error {
+	source, err := NewSource()
+	if err != nil {
+		return err
+	}
+
+	return source.Load(dst...)
+}
+
+func (s Source) Load(dst ...any) error {
+	return s.LoadPrefixed("", dst...)
+}
+
+// LoadPrefixed is Load with prefix added to env tags, it is used for keys that depend on other config values.
+func (s Source) LoadPrefixed(prefix string, dst ...any) error {
+	report := new(Report)
+	for _, d := range dst {
+		value := reflect.ValueOf(d)
+		if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("%w: destination must be a pointer to struct, got %T", ErrUnsupportedField, d)
+		}
+
+		if err := s.loadStruct(value.Elem(), prefix, report); err != nil {
+			return err
+		}
+	}
+
+	return report.errOrNil()
+}
+
+func (s Source) loadStruct(value reflect.Value, prefix string, report *Report) error {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field, fieldValue := valueType.Field(i), value.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key := field.Tag.Get(envTag)
+		if key == "" {
+			if fieldValue.Kind() != reflect.Struct {
+				continue
+			}
+
+			if err := s.loadStruct(fieldValue, prefix, report); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := s.loadField(field, fieldValue, prefix+key, report); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s Source) loadField(field reflect.StructField, value reflect.Value, key string, report *Report) error {
+	raw, ok, err := s.lookup(key, isSecret(field))
+	if err != nil {
+		report.addInvalid(key, err)
+		return nil
+	}
+
+	if !ok {
+		raw, ok = field.Tag.Lookup(defaultTag)
+	}
+
+	if !ok {
+		if field.Tag.Get(requiredTag) == "true" {
+			report.addMissing(key)
+		}
+
+		return nil
+	}
+
+	err = set(value, raw)
+	switch {
+	case errors.Is(err, ErrUnsupportedField):
+		return fmt.Errorf("%w, key %s", err, key)
+	case err != nil && isSecret(field):
+		report.addInvalid(key, errInvalidSecret)
+	case err != nil:
+		report.addInvalid(key, err)
+	}
+
+	return nil
+}
+
+func isSecret(field reflect.StructField) bool {
+	return field.Tag.Get(secretTag) == "true"
+}
+
+func set(value reflect.Value, raw string) error {
+	if value.Kind() == reflect.Pointer {
+		elem := reflect.New(value.Type().Elem())
+		if err := set(elem.Elem(), raw); err != nil {
+			return err
+		}
+
+		value.Set(elem)
+		return nil
+	}
+
+	if value.Type() == durationType {
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration", raw)
+		}
+
+		value.SetInt(int64(duration))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", raw)
+		}
+
+		value.SetInt(result)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		result, err := strconv.ParseUint(raw, 10, value.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a positive integer", raw)
+		}
+
+		value.SetUint(result)
+	case reflect.Bool:
+		result, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a bool", raw)
+		}
+
+		value.SetBool(result)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("%w: %s", ErrUnsupportedField, value.Type())
+		}
+
+		value.Set(reflect.ValueOf(splitList(raw)))
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedField, value.Type())
+	}
+
+	return nil
+}
+
+func splitList(raw string) []string {
+	items := strings.Split(raw, ",")
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testDBConfig struct {
+	Host     string `env:"TEST_DB_HOST" required:"true"`
+	Port     int    `env:"TEST_DB_PORT" default:"5432"`
+	Password string `env:"TEST_DB_PASSWORD" required:"true" secret:"true"`
+}
+
+type testConfig struct {
+	Address  string        `env:"TEST_ADDRESS" required:"true"`
+	Timeout  time.Duration `env:"TEST_TIMEOUT" default:"5s"`
+	Origins  []string      `env:"TEST_ORIGINS"`
+	Debug    *bool         `env:"TEST_DEBUG"`
+	Internal string
+	DB       testDBConfig
+}
+
+func TestSource_Load(t *testing.T) {
+	enabled := true
+
+	tests := []struct {
+		name        string
+		env         map[string]string
+		want        testConfig
+		wantMissing []string
+		wantInvalid []string
+	}{
+		{
+			name: "values are read from env and defaults",
+			env: map[string]string{
+				"TEST_ADDRESS":     ":3000",
+				"TEST_ORIGINS":     "https://a.com, https://b.com,",
+				"TEST_DEBUG":       "true",
+				"TEST_DB_HOST":     "db",
+				"TEST_DB_PASSWORD": "secret",
+			},
+			want: testConfig{
+				Address: ":3000",
+				Timeout: 5 * time.Second,
+				Origins: []string{"https://a.com", "https://b.com"},
+				Debug:   &enabled,
+				DB: testDBConfig{
+					Host:     "db",
+					Port:     5432,
+					Password: "secret",
+				},
+			},
+		},
+		{
+			name: "empty values are treated as missing",
+			env: map[string]string{
+				"TEST_ADDRESS":     "",
+				"TEST_DB_HOST":     "db",
+				"TEST_DB_PASSWORD": "secret",
+			},
+			wantMissing: []string{"TEST_ADDRESS"},
+		},
+		{
+			name:        "all missing values are reported together",
+			env:         map[string]string{},
+			wantMissing: []string{"TEST_ADDRESS", "TEST_DB_HOST", "TEST_DB_PASSWORD"},
+		},
+		{
+			name: "invalid values are reported with missing ones",
+			env: map[string]string{
+				"TEST_TIMEOUT":     "5",
+				"TEST_DB_PORT":     "port",
+				"TEST_DB_PASSWORD": "secret",
+			},
+			wantMissing: []string{"TEST_ADDRESS", "TEST_DB_HOST"},
+			wantInvalid: []string{`TEST_TIMEOUT: "5" is not a duration`, `TEST_DB_PORT: "port" is not an integer`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got testConfig
+			err := newTestSource(tt.env).Load(&got)
+			if tt.wantMissing == nil && tt.wantInvalid == nil {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				return
+			}
+
+			var report *Report
+			require.ErrorAs(t, err, &report)
+			require.ErrorIs(t, err, ErrInvalidConfig)
+			assert.Equal(t, tt.wantMissing, report.Missing)
+			assert.Equal(t, tt.wantInvalid, report.Invalid)
+		})
+	}
+}
+
+func TestSource_LoadPrefixed(t *testing.T) {
+	source := newTestSource(map[string]string{
+		"PRIMARY_TEST_DB_HOST":     "primary",
+		"PRIMARY_TEST_DB_PASSWORD": "secret",
+		"TEST_DB_HOST":             "not prefixed",
+	})
+
+	var got testDBConfig
+	require.NoError(t, source.LoadPrefixed("PRIMARY_", &got))
+	assert.Equal(t, testDBConfig{Host: "primary", Port: 5432, Password: "secret"}, got)
+}
+
+func TestSource_Load_hidesInvalidSecret(t *testing.T) {
+	type secretConfig struct {
+		Key int `env:"TEST_KEY" secret:"true"`
+	}
+
+	err := newTestSource(map[string]string{"TEST_KEY": "leaked-value"}).Load(new(secretConfig))
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	assert.NotContains(t, err.Error(), "leaked-value")
+}
+
+func TestSource_Load_unsupportedDestination(t *testing.T) {
+	type mapConfig struct {
+		Values map[string]string `env:"TEST_VALUES"`
+	}
+
+	source := newTestSource(map[string]string{"TEST_VALUES": "a"})
+	assert.ErrorIs(t, source.Load(new(mapConfig)), ErrUnsupportedField)
+	assert.ErrorIs(t, source.Load(mapConfig{}), ErrUnsupportedField)
+}
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    any
+		wantErr bool
+	}{
+		{name: "integer", raw: "10", want: 10},
+		{name: "integer starting with zeros", raw: "000010", want: 10},
+		{name: "integer starting with plus", raw: "+10", want: 10},
+		{name: "negative integer", raw: "-10", want: -10},
+		{name: "zero", raw: "0", want: 0},
+		{name: "integer with chars", raw: "test", want: 0, wantErr: true},
+		{name: "integer with spaces", raw: " 10", want: 0, wantErr: true},
+		{name: "float as integer", raw: "10.10", want: 0, wantErr: true},
+		{name: "integer bigger than int max value", raw: "10000000000000000000000", want: 0, wantErr: true},
+		{name: "uint8 overflow", raw: "256", want: uint8(0), wantErr: true},
+		{name: "bool", raw: "true", want: true},
+		{name: "bool as number", raw: "1", want: true},
+		{name: "upper case bool", raw: "FALSE", want: false},
+		{name: "not bool string", raw: "yes", want: false, wantErr: true},
+		{name: "duration", raw: "1m30s", want: 90 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := reflect.New(reflect.TypeOf(tt.want)).Elem()
+			err := set(value, tt.raw)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, value.Interface())
+		})
+	}
+}
+
+func newTestSource(env map[string]string) *Source {
+	return &Source{
+		lookupEnv: func(key string) (string, bool) {
+			value, ok := env[key]
+			return value, ok
+		},
+		secretsDir: "/not/existing/dir",
+	}
+}
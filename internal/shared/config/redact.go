@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const redacted = "******"
+
+// Redact returns loaded values as KEY=value lines with secrets hidden, so config can be logged.
+func Redact(src ...any) string {
+	var lines []string
+	for _, s := range src {
+		value := reflect.Indirect(reflect.ValueOf(s))
+		if value.Kind() == reflect.Struct {
+			lines = appendRedacted(lines, value)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func appendRedacted(lines []string, value reflect.Value) []string {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field, fieldValue := valueType.Field(i), value.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key := field.Tag.Get(envTag)
+		switch {
+		case key != "":
+			lines = append(lines, key+"="+redactedValue(field, fieldValue))
+		case fieldValue.Kind() == reflect.Struct:
+			lines = appendRedacted(lines, fieldValue)
+		}
+	}
+
+	return lines
+}
+
+func redactedValue(field reflect.StructField, value reflect.Value) string {
+	const unset = "<unset>"
+
+	switch {
+	case value.IsZero() && value.Kind() == reflect.Pointer:
+		return unset
+	case isSecret(field) && value.IsZero():
+		return ""
+	case isSecret(field):
+		return redacted
+	case value.Kind() == reflect.Pointer:
+		value = value.Elem()
+	}
+
+	if value.Kind() == reflect.Slice {
+		items := make([]string, value.Len())
+		for i := range items {
+			items[i] = fmt.Sprint(value.Index(i).Interface())
+		}
+
+		return strings.Join(items, ",")
+	}
+
+	return fmt.Sprint(value.Interface())
+}
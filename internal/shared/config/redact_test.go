@@ -0,0 +1,32 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	cfg := testConfig{
+		Address: ":3000",
+		Timeout: time.Second,
+		Origins: []string{"https://a.com", "https://b.com"},
+		DB: testDBConfig{
+			Host:     "db",
+			Port:     5432,
+			Password: "secret",
+		},
+	}
+
+	want := `TEST_ADDRESS=:3000
+TEST_TIMEOUT=1s
+TEST_ORIGINS=https://a.com,https://b.com
+TEST_DEBUG=<unset>
+TEST_DB_HOST=db
+TEST_DB_PORT=5432
+TEST_DB_PASSWORD=******`
+
+	assert.Equal(t, want, Redact(cfg))
+	assert.Equal(t, want, Redact(&cfg))
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	fileEnvKey        = "CONFIG_FILE"
+	secretsDirEnvKey  = "SECRETS_DIR"
+	secretFileSuffix  = "_FILE"
+	defaultSecretsDir = "/run/secrets"
+)
+
+// Source looks values up by key in env, then in Docker secret files and then in YAML config file.
+// Secret files are checked only for secret values: the file set in <KEY>_FILE env
+// or the file named after lower cased key in SECRETS_DIR, /run/secrets by default.
+type Source struct {
+	lookupEnv  func(key string) (string, bool)
+	secretsDir string
+	file       map[string]string
+}
+
+// NewSource makes source with YAML file from CONFIG_FILE env, it is skipped when env is not set.
+func NewSource() (*Source, error) {
+	secretsDir := os.Getenv(secretsDirEnvKey)
+	if secretsDir == "" {
+		secretsDir = defaultSecretsDir
+	}
+
+	source := &Source{
+		lookupEnv:  os.LookupEnv,
+		secretsDir: secretsDir,
+	}
+
+	if path := os.Getenv(fileEnvKey); path != "" {
+		file, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		source.file = file
+	}
+
+	return source, nil
+}
+
+// lookup returns value of the key, empty values are treated as not set.
+func (s Source) lookup(key string, secret bool) (string, bool, error) {
+	if value, ok := s.lookupEnv(key); ok && value != "" {
+		return value, true, nil
+	}
+
+	if !secret {
+		value, ok := s.file[key]
+		return value, ok && value != "", nil
+	}
+
+	if path, ok := s.lookupEnv(key + secretFileSuffix); ok && path != "" {
+		value, err := readSecret(path)
+		if err != nil {
+			return "", false, err
+		}
+
+		return value, value != "", nil
+	}
+
+	value, err := readSecret(filepath.Join(s.secretsDir, strings.ToLower(key)))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return "", false, err
+	case value != "":
+		return value, true, nil
+	}
+
+	value, ok := s.file[key]
+	return value, ok && value != "", nil
+}
+
+func readSecret(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// readFile reads flat YAML mapping where keys are the same as env keys, lists are joined by comma.
+func readFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file failed: %w", err)
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("parsing config file failed: %w", err)
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		switch value := value.(type) {
+		case nil:
+		case []any:
+			items := make([]string, len(value))
+			for i := range value {
+				items[i] = fmt.Sprint(value[i])
+			}
+
+			values[key] = strings.Join(items, ",")
+		case map[string]any:
+			return nil, fmt.Errorf("config file value of %q must not be a mapping", key)
+		default:
+			values[key] = fmt.Sprint(value)
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSource_lookup(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "password_file"), "from-file-env\n")
+	writeFile(t, filepath.Join(dir, "secrets", "db_password"), "from-secrets-dir\n")
+	writeFile(t, filepath.Join(dir, "config.yml"), "DB_HOST: yaml-host\nDB_PORT: 5432\nDB_PASSWORD: from-yaml\nORIGINS:\n  - a\n  - b\n")
+
+	file, err := readFile(filepath.Join(dir, "config.yml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		env    map[string]string
+		key    string
+		secret bool
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "env has priority over file",
+			env:    map[string]string{"DB_HOST": "env-host"},
+			key:    "DB_HOST",
+			want:   "env-host",
+			wantOK: true,
+		},
+		{
+			name:   "value from yaml file",
+			key:    "DB_PORT",
+			want:   "5432",
+			wantOK: true,
+		},
+		{
+			name:   "yaml list is joined",
+			key:    "ORIGINS",
+			want:   "a,b",
+			wantOK: true,
+		},
+		{
+			name:   "secret from file set in env",
+			env:    map[string]string{"DB_PASSWORD_FILE": filepath.Join(dir, "password_file")},
+			key:    "DB_PASSWORD",
+			secret: true,
+			want:   "from-file-env",
+			wantOK: true,
+		},
+		{
+			name:   "secret from secrets dir",
+			key:    "DB_PASSWORD",
+			secret: true,
+			want:   "from-secrets-dir",
+			wantOK: true,
+		},
+		{
+			name:   "not secret value is not read from secret files",
+			env:    map[string]string{"DB_PASSWORD_FILE": filepath.Join(dir, "password_file")},
+			key:    "DB_PASSWORD",
+			want:   "from-yaml",
+			wantOK: true,
+		},
+		{
+			name: "missing value",
+			key:  "DB_NAME",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := newTestSource(tt.env)
+			source.secretsDir = filepath.Join(dir, "secrets")
+			source.file = file
+
+			got, ok, err := source.lookup(tt.key, tt.secret)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSource_lookup_missingSecretFile(t *testing.T) {
+	source := newTestSource(map[string]string{"DB_PASSWORD_FILE": filepath.Join(t.TempDir(), "missing")})
+
+	_, _, err := source.lookup("DB_PASSWORD", true)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestReadFile_nestedMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	writeFile(t, path, "DB:\n  HOST: db\n")
+
+	_, err := readFile(path)
+	assert.Error(t, err)
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
@@ -0,0 +1,78 @@
+package dataexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"path"
+)
+
+// File is one file of exported user data, Name is the path inside the archive.
+type File struct {
+	Name    string
+	Content []byte
+}
+
+func JSONFile(name string, data any) (File, error) {
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return File{}, err
+	}
+
+	return File{
+		Name:    name,
+		Content: content,
+	}, nil
+}
+
+func CSVFile(name string, header []string, rows [][]string) (File, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write(header); err != nil {
+		return File{}, err
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return File{}, err
+	}
+
+	return File{
+		Name:    name,
+		Content: buf.Bytes(),
+	}, nil
+}
+
+// InDir returns files with names moved into the directory.
+func InDir(dir string, files ...File) []File {
+	result := make([]File, 0, len(files))
+	for _, file := range files {
+		file.Name = path.Join(dir, file.Name)
+		result = append(result, file)
+	}
+
+	return result
+}
+
+func Zip(files []File) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+
+	for _, file := range files {
+		fileWriter, err := writer.Create(file.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := fileWriter.Write(file.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
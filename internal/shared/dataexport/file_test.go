@@ -0,0 +1,44 @@
+package dataexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZip(t *testing.T) {
+	jsonFile, err := JSONFile("profile.json", map[string]string{"username": "user"})
+	require.NoError(t, err)
+
+	csvFile, err := CSVFile("items.csv", []string{"name", "note"}, [][]string{{"milk", "with, comma"}})
+	require.NoError(t, err)
+
+	archive, err := Zip(InDir("id", jsonFile, csvFile))
+	require.NoError(t, err)
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	contents := make(map[string]string)
+	for _, file := range reader.File {
+		opened, err := file.Open()
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(opened)
+		require.NoError(t, err)
+		require.NoError(t, opened.Close())
+
+		contents[file.Name] = string(content)
+	}
+
+	expected := map[string]string{
+		"id/profile.json": "{\n  \"username\": \"user\"\n}",
+		"id/items.csv":    "name,note\nmilk,\"with, comma\"\n",
+	}
+
+	assert.Equal(t, expected, contents)
+}
@@ -0,0 +1,123 @@
+package etag
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zhuboris/never-expires/internal/shared/rwjson"
+)
+
+const (
+	header            = "ETag"
+	ifMatchHeader     = "If-Match"
+	ifNoneMatchHeader = "If-None-Match"
+	anyValue          = "*"
+	weakPrefix        = "W/"
+	hashLength        = 16
+)
+
+var ErrPreconditionFailed = errors.New("resource does not match If-Match header")
+
+// FromVersion makes strong ETag of the resource that has version increased on every change.
+func FromVersion(version int) string {
+	return quoted(strconv.Itoa(version))
+}
+
+// FromContent makes strong ETag from the hash of the response body, it is used for the lists that have no own version.
+func FromContent(content []byte) string {
+	hash := sha256.Sum256(content)
+	return quoted(hex.EncodeToString(hash[:hashLength]))
+}
+
+func Set(w http.ResponseWriter, value string) {
+	w.Header().Set(header, value)
+}
+
+// IfMatchVersion returns version from If-Match header, it is zero if the header is missing or is "*".
+// Only single strong ETag made by FromVersion can match, otherwise ErrPreconditionFailed is returned.
+func IfMatchVersion(r *http.Request) (int, error) {
+	value := strings.TrimSpace(r.Header.Get(ifMatchHeader))
+	if value == "" || value == anyValue {
+		return 0, nil
+	}
+
+	unquoted, ok := strings.CutPrefix(value, `"`)
+	if !ok {
+		return 0, ErrPreconditionFailed
+	}
+
+	unquoted, ok = strings.CutSuffix(unquoted, `"`)
+	if !ok {
+		return 0, ErrPreconditionFailed
+	}
+
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version <= 0 {
+		return 0, ErrPreconditionFailed
+	}
+
+	return version, nil
+}
+
+// NotModified reports if If-None-Match header has the value, weak ETags are compared as strong ones as RFC 9110 requires.
+func NotModified(r *http.Request, value string) bool {
+	header := r.Header.Get(ifNoneMatchHeader)
+	if header == "" {
+		return false
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == anyValue || strings.TrimPrefix(tag, weakPrefix) == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WriteJSON writes data with the ETag, or only 304 status if the client already has the same data.
+func WriteJSON(w http.ResponseWriter, r *http.Request, statusCode int, data any, value string) error {
+	const (
+		contentTypeHeader = "Content-Type"
+		contentTypeValue  = "application/json"
+	)
+
+	Set(w, value)
+	if NotModified(r, value) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return errors.Join(rwjson.ErrWriting, err)
+	}
+
+	w.Header().Add(contentTypeHeader, contentTypeValue)
+	w.WriteHeader(statusCode)
+	if _, err = w.Write(body); err != nil {
+		return errors.Join(rwjson.ErrWriting, err)
+	}
+
+	return nil
+}
+
+// WriteContentJSON writes data with the ETag made from its content, or only 304 status if the client already has the same data.
+func WriteContentJSON(w http.ResponseWriter, r *http.Request, statusCode int, data any) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return errors.Join(rwjson.ErrWriting, err)
+	}
+
+	return WriteJSON(w, r, statusCode, json.RawMessage(body), FromContent(body))
+}
+
+func quoted(value string) string {
+	return `"` + value + `"`
+}
@@ -0,0 +1,113 @@
+package etag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		wantVersion int
+		wantErr     error
+	}{
+		{
+			name:        "no header",
+			wantVersion: 0,
+		},
+		{
+			name:        "any version",
+			header:      "*",
+			wantVersion: 0,
+		},
+		{
+			name:        "version",
+			header:      `"12"`,
+			wantVersion: 12,
+		},
+		{
+			name:    "weak etag never matches",
+			header:  `W/"12"`,
+			wantErr: ErrPreconditionFailed,
+		},
+		{
+			name:    "not quoted",
+			header:  `12`,
+			wantErr: ErrPreconditionFailed,
+		},
+		{
+			name:    "not a version",
+			header:  FromContent([]byte("content")),
+			wantErr: ErrPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/items/id", nil)
+			if tt.header != "" {
+				r.Header.Set(ifMatchHeader, tt.header)
+			}
+
+			version, err := IfMatchVersion(r)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantVersion, version)
+		})
+	}
+}
+
+func TestWriteContentJSON(t *testing.T) {
+	data := []string{"milk", "eggs"}
+
+	first := httptest.NewRecorder()
+	err := WriteContentJSON(first, httptest.NewRequest(http.MethodGet, "/items", nil), http.StatusOK, data)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.JSONEq(t, `["milk","eggs"]`, first.Body.String())
+
+	tag := first.Header().Get(header)
+	require.NotEmpty(t, tag)
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantCode    int
+	}{
+		{
+			name:        "same etag",
+			ifNoneMatch: tag,
+			wantCode:    http.StatusNotModified,
+		},
+		{
+			name:        "weak etag in list",
+			ifNoneMatch: `"other", W/` + tag,
+			wantCode:    http.StatusNotModified,
+		},
+		{
+			name:        "other etag",
+			ifNoneMatch: `"other"`,
+			wantCode:    http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/items", nil)
+			r.Header.Set(ifNoneMatchHeader, tt.ifNoneMatch)
+			w := httptest.NewRecorder()
+
+			err := WriteContentJSON(w, r, http.StatusOK, data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCode, w.Code)
+			assert.Equal(t, tag, w.Header().Get(header))
+			if tt.wantCode == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const ExchangeName = "domain_events"
+
+type Event interface {
+	EventType() string
+	SchemaVersion() int
+}
+
+type Envelope struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	SchemaVersion int             `json:"schema_version"`
+	Source        string          `json:"source"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+func newEnvelope(id, source string, event Event) (Envelope, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return Envelope{}, err
+	}
+
+	return Envelope{
+		ID:            id,
+		Type:          event.EventType(),
+		SchemaVersion: event.SchemaVersion(),
+		Source:        source,
+		OccurredAt:    time.Now().UTC(),
+		Payload:       payload,
+	}, nil
+}
+
+func (e Envelope) Decode(payload any) error {
+	return json.Unmarshal(e.Payload, payload)
+}
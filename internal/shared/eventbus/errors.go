@@ -0,0 +1,8 @@
+package eventbus
+
+import "errors"
+
+var (
+	ErrMalformedEvent           = errors.New("event cannot be decoded")
+	ErrUnsupportedSchemaVersion = errors.New("event schema version is not supported")
+)
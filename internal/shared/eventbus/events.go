@@ -0,0 +1,58 @@
+package eventbus
+
+import "time"
+
+// Only user.deleted is consumed for now, by the App Logic API. Publishing fails while no queue is bound for the event type,
+// so item.expired stays unannounced and is published again on the next check, and failed user.email_changed is logged.
+const (
+	UserDeletedType      = "user.deleted"
+	UserEmailChangedType = "user.email_changed"
+	ItemExpiredType      = "item.expired"
+)
+
+const (
+	UserDeletedVersion      = 1
+	UserEmailChangedVersion = 1
+	ItemExpiredVersion      = 1
+)
+
+type UserDeleted struct {
+	UserID string `json:"user_id"`
+}
+
+func (UserDeleted) EventType() string {
+	return UserDeletedType
+}
+
+func (UserDeleted) SchemaVersion() int {
+	return UserDeletedVersion
+}
+
+type UserEmailChanged struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+func (UserEmailChanged) EventType() string {
+	return UserEmailChangedType
+}
+
+func (UserEmailChanged) SchemaVersion() int {
+	return UserEmailChangedVersion
+}
+
+type ItemExpired struct {
+	ItemID         string    `json:"item_id"`
+	StorageID      string    `json:"storage_id"`
+	OwnerID        string    `json:"owner_id"`
+	Name           string    `json:"name"`
+	ExpirationDate time.Time `json:"expiration_date"`
+}
+
+func (ItemExpired) EventType() string {
+	return ItemExpiredType
+}
+
+func (ItemExpired) SchemaVersion() int {
+	return ItemExpiredVersion
+}
@@ -0,0 +1,27 @@
+package eventbus
+
+import (
+	"context"
+
+	"github.com/zhuboris/never-expires/internal/shared/rabbitmq"
+)
+
+type jobExecutor interface {
+	ExecuteJobOnMessages(ctx context.Context, job rabbitmq.Job) error
+}
+
+type Listener struct {
+	subscriber *Subscriber
+	consumer   jobExecutor
+}
+
+func NewListener(subscriber *Subscriber, consumer jobExecutor) *Listener {
+	return &Listener{
+		subscriber: subscriber,
+		consumer:   consumer,
+	}
+}
+
+func (l Listener) RunWithCtx(ctx context.Context) error {
+	return l.consumer.ExecuteJobOnMessages(ctx, l.subscriber.Consume)
+}
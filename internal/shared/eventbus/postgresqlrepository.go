@@ -0,0 +1,48 @@
+package eventbus
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/zhuboris/never-expires/internal/shared/postgresql"
+)
+
+type PostgresqlRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresqlRepository(pool *pgxpool.Pool) (*PostgresqlRepository, error) {
+	if pool == nil {
+		return nil, postgresql.ErrPoolInitRequired
+	}
+
+	return &PostgresqlRepository{
+		pool: pool,
+	}, nil
+}
+
+func (r PostgresqlRepository) isProcessed(ctx context.Context, consumer, eventID string) (bool, error) {
+	const sql = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM processed_events
+			WHERE consumer = $1 AND event_id = $2
+		);
+	`
+
+	var isProcessed bool
+	err := r.pool.QueryRow(ctx, sql, consumer, eventID).Scan(&isProcessed)
+	return isProcessed, postgresql.HandleQueryErr(err)
+}
+
+func (r PostgresqlRepository) markProcessed(ctx context.Context, consumer, eventID string) error {
+	const sql = `
+		INSERT INTO processed_events (consumer, event_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING;
+	`
+
+	_, err := r.pool.Exec(ctx, sql, consumer, eventID)
+	return postgresql.HandleQueryErr(err)
+}
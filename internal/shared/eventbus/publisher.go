@@ -0,0 +1,45 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+type routedPublisher interface {
+	PublishWithKey(ctx context.Context, routingKey, messageID string, msg []byte) error
+}
+
+type Publisher struct {
+	publisher routedPublisher
+	source    string
+}
+
+func NewPublisher(publisher routedPublisher, source string) *Publisher {
+	return &Publisher{
+		publisher: publisher,
+		source:    source,
+	}
+}
+
+// Publish sends event with a new random id, so its redelivery is deduplicated but a repeated Publish is not.
+func (p Publisher) Publish(ctx context.Context, event Event) error {
+	return p.PublishWithID(ctx, uuid.New().String(), event)
+}
+
+// PublishWithID sends event with the id set by the caller, it is also used as the message id.
+// Publishing the same fact again with the same id lets subscribers skip it as already handled.
+func (p Publisher) PublishWithID(ctx context.Context, id string, event Event) error {
+	envelope, err := newEnvelope(id, p.source, event)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	return p.publisher.PublishWithKey(ctx, envelope.Type, envelope.ID, body)
+}
@@ -0,0 +1,86 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	errPublish := errors.New("broker is down")
+
+	tests := []struct {
+		name       string
+		id         string
+		event      Event
+		publishErr error
+		wantKey    string
+		wantErr    error
+	}{
+		{
+			name:    "user deleted",
+			event:   UserDeleted{UserID: "user-id"},
+			wantKey: UserDeletedType,
+		},
+		{
+			name:    "user email changed",
+			event:   UserEmailChanged{UserID: "user-id", Email: "new@test.com"},
+			wantKey: UserEmailChangedType,
+		},
+		{
+			name:    "event id is set by caller",
+			id:      "event-id",
+			event:   UserDeleted{UserID: "user-id"},
+			wantKey: UserDeletedType,
+		},
+		{
+			name:       "publishing failed",
+			event:      ItemExpired{ItemID: "item-id"},
+			publishErr: errPublish,
+			wantKey:    ItemExpiredType,
+			wantErr:    errPublish,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				published []byte
+				messageID string
+			)
+			publisher := NewMockroutedPublisher(t)
+			publisher.EXPECT().
+				PublishWithKey(mock.Anything, tt.wantKey, mock.AnythingOfType("string"), mock.Anything).
+				Run(func(_ context.Context, _, id string, msg []byte) { messageID, published = id, msg }).
+				Return(tt.publishErr)
+
+			var err error
+			if tt.id != "" {
+				err = NewPublisher(publisher, "test").PublishWithID(context.Background(), tt.id, tt.event)
+			} else {
+				err = NewPublisher(publisher, "test").Publish(context.Background(), tt.event)
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			var envelope Envelope
+			require.NoError(t, json.Unmarshal(published, &envelope))
+			assert.NotEmpty(t, envelope.ID)
+			assert.Equal(t, envelope.ID, messageID)
+			if tt.id != "" {
+				assert.Equal(t, tt.id, envelope.ID)
+			}
+			assert.Equal(t, tt.event.EventType(), envelope.Type)
+			assert.Equal(t, tt.event.SchemaVersion(), envelope.SchemaVersion)
+			assert.Equal(t, "test", envelope.Source)
+
+			wantPayload, err := json.Marshal(tt.event)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(wantPayload), string(envelope.Payload))
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type processedEvents interface {
+	isProcessed(ctx context.Context, consumer, eventID string) (bool, error)
+	markProcessed(ctx context.Context, consumer, eventID string) error
+}
+
+type Handler func(ctx context.Context, envelope Envelope) error
+
+type handlerEntry struct {
+	maxVersion int
+	handle     Handler
+}
+
+type Subscriber struct {
+	name      string
+	processed processedEvents
+	handlers  map[string]handlerEntry
+	logger    *zap.Logger
+}
+
+func NewSubscriber(name string, processed processedEvents, logger *zap.Logger) *Subscriber {
+	return &Subscriber{
+		name:      name,
+		processed: processed,
+		handlers:  make(map[string]handlerEntry),
+		logger:    logger,
+	}
+}
+
+// On registers handler for the event type, events with schema version above maxVersion are not handled.
+func (s *Subscriber) On(eventType string, maxVersion int, handler Handler) {
+	s.handlers[eventType] = handlerEntry{
+		maxVersion: maxVersion,
+		handle:     handler,
+	}
+}
+
+func (s *Subscriber) QueueName() string {
+	return QueueName(s.name)
+}
+
+// QueueName returns the queue of named subscriber, producers declare it too when their events must not be lost before the subscriber starts.
+func QueueName(subscriber string) string {
+	return subscriber + "." + ExchangeName
+}
+
+func (s *Subscriber) EventTypes() []string {
+	types := make([]string, 0, len(s.handlers))
+	for eventType := range s.handlers {
+		types = append(types, eventType)
+	}
+
+	sort.Strings(types)
+	return types
+}
+
+// Consume is a rabbitmq job. Events that can never be handled are logged and acknowledged,
+// only handling failures are returned to be redelivered.
+func (s *Subscriber) Consume(ctx context.Context, messageID string, message []byte) error {
+	var envelope Envelope
+	if err := json.Unmarshal(message, &envelope); err != nil {
+		s.logSkipped(messageID, errors.Join(ErrMalformedEvent, err))
+		return nil
+	}
+
+	entry, ok := s.handlers[envelope.Type]
+	if !ok {
+		return nil
+	}
+
+	if envelope.SchemaVersion < 1 || envelope.SchemaVersion > entry.maxVersion {
+		s.logSkipped(envelope.ID, fmt.Errorf("%w: %s v%d", ErrUnsupportedSchemaVersion, envelope.Type, envelope.SchemaVersion))
+		return nil
+	}
+
+	isProcessed, err := s.processed.isProcessed(ctx, s.name, envelope.ID)
+	if err != nil || isProcessed {
+		return err
+	}
+
+	err = entry.handle(ctx, envelope)
+	if err == nil {
+		err = s.processed.markProcessed(ctx, s.name, envelope.ID)
+	}
+
+	s.logHandling(envelope, err)
+	return err
+}
+
+func (s *Subscriber) logSkipped(eventID string, err error) {
+	s.logger.Error("Event is skipped", zap.String("eventID", eventID), zap.Error(err))
+}
+
+func (s *Subscriber) logHandling(envelope Envelope, err error) {
+	msg := "Event handled"
+	logLvl := zapcore.InfoLevel
+	if err != nil {
+		msg = "Failed to handle event"
+		logLvl = zapcore.ErrorLevel
+	}
+
+	s.logger.Log(logLvl, msg, zap.String("eventID", envelope.ID), zap.String("eventType", envelope.Type), zap.Error(err))
+}
@@ -0,0 +1,128 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testConsumer = "test"
+
+func TestSubscriber_Consume(t *testing.T) {
+	var (
+		errHandle = errors.New("handle failed")
+		errRepo   = errors.New("db is down")
+	)
+
+	tests := []struct {
+		name            string
+		message         []byte
+		isProcessed     bool
+		isProcessedErr  error
+		handleErr       error
+		wantHandled     bool
+		wantMarked      bool
+		wantRepoChecked bool
+		wantErr         error
+	}{
+		{
+			name:            "new event is handled and marked",
+			message:         testMessage(t, UserDeletedType, UserDeletedVersion),
+			wantRepoChecked: true,
+			wantHandled:     true,
+			wantMarked:      true,
+		},
+		{
+			name:            "processed event is skipped",
+			message:         testMessage(t, UserDeletedType, UserDeletedVersion),
+			isProcessed:     true,
+			wantRepoChecked: true,
+		},
+		{
+			name:            "failed handling is returned for redelivery",
+			message:         testMessage(t, UserDeletedType, UserDeletedVersion),
+			handleErr:       errHandle,
+			wantRepoChecked: true,
+			wantHandled:     true,
+			wantErr:         errHandle,
+		},
+		{
+			name:            "repository error is returned",
+			message:         testMessage(t, UserDeletedType, UserDeletedVersion),
+			isProcessedErr:  errRepo,
+			wantRepoChecked: true,
+			wantErr:         errRepo,
+		},
+		{
+			name:    "newer schema version is skipped",
+			message: testMessage(t, UserDeletedType, UserDeletedVersion+1),
+		},
+		{
+			name:    "event without handler is ignored",
+			message: testMessage(t, ItemExpiredType, ItemExpiredVersion),
+		},
+		{
+			name:    "malformed message is skipped",
+			message: []byte("not json"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockprocessedEvents(t)
+			if tt.wantRepoChecked {
+				repo.EXPECT().isProcessed(context.Background(), testConsumer, "event-id").Return(tt.isProcessed, tt.isProcessedErr)
+			}
+
+			if tt.wantMarked {
+				repo.EXPECT().markProcessed(context.Background(), testConsumer, "event-id").Return(nil)
+			}
+
+			var isHandled bool
+			subscriber := NewSubscriber(testConsumer, repo, zap.NewNop())
+			subscriber.On(UserDeletedType, UserDeletedVersion, func(_ context.Context, envelope Envelope) error {
+				var event UserDeleted
+				require.NoError(t, envelope.Decode(&event))
+				assert.Equal(t, "user-id", event.UserID)
+
+				isHandled = true
+				return tt.handleErr
+			})
+
+			err := subscriber.Consume(context.Background(), "event-id", tt.message)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantHandled, isHandled)
+		})
+	}
+}
+
+func TestSubscriber_EventTypes(t *testing.T) {
+	subscriber := NewSubscriber(testConsumer, nil, zap.NewNop())
+	subscriber.On(UserDeletedType, UserDeletedVersion, nil)
+	subscriber.On(ItemExpiredType, ItemExpiredVersion, nil)
+
+	assert.Equal(t, []string{ItemExpiredType, UserDeletedType}, subscriber.EventTypes())
+	assert.Equal(t, "test.domain_events", subscriber.QueueName())
+}
+
+func testMessage(t *testing.T, eventType string, version int) []byte {
+	t.Helper()
+
+	payload, err := json.Marshal(UserDeleted{UserID: "user-id"})
+	require.NoError(t, err)
+
+	message, err := json.Marshal(Envelope{
+		ID:            "event-id",
+		Type:          eventType,
+		SchemaVersion: version,
+		Payload:       payload,
+	})
+	require.NoError(t, err)
+
+	return message
+}
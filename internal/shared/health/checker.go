@@ -0,0 +1,96 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultTimeout  = 2 * time.Second
+	defaultCacheTTL = 5 * time.Second
+)
+
+type CheckFunc func(ctx context.Context) error
+
+type Report struct {
+	Healthy      bool               `json:"healthy"`
+	ShuttingDown bool               `json:"shuttingDown,omitempty"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+}
+
+func (r Report) withoutErrors() Report {
+	dependencies := make([]DependencyStatus, len(r.Dependencies))
+	for i, status := range r.Dependencies {
+		status.LastError = ""
+		dependencies[i] = status
+	}
+
+	r.Dependencies = dependencies
+	return r
+}
+
+// Checker checks service dependencies, results are cached for a short time,
+// so frequent probes do not reach databases more than once per cache period.
+type Checker struct {
+	cacheTTL     time.Duration
+	now          func() time.Time
+	dependencies []*dependency
+	shuttingDown atomic.Bool
+}
+
+func NewChecker() *Checker {
+	return &Checker{
+		cacheTTL: defaultCacheTTL,
+		now:      time.Now,
+	}
+}
+
+func (c *Checker) Add(name string, check CheckFunc) {
+	c.AddWithTimeout(name, defaultTimeout, check)
+}
+
+func (c *Checker) AddWithTimeout(name string, timeout time.Duration, check CheckFunc) {
+	c.dependencies = append(c.dependencies, &dependency{
+		name:    name,
+		timeout: timeout,
+		check:   check,
+	})
+}
+
+// SetShuttingDown makes the service not ready until it stops, so load balancer stops sending new requests to it.
+func (c *Checker) SetShuttingDown() {
+	c.shuttingDown.Store(true)
+}
+
+func (c *Checker) Check(ctx context.Context) Report {
+	var (
+		report = Report{
+			Healthy:      true,
+			Dependencies: make([]DependencyStatus, len(c.dependencies)),
+		}
+		wg sync.WaitGroup
+	)
+
+	wg.Add(len(c.dependencies))
+	for i, dep := range c.dependencies {
+		i, dep := i, dep
+		go func() {
+			defer wg.Done()
+			report.Dependencies[i] = dep.status(ctx, c.cacheTTL, c.now)
+		}()
+	}
+
+	wg.Wait()
+	for _, status := range report.Dependencies {
+		report.Healthy = report.Healthy && status.Healthy
+	}
+
+	if c.shuttingDown.Load() {
+		report.Healthy = false
+		report.ShuttingDown = true
+	}
+
+	return report
+}
@@ -0,0 +1,123 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker_Check(t *testing.T) {
+	errDown := errors.New("database is down")
+
+	tests := []struct {
+		name        string
+		checks      map[string]error
+		wantHealthy bool
+	}{
+		{
+			name:        "no dependencies",
+			wantHealthy: true,
+		},
+		{
+			name:        "all dependencies are available",
+			checks:      map[string]error{"postgres": nil, "redis": nil},
+			wantHealthy: true,
+		},
+		{
+			name:        "one dependency is unavailable",
+			checks:      map[string]error{"postgres": nil, "redis": errDown},
+			wantHealthy: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker()
+			for name, err := range tt.checks {
+				err := err
+				checker.Add(name, func(context.Context) error {
+					return err
+				})
+			}
+
+			report := checker.Check(context.Background())
+			assert.Equal(t, tt.wantHealthy, report.Healthy)
+			require.Len(t, report.Dependencies, len(tt.checks))
+			for _, status := range report.Dependencies {
+				wantErr := tt.checks[status.Name]
+				assert.Equal(t, wantErr == nil, status.Healthy)
+				assert.Equal(t, wantErr == nil, status.LastSuccess != nil)
+				if wantErr != nil {
+					assert.Equal(t, wantErr.Error(), status.LastError)
+				}
+			}
+		})
+	}
+}
+
+func TestChecker_CheckIsCached(t *testing.T) {
+	var (
+		errDown = errors.New("redis is down")
+		now     = time.Date(2023, 9, 1, 12, 0, 0, 0, time.UTC)
+		calls   int
+		result  error
+	)
+
+	checker := NewChecker()
+	checker.now = func() time.Time {
+		return now
+	}
+
+	checker.Add("redis", func(context.Context) error {
+		calls++
+		return result
+	})
+
+	ctx := context.Background()
+	status := checker.Check(ctx).Dependencies[0]
+	assert.True(t, status.Healthy)
+	assert.Equal(t, 1, calls)
+
+	result = errDown
+	status = checker.Check(ctx).Dependencies[0]
+	assert.True(t, status.Healthy, "cached result is expected")
+	assert.Equal(t, 1, calls)
+
+	lastSuccess := now
+	now = now.Add(defaultCacheTTL)
+	status = checker.Check(ctx).Dependencies[0]
+	assert.False(t, status.Healthy)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, errDown.Error(), status.LastError)
+	require.NotNil(t, status.LastSuccess)
+	assert.Equal(t, lastSuccess, *status.LastSuccess)
+}
+
+func TestChecker_CheckTimeout(t *testing.T) {
+	checker := NewChecker()
+	checker.AddWithTimeout("rabbitMQ", time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	status := checker.Check(context.Background()).Dependencies[0]
+	assert.False(t, status.Healthy)
+	assert.Equal(t, context.DeadlineExceeded.Error(), status.LastError)
+}
+
+func TestChecker_CheckIgnoresCanceledProbe(t *testing.T) {
+	checker := NewChecker()
+	checker.Add("postgres", func(ctx context.Context) error {
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report := checker.Check(ctx)
+	assert.True(t, report.Healthy)
+}
@@ -0,0 +1,80 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type DependencyStatus struct {
+	Name        string     `json:"name"`
+	Healthy     bool       `json:"healthy"`
+	LatencyMS   float64    `json:"latency_ms"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	CheckedAt   time.Time  `json:"checked_at"`
+}
+
+type dependency struct {
+	name    string
+	timeout time.Duration
+	check   CheckFunc
+
+	mu          sync.Mutex
+	checkedAt   time.Time
+	latency     time.Duration
+	lastErr     error
+	lastSuccess time.Time
+}
+
+// status returns cached result if it is fresh, otherwise runs the check.
+// Concurrent callers wait for the running check instead of starting their own.
+func (d *dependency) status(ctx context.Context, cacheTTL time.Duration, now func() time.Time) DependencyStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.checkedAt.IsZero() || now().Sub(d.checkedAt) >= cacheTTL {
+		d.run(ctx, now)
+	}
+
+	return d.snapshot()
+}
+
+func (d *dependency) run(ctx context.Context, now func() time.Time) {
+	// Result is shared between probes, so it must not fail because one of them has gone.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), d.timeout)
+	defer cancel()
+
+	startTime := now()
+	err := d.check(ctx)
+	if err == nil {
+		err = ctx.Err()
+	}
+
+	d.checkedAt = now()
+	d.latency = d.checkedAt.Sub(startTime)
+	d.lastErr = err
+	if err == nil {
+		d.lastSuccess = d.checkedAt
+	}
+}
+
+func (d *dependency) snapshot() DependencyStatus {
+	status := DependencyStatus{
+		Name:      d.name,
+		Healthy:   d.lastErr == nil,
+		LatencyMS: float64(d.latency) / float64(time.Millisecond),
+		CheckedAt: d.checkedAt,
+	}
+
+	if d.lastErr != nil {
+		status.LastError = d.lastErr.Error()
+	}
+
+	if !d.lastSuccess.IsZero() {
+		lastSuccess := d.lastSuccess
+		status.LastSuccess = &lastSuccess
+	}
+
+	return status
+}
@@ -0,0 +1,91 @@
+package health
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/zhuboris/never-expires/internal/shared/rwjson"
+)
+
+const (
+	LivenessRoute  = "/livez"
+	ReadinessRoute = "/readyz"
+	StatusRoute    = "/status"
+)
+
+const verboseQueryKey = "verbose"
+
+type handlerRegistrar interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
+
+// Register adds liveness, readiness and status endpoints to the mux of the internal server.
+func (c *Checker) Register(mux handlerRegistrar) {
+	c.register(mux, false)
+}
+
+// RegisterPublic adds the same endpoints to the mux of a public API,
+// verbose status there does not contain error texts, they can expose addresses and credentials of dependencies.
+func (c *Checker) RegisterPublic(mux handlerRegistrar) {
+	c.register(mux, true)
+}
+
+func (c *Checker) register(mux handlerRegistrar, hideErrors bool) {
+	mux.HandleFunc(LivenessRoute, onlyGet(c.handleLiveness))
+	mux.HandleFunc(ReadinessRoute, onlyGet(c.handleReadiness))
+	mux.HandleFunc(StatusRoute, onlyGet(c.statusHandler(hideErrors)))
+}
+
+// handleLiveness reports only that the process is able to serve requests, dependencies are not checked,
+// so a broken database does not make the service to be restarted.
+func (c *Checker) handleLiveness(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c *Checker) handleReadiness(w http.ResponseWriter, r *http.Request) {
+	report := c.Check(r.Context())
+	w.WriteHeader(statusCode(report))
+}
+
+func (c *Checker) statusHandler(hideErrors bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := c.Check(r.Context())
+		if !isVerbose(r) {
+			w.WriteHeader(statusCode(report))
+			return
+		}
+
+		if hideErrors {
+			report = report.withoutErrors()
+		}
+
+		_ = rwjson.WriteJSON(w, statusCode(report), report)
+	}
+}
+
+func onlyGet(next http.HandlerFunc) http.HandlerFunc {
+	const allowHeader = "Allow"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set(allowHeader, http.MethodGet+", "+http.MethodHead)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func isVerbose(r *http.Request) bool {
+	verbose, err := strconv.ParseBool(r.URL.Query().Get(verboseQueryKey))
+	return err == nil && verbose
+}
+
+func statusCode(report Report) int {
+	if report.Healthy {
+		return http.StatusOK
+	}
+
+	return http.StatusServiceUnavailable
+}
@@ -0,0 +1,137 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker_Register(t *testing.T) {
+	errDown := errors.New("database is down")
+
+	tests := []struct {
+		name          string
+		method        string
+		target        string
+		checkErr      error
+		shuttingDown  bool
+		public        bool
+		wantCode      int
+		wantBody      bool
+		wantLastError string
+	}{
+		{
+			name:     "liveness does not depend on dependencies",
+			method:   http.MethodGet,
+			target:   LivenessRoute,
+			checkErr: errDown,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "ready",
+			method:   http.MethodGet,
+			target:   ReadinessRoute,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "not ready",
+			method:   http.MethodGet,
+			target:   ReadinessRoute,
+			checkErr: errDown,
+			wantCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:         "not ready while shutting down",
+			method:       http.MethodGet,
+			target:       ReadinessRoute,
+			shuttingDown: true,
+			wantCode:     http.StatusServiceUnavailable,
+		},
+		{
+			name:         "liveness does not change while shutting down",
+			method:       http.MethodGet,
+			target:       LivenessRoute,
+			shuttingDown: true,
+			wantCode:     http.StatusOK,
+		},
+		{
+			name:     "status without details",
+			method:   http.MethodGet,
+			target:   StatusRoute,
+			checkErr: errDown,
+			wantCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:     "verbose status",
+			method:   http.MethodGet,
+			target:   StatusRoute + "?verbose=1",
+			wantCode: http.StatusOK,
+			wantBody: true,
+		},
+		{
+			name:          "verbose status of unavailable service",
+			method:        http.MethodGet,
+			target:        StatusRoute + "?verbose=true",
+			checkErr:      errDown,
+			wantCode:      http.StatusServiceUnavailable,
+			wantBody:      true,
+			wantLastError: errDown.Error(),
+		},
+		{
+			name:     "verbose public status hides error",
+			method:   http.MethodGet,
+			target:   StatusRoute + "?verbose=1",
+			public:   true,
+			checkErr: errDown,
+			wantCode: http.StatusServiceUnavailable,
+			wantBody: true,
+		},
+		{
+			name:     "method not allowed",
+			method:   http.MethodPost,
+			target:   StatusRoute,
+			wantCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker()
+			checker.Add("postgres", func(context.Context) error {
+				return tt.checkErr
+			})
+			if tt.shuttingDown {
+				checker.SetShuttingDown()
+			}
+
+			mux := http.NewServeMux()
+			if tt.public {
+				checker.RegisterPublic(mux)
+			} else {
+				checker.Register(mux)
+			}
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(tt.method, tt.target, nil))
+			assert.Equal(t, tt.wantCode, w.Code)
+
+			if !tt.wantBody {
+				assert.Empty(t, w.Body.String())
+				return
+			}
+
+			var report Report
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+			assert.Equal(t, tt.checkErr == nil, report.Healthy)
+			require.Len(t, report.Dependencies, 1)
+			assert.Equal(t, "postgres", report.Dependencies[0].Name)
+			assert.Equal(t, tt.wantLastError, report.Dependencies[0].LastError)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package health
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/zhuboris/never-expires/internal/shared/runapi"
+)
+
+type Config struct {
+	Address string `env:"HEALTH_SERVER_ADDRESS" required:"true"`
+}
+
+// Server exposes health endpoints for the services that do not run an API.
+type Server struct {
+	addr    string
+	checker *Checker
+	server  *http.Server
+}
+
+func NewServer(config Config, checker *Checker) *Server {
+	return &Server{
+		addr:    config.Address,
+		checker: checker,
+	}
+}
+
+func (s *Server) RunWithCtx(ctx context.Context) error {
+	return runapi.WithContext(ctx, s.run, func(ctx context.Context) error {
+		return s.server.Shutdown(ctx)
+	})
+}
+
+func (s *Server) run() error {
+	mux := http.NewServeMux()
+	s.checker.Register(mux)
+
+	s.server = &http.Server{
+		Addr:    s.addr,
+		Handler: mux,
+	}
+
+	return s.server.ListenAndServe()
+}
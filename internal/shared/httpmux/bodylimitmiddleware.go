@@ -0,0 +1,43 @@
+package httpmux
+
+import (
+	"io"
+	"net/http"
+)
+
+// DefaultMaxBodySize is used for routes without MaxBodySize middleware until SetMaxBodySize is called.
+const DefaultMaxBodySize int64 = 64 << 10
+
+// limitedBody keeps the original body, so route limit replaces the default one instead of being capped by it.
+type limitedBody struct {
+	io.ReadCloser
+
+	original io.ReadCloser
+}
+
+// MaxBodySize overrides the default body size limit for a route, reading above the limit fails with reqbody.ErrBodyTooLarge.
+func MaxBodySize(limit int64) Middleware {
+	return func(f errorHandledFunc) errorHandledFunc {
+		return bodyLimitMiddleware(limit, f)
+	}
+}
+
+func bodyLimitMiddleware(limit int64, f errorHandledFunc) errorHandledFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		if limit <= 0 || r.Body == nil {
+			return f(w, r)
+		}
+
+		original := r.Body
+		if body, ok := original.(limitedBody); ok {
+			original = body.original
+		}
+
+		r.Body = limitedBody{
+			ReadCloser: http.MaxBytesReader(w, original, limit),
+			original:   original,
+		}
+
+		return f(w, r)
+	}
+}
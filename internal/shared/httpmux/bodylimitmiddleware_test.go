@@ -0,0 +1,81 @@
+package httpmux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zhuboris/never-expires/internal/shared/reqbody"
+)
+
+func TestMaxBodySize(t *testing.T) {
+	const defaultLimit = 16
+
+	type body struct {
+		Name string `json:"name"`
+	}
+
+	var (
+		shortBody = `{"name": "a"}`
+		longBody  = `{"name": "` + strings.Repeat("a", 32) + `"}`
+		decode    = func(w http.ResponseWriter, r *http.Request) error {
+			return reqbody.Decode(new(body), r.Body)
+		}
+	)
+
+	tests := []struct {
+		name        string
+		body        string
+		middlewares []Middleware
+		wantErr     error
+	}{
+		{
+			name:        "body within default limit",
+			body:        shortBody,
+			middlewares: []Middleware{MaxBodySize(defaultLimit)},
+		},
+		{
+			name:        "body above default limit",
+			body:        longBody,
+			middlewares: []Middleware{MaxBodySize(defaultLimit)},
+			wantErr:     reqbody.ErrBodyTooLarge,
+		},
+		{
+			name:        "route limit replaces default one",
+			body:        longBody,
+			middlewares: []Middleware{MaxBodySize(defaultLimit), MaxBodySize(1024)},
+		},
+		{
+			name:        "route limit is lower than default one",
+			body:        shortBody,
+			middlewares: []Middleware{MaxBodySize(1024), MaxBodySize(4)},
+			wantErr:     reqbody.ErrBodyTooLarge,
+		},
+		{
+			name:        "zero limit disables limiting",
+			body:        longBody,
+			middlewares: []Middleware{MaxBodySize(0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				w       = httptest.NewRecorder()
+				r       = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+				handler = applyMiddlewares(decode, tt.middlewares...)
+			)
+
+			err := handler(w, r)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
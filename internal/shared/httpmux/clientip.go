@@ -0,0 +1,31 @@
+package httpmux
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ClientIP returns address of the client set by the proxy or the remote address of the request, it is empty if nothing is found.
+func ClientIP(r *http.Request) string {
+	if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+		return realIP
+	}
+
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		ips := strings.Split(forwarded, ",")
+		return strings.TrimSpace(ips[0])
+	}
+
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return ""
+	}
+
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return ""
+	}
+
+	return parsedIP.String()
+}
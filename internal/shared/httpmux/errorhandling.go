@@ -23,6 +23,7 @@ type RequestingResult struct {
 	statusCode        int
 	internalErrorCode StatusCode
 	responseMsg       any
+	headers           http.Header
 	shouldResponse    bool
 	resultType        resultType
 }
@@ -32,6 +33,12 @@ func (r RequestingResult) AddToRespond(w http.ResponseWriter) {
 		return
 	}
 
+	for key, values := range r.headers {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+
 	if r.responseMsg == nil {
 		w.WriteHeader(r.statusCode)
 		return
@@ -82,6 +89,15 @@ func (rb *RequestingResultBuilder) AddInternalErrorCode(code StatusCode) *Reques
 	return rb
 }
 
+func (rb *RequestingResultBuilder) AddHeader(key, value string) *RequestingResultBuilder {
+	if rb.result.headers == nil {
+		rb.result.headers = make(http.Header)
+	}
+
+	rb.result.headers.Add(key, value)
+	return rb
+}
+
 func (rb *RequestingResultBuilder) AddError(err error) *RequestingResultBuilder {
 	rb.result.err = err
 	return rb
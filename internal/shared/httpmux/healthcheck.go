@@ -0,0 +1,10 @@
+package httpmux
+
+import "github.com/zhuboris/never-expires/internal/shared/health"
+
+// HandleHealth registers liveness, readiness and status endpoints, they are served
+// without the API error handling, so frequent probes are not logged and counted as requests.
+// Errors of dependencies are not shown to public clients.
+func (m *Mux) HandleHealth(checker *health.Checker) {
+	checker.RegisterPublic(m)
+}
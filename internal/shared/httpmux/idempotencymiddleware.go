@@ -0,0 +1,211 @@
+package httpmux
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/zhuboris/never-expires/internal/shared/idempotency"
+	"github.com/zhuboris/never-expires/internal/shared/reqbody"
+)
+
+const idempotencyServiceName = "idempotencyMiddleware"
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyLockDuration   = time.Minute
+	idempotencyReplayedResult = "true"
+)
+
+var (
+	ErrInvalidIdempotencyKey  = errors.New("idempotency key must not be longer than 255 characters")
+	ErrIdempotencyKeyReused   = errors.New("idempotency key is already used for another request")
+	ErrIdempotencyKeyInFlight = errors.New("request with the same idempotency key is still processing")
+)
+
+// Idempotent replays saved response to the retries of successful request with the same Idempotency-Key header
+// made by the same user during ttl, without the header or the store set by SetIdempotencyStore requests are handled as usual.
+// It works only after Authorize middleware, requests without user are scoped by IP.
+// Failed requests are not saved, so they can be retried with the same key.
+func (m *Mux) Idempotent(ttl time.Duration) Middleware {
+	return func(f errorHandledFunc) errorHandledFunc {
+		return m.idempotencyMiddleware(ttl, f)
+	}
+}
+
+func (m *Mux) idempotencyMiddleware(ttl time.Duration, next errorHandledFunc) errorHandledFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		key := r.Header.Get(idempotencyKeyHeader)
+		if m.idempotencyStore == nil || key == "" || !isMutatingMethod(r.Method) {
+			return next(w, r)
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			return ErrInvalidIdempotencyKey
+		}
+
+		var (
+			replayed bool
+			storeErr error
+			ctx      = r.Context()
+		)
+
+		defer func(startTime time.Time) {
+			loggingFunc := func(logger *zap.Logger, elapsedTime time.Duration) {
+				logIdempotency(logger, storeErr, key, replayed, elapsedTime)
+			}
+
+			logMiddlewareResult(ctx, idempotencyServiceName, startTime, loggingFunc)
+		}(time.Now())
+
+		body, err := io.ReadAll(r.Body)
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return errors.Join(reqbody.ErrBodyTooLarge, err)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		route, _ := endpoint(ctx)
+		var (
+			storeKey    = route + ":" + ByUser.value(r) + ":" + key
+			fingerprint = idempotency.Fingerprint(r.Method, r.URL.Path, body)
+		)
+
+		record, locked, storeErr := m.idempotencyStore.Lock(ctx, storeKey, fingerprint, idempotencyLockDuration)
+		if storeErr != nil {
+			// the store must not make the API unavailable, so requests are handled as usual while it is failing
+			return next(w, r)
+		}
+
+		if !locked {
+			replayed = true
+			return replay(w, record, fingerprint)
+		}
+
+		recorder := newResponseRecorder(w)
+		if err := next(recorder, r); err != nil {
+			storeErr = m.idempotencyStore.Unlock(ctx, storeKey)
+			return err
+		}
+
+		completed := idempotency.Record{
+			Fingerprint: fingerprint,
+			Response:    recorder.response(),
+		}
+
+		// the response is already sent, so saving error is only logged
+		if storeErr = m.idempotencyStore.Save(ctx, storeKey, completed, ttl); storeErr != nil {
+			storeErr = errors.Join(storeErr, m.idempotencyStore.Unlock(ctx, storeKey))
+		}
+
+		return nil
+	}
+}
+
+func replay(w http.ResponseWriter, record idempotency.Record, fingerprint string) error {
+	if record.Fingerprint != fingerprint {
+		return ErrIdempotencyKeyReused
+	}
+
+	if record.InProgress() {
+		return ErrIdempotencyKeyInFlight
+	}
+
+	for key, values := range record.Response.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+
+	w.Header().Set(idempotentReplayedHeader, idempotencyReplayedResult)
+	w.WriteHeader(record.Response.StatusCode)
+	_, err := w.Write(record.Response.Body)
+	return err
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// responseRecorder writes the response to the client and keeps its copy to be saved.
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	header     http.Header
+	body       bytes.Buffer
+}
+
+func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
+	return &responseRecorder{
+		ResponseWriter: w,
+	}
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if r.statusCode == 0 {
+		r.statusCode = statusCode
+		r.header = r.Header().Clone()
+	}
+
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	if r.statusCode == 0 {
+		r.WriteHeader(http.StatusOK)
+	}
+
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) response() *idempotency.Response {
+	statusCode := r.statusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+
+	return &idempotency.Response{
+		StatusCode: statusCode,
+		Header:     r.header,
+		Body:       r.body.Bytes(),
+	}
+}
+
+func logIdempotency(logger *zap.Logger, err error, key string, replayed bool, elapsedTime time.Duration) {
+	var (
+		msg    = "Request is handled with idempotency key"
+		logLvl = zapcore.InfoLevel
+	)
+
+	switch {
+	case err != nil:
+		msg = "Idempotency store failed"
+		logLvl = zapcore.ErrorLevel
+	case replayed:
+		msg = "Request with used idempotency key is replayed or rejected"
+	}
+
+	logger.Log(logLvl, msg,
+		zap.String("idempotencyKey", key),
+		zap.Duration(elapsedTimeLogKey, elapsedTime),
+		zap.Error(err),
+	)
+}
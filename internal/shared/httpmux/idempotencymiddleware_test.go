@@ -0,0 +1,172 @@
+package httpmux
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zhuboris/never-expires/internal/shared/idempotency"
+)
+
+func TestMux_Idempotent(t *testing.T) {
+	const route = "/items"
+
+	errFailed := errors.New("failed")
+
+	type request struct {
+		key  string
+		ip   string
+		body string
+	}
+
+	tests := []struct {
+		name        string
+		requests    []request
+		handlerErrs []error
+		wantErrors  []error
+		wantCalls   int
+		wantBodies  []string
+	}{
+		{
+			name: "retry gets saved response",
+			requests: []request{
+				{key: "key", ip: "10.0.0.1", body: `{"name":"milk"}`},
+				{key: "key", ip: "10.0.0.1", body: `{"name":"milk"}`},
+			},
+			wantErrors: []error{nil, nil},
+			wantCalls:  1,
+			wantBodies: []string{"1", "1"},
+		},
+		{
+			name: "reused key with another body is rejected",
+			requests: []request{
+				{key: "key", ip: "10.0.0.1", body: `{"name":"milk"}`},
+				{key: "key", ip: "10.0.0.1", body: `{"name":"eggs"}`},
+			},
+			wantErrors: []error{nil, ErrIdempotencyKeyReused},
+			wantCalls:  1,
+			wantBodies: []string{"1", ""},
+		},
+		{
+			name: "keys are scoped by client",
+			requests: []request{
+				{key: "key", ip: "10.0.0.1", body: `{"name":"milk"}`},
+				{key: "key", ip: "10.0.0.2", body: `{"name":"milk"}`},
+			},
+			wantErrors: []error{nil, nil},
+			wantCalls:  2,
+			wantBodies: []string{"1", "2"},
+		},
+		{
+			name: "requests without key are not saved",
+			requests: []request{
+				{ip: "10.0.0.1", body: `{"name":"milk"}`},
+				{ip: "10.0.0.1", body: `{"name":"milk"}`},
+			},
+			wantErrors: []error{nil, nil},
+			wantCalls:  2,
+			wantBodies: []string{"1", "2"},
+		},
+		{
+			name: "failed request can be retried",
+			requests: []request{
+				{key: "key", ip: "10.0.0.1", body: `{"name":"milk"}`},
+				{key: "key", ip: "10.0.0.1", body: `{"name":"milk"}`},
+			},
+			handlerErrs: []error{errFailed, nil},
+			wantErrors:  []error{errFailed, nil},
+			wantCalls:   2,
+			wantBodies:  []string{"", "2"},
+		},
+		{
+			name: "too long key is rejected",
+			requests: []request{
+				{key: strings.Repeat("k", maxIdempotencyKeyLength+1), ip: "10.0.0.1", body: `{}`},
+			},
+			wantErrors: []error{ErrInvalidIdempotencyKey},
+			wantCalls:  0,
+			wantBodies: []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			handler := func(w http.ResponseWriter, r *http.Request) error {
+				if _, err := io.ReadAll(r.Body); err != nil {
+					return err
+				}
+
+				calls++
+				if len(tt.handlerErrs) >= calls && tt.handlerErrs[calls-1] != nil {
+					return tt.handlerErrs[calls-1]
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				_, err := w.Write([]byte(string(rune('0' + calls))))
+				return err
+			}
+
+			mux := NewMux(nil)
+			mux.SetIdempotencyStore(idempotency.NewMemoryStore())
+			idempotent := mux.Idempotent(time.Hour)(handler)
+
+			for i, req := range tt.requests {
+				r := httptest.NewRequest(http.MethodPost, route, strings.NewReader(req.body))
+				r = r.WithContext(withEndpoint(r.Context(), route))
+				r.Header.Set("X-Real-IP", req.ip)
+				if req.key != "" {
+					r.Header.Set(idempotencyKeyHeader, req.key)
+				}
+
+				w := httptest.NewRecorder()
+
+				err := idempotent(w, r)
+				assert.ErrorIs(t, err, tt.wantErrors[i])
+				assert.Equal(t, tt.wantBodies[i], w.Body.String())
+				if tt.wantErrors[i] == nil {
+					assert.Equal(t, http.StatusCreated, w.Code)
+					assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+				}
+			}
+
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestMux_Idempotent_InFlight(t *testing.T) {
+	const route = "/items"
+
+	store := idempotency.NewMemoryStore()
+	mux := NewMux(nil)
+	mux.SetIdempotencyStore(store)
+
+	var replayedErr error
+	handler := func(w http.ResponseWriter, r *http.Request) error {
+		retry := httptest.NewRequest(http.MethodPost, route, strings.NewReader(`{}`))
+		retry = retry.WithContext(withEndpoint(retry.Context(), route))
+		retry.Header.Set(idempotencyKeyHeader, "key")
+
+		replayedErr = mux.Idempotent(time.Hour)(func(w http.ResponseWriter, r *http.Request) error {
+			return nil
+		})(httptest.NewRecorder(), retry)
+
+		return nil
+	}
+
+	r := httptest.NewRequest(http.MethodPost, route, strings.NewReader(`{}`))
+	r = r.WithContext(withEndpoint(r.Context(), route))
+	r.Header.Set(idempotencyKeyHeader, "key")
+
+	err := mux.Idempotent(time.Hour)(handler)(httptest.NewRecorder(), r)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, replayedErr, ErrIdempotencyKeyInFlight)
+}
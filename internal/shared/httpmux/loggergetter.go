@@ -18,6 +18,7 @@ const (
 	statusCodeLogKey      = "statusCode"
 	userIDLogKey          = "userID"
 	rawIDLogKey           = "rawID"
+	traceIDLogKey         = "traceID"
 )
 
 type loggerKeyType struct{}
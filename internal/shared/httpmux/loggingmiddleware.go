@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
 )
 
 func loggerMiddleware(logger *zap.Logger, next errorHandledFunc) errorHandledFunc {
@@ -32,7 +34,7 @@ func loggerMiddleware(logger *zap.Logger, next errorHandledFunc) errorHandledFun
 			return fmt.Errorf("failed adding the logger to the context: %w", err)
 		}
 
-		scopedLogger = logger.With(zap.Stringer(requestIDLogKey, id), zap.String(pathLogKey, r.URL.Path))
+		scopedLogger = logger.With(zap.Stringer(requestIDLogKey, id), zap.String(pathLogKey, r.URL.Path), zap.String(traceIDLogKey, tracing.TraceID(ctx)))
 		ctx = withLogger(ctx, scopedLogger)
 		r = r.WithContext(ctx)
 
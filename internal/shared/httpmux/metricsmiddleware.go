@@ -3,14 +3,31 @@ package httpmux
 import (
 	"context"
 	"net/http"
+	"time"
 )
 
+type requestsDurationObserver interface {
+	Observe(route, method string, statusCode int, duration time.Duration)
+}
+
 func (m *Mux) metricsRegisterMiddleware(endpoint string, handler http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := withEndpoint(r.Context(), endpoint)
 		r = r.WithContext(ctx)
 
-		handler(w, r)
+		if m.requestDuration == nil {
+			handler(w, r)
+			return
+		}
+
+		recorder := &statusRecorder{
+			ResponseWriter: w,
+			statusCode:     http.StatusOK,
+		}
+
+		startTime := time.Now()
+		handler(recorder, r)
+		m.requestDuration.Observe(endpoint, r.Method, recorder.statusCode, time.Since(startTime))
 	}
 }
 
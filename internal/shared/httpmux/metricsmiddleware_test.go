@@ -0,0 +1,78 @@
+package httpmux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type observedRequest struct {
+	route      string
+	method     string
+	statusCode int
+	duration   time.Duration
+}
+
+type durationObserverStub struct {
+	observed []observedRequest
+}
+
+func (o *durationObserverStub) Observe(route, method string, statusCode int, duration time.Duration) {
+	o.observed = append(o.observed, observedRequest{
+		route:      route,
+		method:     method,
+		statusCode: statusCode,
+		duration:   duration,
+	})
+}
+
+func TestMux_metricsRegisterMiddleware(t *testing.T) {
+	const route = "/items/"
+
+	tests := []struct {
+		name           string
+		writeStatus    int
+		wantStatusCode int
+	}{
+		{
+			name:           "status is 200 if handler does not write header",
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "written status is observed",
+			writeStatus:    http.StatusNotFound,
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observer := &durationObserverStub{}
+			mux := NewMux(nil)
+			mux.SetRequestDurationHistogram(observer)
+
+			var gotEndpoint string
+			handler := mux.metricsRegisterMiddleware(route, func(w http.ResponseWriter, r *http.Request) {
+				gotEndpoint, _ = endpoint(r.Context())
+				time.Sleep(time.Millisecond)
+				if tt.writeStatus != 0 {
+					w.WriteHeader(tt.writeStatus)
+				}
+			})
+
+			handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/items/42", nil))
+
+			assert.Equal(t, route, gotEndpoint)
+			require.Len(t, observer.observed, 1)
+			got := observer.observed[0]
+			assert.Equal(t, route, got.route, "route pattern must be observed instead of path")
+			assert.Equal(t, http.MethodDelete, got.method)
+			assert.Equal(t, tt.wantStatusCode, got.statusCode)
+			assert.GreaterOrEqual(t, got.duration, time.Millisecond)
+		})
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/zhuboris/never-expires/internal/shared/idempotency"
+	"github.com/zhuboris/never-expires/internal/shared/ratelimit"
 )
 
 type requestsCounter interface {
@@ -16,8 +19,13 @@ type errorHandlingFunc func(error) RequestingResult
 type Mux struct {
 	zapLogger         *zap.Logger
 	defaultTimeout    time.Duration
+	maxBodySize       int64
 	errorHandlingFunc errorHandlingFunc
 	requestCounter    requestsCounter
+	requestDuration   requestsDurationObserver
+	rateLimitStore    ratelimit.Store
+	throttleCounter   throttleCounter
+	idempotencyStore  idempotency.Store
 
 	http.ServeMux
 }
@@ -25,6 +33,7 @@ type Mux struct {
 func NewMux(errorHandlingFunc errorHandlingFunc) *Mux {
 	return &Mux{
 		errorHandlingFunc: errorHandlingFunc,
+		maxBodySize:       DefaultMaxBodySize,
 	}
 }
 
@@ -36,10 +45,31 @@ func (m *Mux) SetDefaultTimeout(value time.Duration) {
 	m.defaultTimeout = value
 }
 
+// SetMaxBodySize changes the body size limit of routes without MaxBodySize middleware, zero disables it.
+func (m *Mux) SetMaxBodySize(limit int64) {
+	m.maxBodySize = limit
+}
+
 func (m *Mux) SetRequestCounter(counter requestsCounter) {
 	m.requestCounter = counter
 }
 
+func (m *Mux) SetRequestDurationHistogram(histogram requestsDurationObserver) {
+	m.requestDuration = histogram
+}
+
+func (m *Mux) SetRateLimitStore(store ratelimit.Store) {
+	m.rateLimitStore = store
+}
+
+func (m *Mux) SetThrottleCounter(counter throttleCounter) {
+	m.throttleCounter = counter
+}
+
+func (m *Mux) SetIdempotencyStore(store idempotency.Store) {
+	m.idempotencyStore = store
+}
+
 func (m *Mux) HandleGet(route string, f errorHandledFunc, middlewares ...Middleware) {
 	m.HandleFuncWithMiddlewares(route, f, []string{http.MethodGet}, middlewares...)
 }
@@ -77,14 +107,14 @@ func (m *Mux) HandleTrace(route string, f errorHandledFunc, middlewares ...Middl
 
 func (m *Mux) HandleFuncWithMiddlewares(route string, f errorHandledFunc, httpMethods []string, middlewares ...Middleware) {
 	middlewares = append(m.defaultMiddlewares(httpMethods), middlewares...)
-	httpHandler := requestIDMiddleware(m.handleAPIErrors(applyMiddlewares(f, middlewares...)))
+	httpHandler := requestIDMiddleware(tracingMiddleware(route, m.handleAPIErrors(applyMiddlewares(f, middlewares...))))
 	httpHandler = m.metricsRegisterMiddleware(route, httpHandler)
 
 	m.HandleFunc(route, httpHandler)
 }
 
 func (m *Mux) defaultMiddlewares(httpMethods []string) []Middleware {
-	return []Middleware{m.tryAddDefaultTimeoutMiddleware(), makeScopedLogger(m.zapLogger), checkHttpMethod(httpMethods...)}
+	return []Middleware{m.tryAddDefaultTimeoutMiddleware(), makeScopedLogger(m.zapLogger), checkHttpMethod(httpMethods...), MaxBodySize(m.maxBodySize)}
 }
 
 func (m *Mux) tryAddDefaultTimeoutMiddleware() Middleware {
@@ -0,0 +1,200 @@
+package httpmux
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/zhuboris/never-expires/internal/id/usr"
+	"github.com/zhuboris/never-expires/internal/shared/ratelimit"
+)
+
+const rateLimitServiceName = "rateLimitMiddleware"
+
+const (
+	retryAfterHeader         = "Retry-After"
+	rateLimitLimitHeader     = "RateLimit-Limit"
+	rateLimitRemainingHeader = "RateLimit-Remaining"
+	rateLimitResetHeader     = "RateLimit-Reset"
+)
+
+var ErrTooManyRequests = errors.New("too many requests, try again later")
+
+type throttleCounter interface {
+	Increment(route, limitedBy string)
+}
+
+// RateLimitKey defines what requests share one token bucket.
+type RateLimitKey int
+
+const (
+	ByIP RateLimitKey = iota
+	// ByUser works only after Authorize middleware, requests without user are limited by IP.
+	ByUser
+	// ByRoute limits all requests to the route together.
+	ByRoute
+	// ByEmail limits by "email" field of JSON body, requests without it are limited by IP.
+	ByEmail
+)
+
+func (k RateLimitKey) String() string {
+	switch k {
+	case ByIP:
+		return "ip"
+	case ByUser:
+		return "user"
+	case ByRoute:
+		return "route"
+	case ByEmail:
+		return "email"
+	default:
+		return "unknown"
+	}
+}
+
+func (k RateLimitKey) value(r *http.Request) string {
+	switch k {
+	case ByUser:
+		if id, err := usr.ID(r.Context()); err == nil { // if NO error
+			return uuid.UUID(id.Bytes).String()
+		}
+
+		return ByIP.String() + ":" + ClientIP(r)
+	case ByRoute:
+		return ""
+	case ByEmail:
+		if email := emailFromBody(r); email != "" {
+			return email
+		}
+
+		return ByIP.String() + ":" + ClientIP(r)
+	default:
+		return ClientIP(r)
+	}
+}
+
+// emailFromBody reads the body and puts it back, so the handler decodes it as usual.
+func emailFromBody(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+
+	data, err := io.ReadAll(r.Body)
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{
+		// the rest of the body is read after data, so reading error is returned to the handler again
+		Reader: io.MultiReader(bytes.NewReader(data), r.Body),
+		Closer: r.Body,
+	}
+
+	if err != nil {
+		return ""
+	}
+
+	var body struct {
+		Email string `json:"email"`
+	}
+
+	if err := json.Unmarshal(data, &body); err != nil {
+		return ""
+	}
+
+	return strings.ToLower(strings.TrimSpace(body.Email))
+}
+
+// RateLimit throttles requests with token bucket from the store set by SetRateLimitStore, without the store requests are not limited.
+// Limited requests fail with ErrTooManyRequests.
+func (m *Mux) RateLimit(limit ratelimit.Limit, key RateLimitKey) Middleware {
+	return func(f errorHandledFunc) errorHandledFunc {
+		return m.rateLimitMiddleware(limit, key, f)
+	}
+}
+
+func (m *Mux) rateLimitMiddleware(limit ratelimit.Limit, key RateLimitKey, next errorHandledFunc) errorHandledFunc {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		if m.rateLimitStore == nil {
+			return next(w, r)
+		}
+
+		var (
+			decision ratelimit.Decision
+			err      error
+			ctx      = r.Context()
+		)
+
+		defer func(startTime time.Time) {
+			loggingFunc := func(logger *zap.Logger, elapsedTime time.Duration) {
+				logRateLimit(logger, err, decision, key, elapsedTime)
+			}
+
+			logMiddlewareResult(ctx, rateLimitServiceName, startTime, loggingFunc)
+		}(time.Now())
+
+		route, _ := endpoint(ctx)
+		bucketKey := route + ":" + key.String() + ":" + key.value(r)
+
+		decision, err = m.rateLimitStore.Take(ctx, bucketKey, limit)
+		if err != nil {
+			// limits must not make the API unavailable, so requests are allowed while the store is failing
+			return next(w, r)
+		}
+
+		addRateLimitHeaders(w, decision)
+		if decision.Allowed {
+			return next(w, r)
+		}
+
+		if m.throttleCounter != nil {
+			m.throttleCounter.Increment(route, key.String())
+		}
+
+		w.Header().Set(retryAfterHeader, seconds(decision.RetryAfter))
+		return ErrTooManyRequests
+	}
+}
+
+func addRateLimitHeaders(w http.ResponseWriter, decision ratelimit.Decision) {
+	w.Header().Set(rateLimitLimitHeader, strconv.Itoa(decision.Limit))
+	w.Header().Set(rateLimitRemainingHeader, strconv.Itoa(decision.Remaining))
+	w.Header().Set(rateLimitResetHeader, seconds(decision.Reset))
+}
+
+// seconds rounds duration up, so a client waiting for it is not limited again.
+func seconds(duration time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(duration.Seconds())))
+}
+
+func logRateLimit(logger *zap.Logger, err error, decision ratelimit.Decision, key RateLimitKey, elapsedTime time.Duration) {
+	var (
+		msg    = "Request is allowed"
+		logLvl = zapcore.InfoLevel
+	)
+
+	switch {
+	case err != nil:
+		msg = "Rate limit store failed, request is allowed"
+		logLvl = zapcore.ErrorLevel
+	case !decision.Allowed:
+		msg = "Request is throttled"
+		logLvl = zapcore.WarnLevel
+	}
+
+	logger.Log(logLvl, msg,
+		zap.Stringer("limitedBy", key),
+		zap.Int("remaining", decision.Remaining),
+		zap.Duration(elapsedTimeLogKey, elapsedTime),
+		zap.Error(err),
+	)
+}
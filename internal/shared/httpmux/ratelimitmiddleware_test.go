@@ -0,0 +1,143 @@
+package httpmux
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zhuboris/never-expires/internal/shared/ratelimit"
+)
+
+type throttleCounterStub struct {
+	throttled map[string]int
+}
+
+func (c *throttleCounterStub) Increment(route, limitedBy string) {
+	c.throttled[route+" "+limitedBy]++
+}
+
+func TestMux_RateLimit(t *testing.T) {
+	const route = "/login"
+
+	var (
+		limit = ratelimit.PerMinute(1, 2)
+		ok    = func(w http.ResponseWriter, r *http.Request) error {
+			return nil
+		}
+	)
+
+	tests := []struct {
+		name          string
+		key           RateLimitKey
+		requestsFrom  []string
+		wantErrors    []error
+		wantThrottled map[string]int
+	}{
+		{
+			name:          "requests over burst are throttled",
+			key:           ByIP,
+			requestsFrom:  []string{"10.0.0.1", "10.0.0.1", "10.0.0.1"},
+			wantErrors:    []error{nil, nil, ErrTooManyRequests},
+			wantThrottled: map[string]int{route + " ip": 1},
+		},
+		{
+			name:          "clients are limited separately",
+			key:           ByIP,
+			requestsFrom:  []string{"10.0.0.1", "10.0.0.1", "10.0.0.2"},
+			wantErrors:    []error{nil, nil, nil},
+			wantThrottled: map[string]int{},
+		},
+		{
+			name:          "route is limited for all clients",
+			key:           ByRoute,
+			requestsFrom:  []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"},
+			wantErrors:    []error{nil, nil, ErrTooManyRequests},
+			wantThrottled: map[string]int{route + " route": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := &throttleCounterStub{throttled: make(map[string]int)}
+			mux := NewMux(nil)
+			mux.SetRateLimitStore(ratelimit.NewMemoryStore())
+			mux.SetThrottleCounter(counter)
+
+			handler := mux.RateLimit(limit, tt.key)(ok)
+			for i, ip := range tt.requestsFrom {
+				r := httptest.NewRequest(http.MethodPost, route, nil)
+				r = r.WithContext(withEndpoint(r.Context(), route))
+				r.Header.Set("X-Real-IP", ip)
+				w := httptest.NewRecorder()
+
+				err := handler(w, r)
+				assert.ErrorIs(t, err, tt.wantErrors[i])
+				assert.Equal(t, "2", w.Header().Get(rateLimitLimitHeader))
+				if tt.wantErrors[i] != nil {
+					assert.Equal(t, "0", w.Header().Get(rateLimitRemainingHeader))
+					assert.Equal(t, "60", w.Header().Get(retryAfterHeader))
+				}
+			}
+
+			assert.Equal(t, tt.wantThrottled, counter.throttled)
+		})
+	}
+}
+
+func TestMux_RateLimitByEmail(t *testing.T) {
+	const route = "/login/email-code"
+
+	tests := []struct {
+		name       string
+		bodies     []string
+		wantErrors []error
+	}{
+		{
+			name:       "same email from different clients is throttled",
+			bodies:     []string{`{"email":"user@example.com"}`, `{"email":" User@Example.com"}`, `{"email":"user@example.com"}`},
+			wantErrors: []error{nil, nil, ErrTooManyRequests},
+		},
+		{
+			name:       "different emails are limited separately",
+			bodies:     []string{`{"email":"first@example.com"}`, `{"email":"first@example.com"}`, `{"email":"second@example.com"}`},
+			wantErrors: []error{nil, nil, nil},
+		},
+		{
+			name:       "body without email is limited by IP",
+			bodies:     []string{`{}`, `not json`, `{"email":""}`},
+			wantErrors: []error{nil, nil, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mux         = NewMux(nil)
+				decodedBody string
+				handler     = mux.RateLimit(ratelimit.PerMinute(1, 2), ByEmail)(func(w http.ResponseWriter, r *http.Request) error {
+					data, err := io.ReadAll(r.Body)
+					decodedBody = string(data)
+					return err
+				})
+			)
+
+			mux.SetRateLimitStore(ratelimit.NewMemoryStore())
+			for i, body := range tt.bodies {
+				r := httptest.NewRequest(http.MethodPost, route, strings.NewReader(body))
+				r = r.WithContext(withEndpoint(r.Context(), route))
+				r.Header.Set("X-Real-IP", "10.0.0."+strconv.Itoa(i))
+
+				err := handler(httptest.NewRecorder(), r)
+				assert.ErrorIs(t, err, tt.wantErrors[i])
+				if tt.wantErrors[i] == nil {
+					assert.Equal(t, body, decodedBody, "handler must read the whole body")
+				}
+			}
+		})
+	}
+}
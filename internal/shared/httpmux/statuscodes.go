@@ -1,10 +1,18 @@
 package httpmux
 
+import "github.com/zhuboris/never-expires/internal/shared/validation"
+
 type (
 	ErrorMessage struct {
 		StatusCode   StatusCode `json:"status_code"`
 		ErrorMessage string     `json:"error"`
 	}
+	// InvalidFieldsMessage lists every rejected body field, so clients can point at them.
+	InvalidFieldsMessage struct {
+		ErrorMessage
+
+		Fields []validation.FieldError `json:"fields"`
+	}
 	SuccessMessage struct {
 		StatusCode     StatusCode `json:"status_code"`
 		SuccessMessage string     `json:"success_message"`
@@ -34,8 +42,21 @@ func (c StatusCode) ErrorMessage(msg string) *ErrorMessage {
 	}
 }
 
+func (c StatusCode) InvalidFieldsMessage(msg string, fields []validation.FieldError) *InvalidFieldsMessage {
+	return &InvalidFieldsMessage{
+		ErrorMessage: *c.ErrorMessage(msg),
+		Fields:       fields,
+	}
+}
+
 const (
-	StatusInvalidJSONBody      StatusCode = 1001
-	StatusUnexistingHTTPMethod StatusCode = 1002
-	StatusMissingParameter     StatusCode = 1003
+	StatusInvalidJSONBody        StatusCode = 1001
+	StatusUnexistingHTTPMethod   StatusCode = 1002
+	StatusMissingParameter       StatusCode = 1003
+	StatusRateLimited            StatusCode = 1007
+	StatusInvalidIdempotencyKey  StatusCode = 1008
+	StatusIdempotencyKeyReused   StatusCode = 1009
+	StatusIdempotencyKeyInFlight StatusCode = 1010
+	StatusInvalidFields          StatusCode = 1011
+	StatusBodyTooLarge           StatusCode = 1012
 )
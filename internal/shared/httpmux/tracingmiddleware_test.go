@@ -0,0 +1,122 @@
+package httpmux
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	const (
+		route       = "/items/"
+		traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID    = "00f067aa0ba902b7"
+		traceparent = "00-" + traceID + "-" + parentID + "-01"
+	)
+
+	tests := []struct {
+		name        string
+		traceparent string
+		statusCode  int
+		wantStatus  codes.Code
+	}{
+		{
+			name:       "new trace is started without traceparent",
+			statusCode: http.StatusOK,
+			wantStatus: codes.Unset,
+		},
+		{
+			name:        "trace of the client is continued",
+			traceparent: traceparent,
+			statusCode:  http.StatusCreated,
+			wantStatus:  codes.Unset,
+		},
+		{
+			name:       "server error marks span as failed",
+			statusCode: http.StatusInternalServerError,
+			wantStatus: codes.Error,
+		},
+		{
+			name:       "client error does not mark span as failed",
+			statusCode: http.StatusNotFound,
+			wantStatus: codes.Unset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := setupSpanRecorder(t)
+
+			var handlerTraceID string
+			handler := requestIDMiddleware(tracingMiddleware(route, func(w http.ResponseWriter, r *http.Request) {
+				handlerTraceID = tracing.TraceID(r.Context())
+				w.WriteHeader(tt.statusCode)
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/items/42", nil)
+			if tt.traceparent != "" {
+				r.Header.Set("traceparent", tt.traceparent)
+			}
+
+			handler(httptest.NewRecorder(), r)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+
+			span := spans[0]
+			assert.Equal(t, "GET "+route, span.Name())
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+			assert.Equal(t, tt.wantStatus, span.Status().Code)
+			assert.Contains(t, span.Attributes(), semconv.HTTPStatusCode(tt.statusCode))
+			assert.Contains(t, span.Attributes(), semconv.HTTPRoute(route))
+			assert.Equal(t, span.SpanContext().TraceID().String(), handlerTraceID)
+			assert.True(t, hasAttribute(span.Attributes(), tracing.RequestIDAttributeKey), "request id is not added to span")
+
+			if tt.traceparent != "" {
+				assert.Equal(t, traceID, span.SpanContext().TraceID().String())
+				assert.Equal(t, parentID, span.Parent().SpanID().String())
+			} else {
+				assert.False(t, span.Parent().IsValid())
+			}
+		})
+	}
+}
+
+func setupSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	_, err := tracing.Init(context.Background(), tracing.Config{}, "test")
+	require.NoError(t, err)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := tracing.NewProvider(recorder, "test", 100)
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	return recorder
+}
+
+func hasAttribute(attributes []attribute.KeyValue, key attribute.Key) bool {
+	for _, attr := range attributes {
+		if attr.Key == key && attr.Value.AsString() != "" {
+			return true
+		}
+	}
+
+	return false
+}
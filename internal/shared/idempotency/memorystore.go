@@ -0,0 +1,81 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const cleanupThreshold = 10_000
+
+// MemoryStore keeps records in the process memory, so retries are recognized only by the same instance of the service.
+type MemoryStore struct {
+	now     func() time.Time
+	mu      sync.Mutex
+	records map[string]memoryRecord
+}
+
+type memoryRecord struct {
+	Record
+	expiresAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		now:     time.Now,
+		records: make(map[string]memoryRecord),
+	}
+}
+
+func (s *MemoryStore) Lock(_ context.Context, key, fingerprint string, ttl time.Duration) (Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if len(s.records) >= cleanupThreshold {
+		s.removeExpired(now)
+	}
+
+	if saved, ok := s.records[key]; ok && now.Before(saved.expiresAt) {
+		return saved.Record, false, nil
+	}
+
+	record := Record{
+		Fingerprint: fingerprint,
+	}
+
+	s.records[key] = memoryRecord{
+		Record:    record,
+		expiresAt: now.Add(ttl),
+	}
+
+	return record, true, nil
+}
+
+func (s *MemoryStore) Save(_ context.Context, key string, record Record, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[key] = memoryRecord{
+		Record:    record,
+		expiresAt: s.now().Add(ttl),
+	}
+
+	return nil
+}
+
+func (s *MemoryStore) Unlock(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+func (s *MemoryStore) removeExpired(now time.Time) {
+	for key, record := range s.records {
+		if !now.Before(record.expiresAt) {
+			delete(s.records, key)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_Lock(t *testing.T) {
+	const (
+		key = "key"
+		ttl = time.Minute
+	)
+
+	var (
+		ctx      = context.Background()
+		start    = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		response = &Response{StatusCode: http.StatusOK, Body: []byte("{}")}
+	)
+
+	tests := []struct {
+		name       string
+		prepare    func(s *MemoryStore)
+		at         time.Duration
+		wantLocked bool
+		wantRecord Record
+	}{
+		{
+			name:       "free key is locked",
+			at:         0,
+			wantLocked: true,
+			wantRecord: Record{Fingerprint: "new"},
+		},
+		{
+			name: "locked key returns request in progress",
+			prepare: func(s *MemoryStore) {
+				_, _, _ = s.Lock(ctx, key, "first", ttl)
+			},
+			at:         time.Second,
+			wantLocked: false,
+			wantRecord: Record{Fingerprint: "first"},
+		},
+		{
+			name: "saved key returns response",
+			prepare: func(s *MemoryStore) {
+				_ = s.Save(ctx, key, Record{Fingerprint: "first", Response: response}, ttl)
+			},
+			at:         time.Second,
+			wantLocked: false,
+			wantRecord: Record{Fingerprint: "first", Response: response},
+		},
+		{
+			name: "expired key is locked again",
+			prepare: func(s *MemoryStore) {
+				_ = s.Save(ctx, key, Record{Fingerprint: "first", Response: response}, ttl)
+			},
+			at:         ttl,
+			wantLocked: true,
+			wantRecord: Record{Fingerprint: "new"},
+		},
+		{
+			name: "unlocked key is locked again",
+			prepare: func(s *MemoryStore) {
+				_, _, _ = s.Lock(ctx, key, "first", ttl)
+				_ = s.Unlock(ctx, key)
+			},
+			at:         time.Second,
+			wantLocked: true,
+			wantRecord: Record{Fingerprint: "new"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore()
+			store.now = func() time.Time { return start }
+			if tt.prepare != nil {
+				tt.prepare(store)
+			}
+
+			store.now = func() time.Time { return start.Add(tt.at) }
+			record, locked, err := store.Lock(ctx, key, "new", ttl)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLocked, locked)
+			assert.Equal(t, tt.wantRecord, record)
+		})
+	}
+}
+
+func TestMemoryStore_RemovesExpired(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		start = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		store = NewMemoryStore()
+	)
+
+	store.now = func() time.Time { return start }
+	for i := 0; i < cleanupThreshold; i++ {
+		_, _, err := store.Lock(ctx, time.Duration(i).String(), "fingerprint", time.Minute)
+		require.NoError(t, err)
+	}
+
+	store.now = func() time.Time { return start.Add(time.Minute) }
+	_, _, err := store.Lock(ctx, "new", "fingerprint", time.Minute)
+	require.NoError(t, err)
+	assert.Len(t, store.records, 1)
+}
+
+func TestFingerprint(t *testing.T) {
+	base := Fingerprint(http.MethodPost, "/items", []byte(`{"name":"milk"}`))
+
+	assert.Equal(t, base, Fingerprint(http.MethodPost, "/items", []byte(`{"name":"milk"}`)))
+	assert.NotEqual(t, base, Fingerprint(http.MethodPost, "/items", []byte(`{"name":"eggs"}`)))
+	assert.NotEqual(t, base, Fingerprint(http.MethodPost, "/storages", []byte(`{"name":"milk"}`)))
+	assert.NotEqual(t, base, Fingerprint(http.MethodPut, "/items", []byte(`{"name":"milk"}`)))
+}
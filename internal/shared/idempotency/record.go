@@ -0,0 +1,49 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// Response is saved result of the first request, it is replayed to the retries with the same key.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       []byte      `json:"body,omitempty"`
+}
+
+// Record is kept per key, Response is nil while the first request is still processed.
+type Record struct {
+	Fingerprint string    `json:"fingerprint"`
+	Response    *Response `json:"response,omitempty"`
+}
+
+func (r Record) InProgress() bool {
+	return r.Response == nil
+}
+
+// Fingerprint identifies the request, retries must have the same method, path and body.
+func Fingerprint(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Store keeps records, key of the record must include everything it is scoped by.
+type Store interface {
+	// Lock saves record without response if the key is free and returns true,
+	// otherwise it returns the record that is already saved.
+	Lock(ctx context.Context, key, fingerprint string, ttl time.Duration) (Record, bool, error)
+	// Save replaces locked record with completed one.
+	Save(ctx context.Context, key string, record Record, ttl time.Duration) error
+	// Unlock deletes the record, so the request with the key can be made again.
+	Unlock(ctx context.Context, key string) error
+}
@@ -0,0 +1,94 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "idempotency:"
+
+type Config struct {
+	// RedisAddress is set to share keys between instances, otherwise they are kept in memory.
+	RedisAddress  string `env:"IDEMPOTENCY_REDIS_ADDR"`
+	RedisUsername string `env:"IDEMPOTENCY_REDIS_USERNAME"`
+	RedisPassword string `env:"IDEMPOTENCY_REDIS_PASSWORD" secret:"true"`
+}
+
+func NewStore(config Config) Store {
+	if config.RedisAddress == "" {
+		return NewMemoryStore()
+	}
+
+	options := &redis.Options{
+		Addr:     config.RedisAddress,
+		Username: config.RedisUsername,
+		Password: config.RedisPassword,
+	}
+
+	return NewRedisStore(redis.NewClient(options))
+}
+
+type RedisStore struct {
+	client redis.Cmdable
+}
+
+func NewRedisStore(client redis.Cmdable) *RedisStore {
+	return &RedisStore{
+		client: client,
+	}
+}
+
+// Lock uses SET with NX and GET options, so the check and the lock are made atomically.
+func (s *RedisStore) Lock(ctx context.Context, key, fingerprint string, ttl time.Duration) (Record, bool, error) {
+	record := Record{
+		Fingerprint: fingerprint,
+	}
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		return Record{}, false, err
+	}
+
+	args := redis.SetArgs{
+		Mode: "NX",
+		TTL:  ttl,
+		Get:  true,
+	}
+
+	saved, err := s.client.SetArgs(ctx, redisKeyPrefix+key, value, args).Result()
+	if errors.Is(err, redis.Nil) {
+		return record, true, nil
+	}
+
+	if err != nil {
+		return Record{}, false, err
+	}
+
+	var savedRecord Record
+	if err := json.Unmarshal([]byte(saved), &savedRecord); err != nil {
+		return Record{}, false, err
+	}
+
+	return savedRecord, false, nil
+}
+
+func (s *RedisStore) Save(ctx context.Context, key string, record Record, ttl time.Duration) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return s.client.
+		Set(ctx, redisKeyPrefix+key, value, ttl).
+		Err()
+}
+
+func (s *RedisStore) Unlock(ctx context.Context, key string) error {
+	return s.client.
+		Del(ctx, redisKeyPrefix+key).
+		Err()
+}
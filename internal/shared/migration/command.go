@@ -0,0 +1,53 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Command is the name of the subcommand the services accept to run migrations manually.
+const Command = "migrate"
+
+// RunCommand executes "up" or "down [steps]" passed as args, one step is rolled back by default.
+func RunCommand(ctx context.Context, runner *Runner, args []string) error {
+	if len(args) == 0 {
+		return ErrUnknownSubcommand
+	}
+
+	switch args[0] {
+	case upDirection:
+		if len(args) != 1 {
+			return ErrUnknownSubcommand
+		}
+
+		return runner.Up(ctx)
+	case downDirection:
+		steps, err := parseSteps(args[1:])
+		if err != nil {
+			return err
+		}
+
+		return runner.Down(ctx, steps)
+	default:
+		return fmt.Errorf("%w, got %q", ErrUnknownSubcommand, args[0])
+	}
+}
+
+func parseSteps(args []string) (int, error) {
+	const defaultSteps = 1
+
+	switch len(args) {
+	case 0:
+		return defaultSteps, nil
+	case 1:
+		steps, err := strconv.Atoi(args[0])
+		if err != nil || steps <= 0 {
+			return 0, ErrInvalidSteps
+		}
+
+		return steps, nil
+	default:
+		return 0, ErrUnknownSubcommand
+	}
+}
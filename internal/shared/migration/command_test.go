@@ -0,0 +1,49 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSteps(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    int
+		wantErr error
+	}{
+		{
+			name: "one step by default",
+			want: 1,
+		},
+		{
+			name: "steps are passed",
+			args: []string{"3"},
+			want: 3,
+		},
+		{
+			name:    "not a number",
+			args:    []string{"all"},
+			wantErr: ErrInvalidSteps,
+		},
+		{
+			name:    "negative steps",
+			args:    []string{"-1"},
+			wantErr: ErrInvalidSteps,
+		},
+		{
+			name:    "too many arguments",
+			args:    []string{"1", "2"},
+			wantErr: ErrUnknownSubcommand,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSteps(tt.args)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package migration
+
+import "errors"
+
+var (
+	ErrInvalidFileName   = errors.New("migration file name must look like 0001_name.up.sql or 0001_name.down.sql")
+	ErrDuplicateVersion  = errors.New("migration version is used more than once")
+	ErrMissingUp         = errors.New("migration has no up script")
+	ErrChecksumMismatch  = errors.New("applied migration was changed")
+	ErrUnknownVersion    = errors.New("applied migration is not found in sources")
+	ErrIrreversible      = errors.New("migration has no down script")
+	ErrInvalidSteps      = errors.New("steps to roll back must be positive")
+	ErrUnknownSubcommand = errors.New("unknown migrate subcommand, expected up or down [steps]")
+)
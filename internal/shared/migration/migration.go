@@ -0,0 +1,173 @@
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+const (
+	upDirection   = "up"
+	downDirection = "down"
+)
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// Load reads *.sql files from the root of source and returns migrations sorted by version.
+func Load(source fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(source, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration, len(files))
+	for _, file := range files {
+		if err := addFile(source, file, byVersion); err != nil {
+			return nil, err
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("%w: %s", ErrMissingUp, migration)
+		}
+
+		migration.Checksum = checksum(migration.Up)
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func addFile(source fs.FS, file string, byVersion map[int]*Migration) error {
+	parts := fileNamePattern.FindStringSubmatch(path.Base(file))
+	if parts == nil {
+		return fmt.Errorf("%w: %q", ErrInvalidFileName, file)
+	}
+
+	version, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidFileName, file)
+	}
+
+	content, err := fs.ReadFile(source, file)
+	if err != nil {
+		return err
+	}
+
+	name, direction := parts[2], parts[3]
+	migration, ok := byVersion[version]
+	if !ok {
+		migration = &Migration{
+			Version: version,
+			Name:    name,
+		}
+		byVersion[version] = migration
+	}
+
+	if migration.Name != name {
+		return fmt.Errorf("%w: %q", ErrDuplicateVersion, file)
+	}
+
+	script := &migration.Up
+	if direction == downDirection {
+		script = &migration.Down
+	}
+
+	if *script != "" {
+		return fmt.Errorf("%w: %q", ErrDuplicateVersion, file)
+	}
+
+	*script = string(content)
+	return nil
+}
+
+func checksum(script string) string {
+	sum := sha256.Sum256([]byte(script))
+	return hex.EncodeToString(sum[:])
+}
+
+// pending validates applied migrations against sources and returns the ones still to apply.
+func pending(migrations []Migration, applied map[int]string) ([]Migration, error) {
+	if err := validate(migrations, applied); err != nil {
+		return nil, err
+	}
+
+	result := make([]Migration, 0, len(migrations))
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			result = append(result, migration)
+		}
+	}
+
+	return result, nil
+}
+
+// toRollBack returns up to steps last applied migrations, the latest goes first.
+func toRollBack(migrations []Migration, applied map[int]string, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, ErrInvalidSteps
+	}
+
+	if err := validate(migrations, applied); err != nil {
+		return nil, err
+	}
+
+	result := make([]Migration, 0, steps)
+	for i := len(migrations) - 1; i >= 0 && len(result) < steps; i-- {
+		migration := migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		if migration.Down == "" {
+			return nil, fmt.Errorf("%w: %s", ErrIrreversible, migration)
+		}
+
+		result = append(result, migration)
+	}
+
+	return result, nil
+}
+
+func validate(migrations []Migration, applied map[int]string) error {
+	known := make(map[int]Migration, len(migrations))
+	for _, migration := range migrations {
+		known[migration.Version] = migration
+	}
+
+	for version, appliedChecksum := range applied {
+		migration, ok := known[version]
+		if !ok {
+			return fmt.Errorf("%w: version %d", ErrUnknownVersion, version)
+		}
+
+		if migration.Checksum != appliedChecksum {
+			return fmt.Errorf("%w: %s", ErrChecksumMismatch, migration)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package migration
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	file := func(content string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(content)}
+	}
+
+	tests := []struct {
+		name    string
+		source  fstest.MapFS
+		want    []Migration
+		wantErr error
+	}{
+		{
+			name: "migrations are sorted by version",
+			source: fstest.MapFS{
+				"0002_seed.up.sql":   file("INSERT"),
+				"0001_init.up.sql":   file("CREATE"),
+				"0001_init.down.sql": file("DROP"),
+				"README.md":          file("not a migration"),
+			},
+			want: []Migration{
+				{Version: 1, Name: "init", Up: "CREATE", Down: "DROP", Checksum: checksum("CREATE")},
+				{Version: 2, Name: "seed", Up: "INSERT", Checksum: checksum("INSERT")},
+			},
+		},
+		{
+			name:    "invalid file name",
+			source:  fstest.MapFS{"init.sql": file("CREATE")},
+			wantErr: ErrInvalidFileName,
+		},
+		{
+			name: "same version with different names",
+			source: fstest.MapFS{
+				"0001_init.up.sql":  file("CREATE"),
+				"0001_other.up.sql": file("CREATE"),
+			},
+			wantErr: ErrDuplicateVersion,
+		},
+		{
+			name: "same version written with different padding",
+			source: fstest.MapFS{
+				"0001_init.up.sql": file("CREATE"),
+				"1_init.up.sql":    file("CREATE"),
+			},
+			wantErr: ErrDuplicateVersion,
+		},
+		{
+			name:    "only down script",
+			source:  fstest.MapFS{"0001_init.down.sql": file("DROP")},
+			wantErr: ErrMissingUp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.source)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPending(t *testing.T) {
+	migrations := testMigrations()
+
+	tests := []struct {
+		name    string
+		applied map[int]string
+		want    []Migration
+		wantErr error
+	}{
+		{
+			name:    "nothing is applied",
+			applied: map[int]string{},
+			want:    migrations,
+		},
+		{
+			name:    "only new migrations are pending",
+			applied: map[int]string{1: migrations[0].Checksum},
+			want:    migrations[1:],
+		},
+		{
+			name:    "applied migration was edited",
+			applied: map[int]string{1: checksum("CREATE TABLE old")},
+			wantErr: ErrChecksumMismatch,
+		},
+		{
+			name:    "applied migration was removed from sources",
+			applied: map[int]string{3: checksum("DROP")},
+			wantErr: ErrUnknownVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pending(migrations, tt.applied)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestToRollBack(t *testing.T) {
+	migrations := testMigrations()
+	allApplied := map[int]string{
+		1: migrations[0].Checksum,
+		2: migrations[1].Checksum,
+	}
+
+	tests := []struct {
+		name       string
+		migrations []Migration
+		applied    map[int]string
+		steps      int
+		want       []Migration
+		wantErr    error
+	}{
+		{
+			name:       "latest migration goes first",
+			migrations: migrations,
+			applied:    allApplied,
+			steps:      2,
+			want:       []Migration{migrations[1], migrations[0]},
+		},
+		{
+			name:       "steps are limited by applied migrations",
+			migrations: migrations,
+			applied:    map[int]string{1: migrations[0].Checksum},
+			steps:      5,
+			want:       migrations[:1],
+		},
+		{
+			name:       "not positive steps",
+			migrations: migrations,
+			applied:    allApplied,
+			steps:      0,
+			wantErr:    ErrInvalidSteps,
+		},
+		{
+			name:       "migration without down script",
+			migrations: []Migration{migrations[0], {Version: 2, Name: "seed", Up: "INSERT", Checksum: checksum("INSERT")}},
+			applied:    map[int]string{1: migrations[0].Checksum, 2: checksum("INSERT")},
+			steps:      1,
+			wantErr:    ErrIrreversible,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toRollBack(tt.migrations, tt.applied, tt.steps)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func testMigrations() []Migration {
+	return []Migration{
+		{Version: 1, Name: "init", Up: "CREATE", Down: "DROP", Checksum: checksum("CREATE")},
+		{Version: 2, Name: "seed", Up: "INSERT", Down: "DELETE", Checksum: checksum("INSERT")},
+	}
+}
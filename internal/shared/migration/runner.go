@@ -0,0 +1,168 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lockKey is the advisory lock shared by every process migrating the same database,
+// so replicas started together apply migrations one at a time.
+const lockKey int64 = 6_274_519_033
+
+const createTableQuery = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    checksum CHAR(64) NOT NULL,
+    applied_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+);`
+
+type Runner struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+}
+
+func NewRunner(pool *pgxpool.Pool, source fs.FS) (*Runner, error) {
+	if pool == nil {
+		return nil, errors.New("passed pool is nil")
+	}
+
+	migrations, err := Load(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Runner{
+		pool:       pool,
+		migrations: migrations,
+	}, nil
+}
+
+// Up applies all pending migrations, each one in its own transaction.
+func (r Runner) Up(ctx context.Context) error {
+	return r.withLock(ctx, func(conn *pgxpool.Conn, applied map[int]string) error {
+		migrations, err := pending(r.migrations, applied)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			if err := apply(ctx, conn, migration); err != nil {
+				return fmt.Errorf("applying migration %s failed: %w", migration, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Down rolls back the given number of the last applied migrations.
+func (r Runner) Down(ctx context.Context, steps int) error {
+	return r.withLock(ctx, func(conn *pgxpool.Conn, applied map[int]string) error {
+		migrations, err := toRollBack(r.migrations, applied, steps)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			if err := rollBack(ctx, conn, migration); err != nil {
+				return fmt.Errorf("rolling back migration %s failed: %w", migration, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r Runner) withLock(ctx context.Context, do func(conn *pgxpool.Conn, applied map[int]string) error) (err error) {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return err
+	}
+
+	defer func() {
+		_, unlockErr := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+		err = errors.Join(err, unlockErr)
+	}()
+
+	if _, err := conn.Exec(ctx, createTableQuery); err != nil {
+		return err
+	}
+
+	applied, err := appliedChecksums(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	return do(conn, applied)
+}
+
+func appliedChecksums(ctx context.Context, conn *pgxpool.Conn) (map[int]string, error) {
+	const query = `
+SELECT version, checksum
+FROM schema_migrations;`
+
+	rows, err := conn.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	applied := make(map[int]string)
+	for rows.Next() {
+		var (
+			version  int
+			checksum string
+		)
+
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return nil, err
+		}
+
+		applied[version] = checksum
+	}
+
+	return applied, rows.Err()
+}
+
+func apply(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	const query = `
+INSERT INTO schema_migrations (version, name, checksum)
+VALUES ($1, $2, $3);`
+
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Up); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(ctx, query, migration.Version, migration.Name, migration.Checksum)
+		return err
+	})
+}
+
+func rollBack(ctx context.Context, conn *pgxpool.Conn, migration Migration) error {
+	const query = `
+DELETE FROM schema_migrations
+WHERE version = $1;`
+
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, migration.Down); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(ctx, query, migration.Version)
+		return err
+	})
+}
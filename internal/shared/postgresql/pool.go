@@ -2,19 +2,77 @@ package postgresql
 
 import (
 	"context"
+	"fmt"
+	"io/fs"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/zhuboris/never-expires/internal/shared/migration"
 	"github.com/zhuboris/never-expires/internal/shared/try"
 )
 
+const attemptDelay = 5 * time.Second
+
 func MakePool(ctx context.Context, config Config) (*pgxpool.Pool, error) {
-	const attemptDelay = 5 * time.Second
+	poolConfig, err := pgxpool.ParseConfig(config.ConnString())
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q failed: %w", config.Info(), err)
+	}
 
+	poolConfig.ConnConfig.Tracer = queryTracer{dbName: config.DBName}
 	newPoolFunc := func() (*pgxpool.Pool, error) {
-		return pgxpool.New(ctx, config.ConnString())
+		return pgxpool.NewWithConfig(ctx, poolConfig)
 	}
 
 	return try.GetWithAttempts(ctx, newPoolFunc, attemptDelay)
 }
+
+// MakeMigratedPool makes pool and applies pending migrations from source before it is used.
+func MakeMigratedPool(ctx context.Context, config Config, source fs.FS) (*pgxpool.Pool, error) {
+	pool, err := MakePool(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrate(ctx, pool, source); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("migrating %q failed: %w", config.Info(), err)
+	}
+
+	return pool, nil
+}
+
+func migrate(ctx context.Context, pool *pgxpool.Pool, source fs.FS) error {
+	runner, err := migration.NewRunner(pool, source)
+	if err != nil {
+		return err
+	}
+
+	pingFunc := func() error {
+		return pool.Ping(ctx)
+	}
+
+	if err := try.DoWithAttempts(ctx, pingFunc, attemptDelay); err != nil {
+		return err
+	}
+
+	return runner.Up(ctx)
+}
+
+// RunMigrationCommand handles arguments of migrate subcommand against database from config.
+func RunMigrationCommand(ctx context.Context, config Config, source fs.FS, args []string) error {
+	pool, err := MakePool(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	defer pool.Close()
+
+	runner, err := migration.NewRunner(pool, source)
+	if err != nil {
+		return err
+	}
+
+	return migration.RunCommand(ctx, runner, args)
+}
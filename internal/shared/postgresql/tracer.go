@@ -0,0 +1,38 @@
+package postgresql
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
+)
+
+const dbRowsAffectedAttributeKey = attribute.Key("db.rows_affected")
+
+// queryTracer makes client span for every query of the pool, so slow queries are visible inside the request trace.
+type queryTracer struct {
+	dbName string
+}
+
+func (t queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, _ = tracing.Start(ctx, "postgresql.query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBName(t.dbName),
+			semconv.DBStatement(data.SQL),
+		),
+	)
+
+	return ctx
+}
+
+func (t queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(dbRowsAffectedAttributeKey.Int64(data.CommandTag.RowsAffected()))
+	tracing.End(span, data.Err)
+}
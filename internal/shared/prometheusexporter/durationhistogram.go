@@ -0,0 +1,36 @@
+package prometheusexporter
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type DurationHistogram struct {
+	metric *prometheus.HistogramVec
+}
+
+// NewDurationHistogram registers histogram of durations in seconds, labelValues of Observe must match labels.
+func (e *PrometheusExporter) NewDurationHistogram(name, help string, labels ...string) (*DurationHistogram, error) {
+	histogram := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    name,
+			Help:    help,
+			Buckets: prometheus.DefBuckets,
+		},
+		labels,
+	)
+
+	if err := prometheus.Register(histogram); err != nil {
+		return nil, errors.Join(errFailedRegisterHistogram, err)
+	}
+
+	return &DurationHistogram{
+		metric: histogram,
+	}, nil
+}
+
+func (h DurationHistogram) Observe(duration time.Duration, labelValues ...string) {
+	h.metric.WithLabelValues(labelValues...).Observe(duration.Seconds())
+}
@@ -3,6 +3,10 @@ package prometheusexporter
 import "errors"
 
 var (
-	errFailedRegisterRequestCounter = errors.New("failed to register request counter")
-	errFailedRegisterStatusDisplay  = errors.New("failed to register status display")
+	errFailedRegisterRequestCounter  = errors.New("failed to register request counter")
+	errFailedRegisterStatusDisplay   = errors.New("failed to register status display")
+	errFailedRegisterThrottleCounter = errors.New("failed to register throttle counter")
+	errFailedRegisterRequestDuration = errors.New("failed to register request duration histogram")
+	errFailedRegisterHistogram       = errors.New("failed to register histogram")
+	errFailedRegisterPoolStats       = errors.New("failed to register pool stats")
 )
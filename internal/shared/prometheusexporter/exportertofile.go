@@ -10,12 +10,7 @@ import (
 func (e *PrometheusExporter) WriteToFile(path string) error {
 	const rwPermission = 0644
 
-	metricURL, err := e.metricURL()
-	if err != nil {
-		return err
-	}
-
-	resp, err := http.Get(metricURL)
+	resp, err := http.Get(e.metricURL())
 	if err != nil {
 		return err
 	}
@@ -29,12 +24,6 @@ func (e *PrometheusExporter) WriteToFile(path string) error {
 	return os.WriteFile(path, body, rwPermission)
 }
 
-func (e *PrometheusExporter) metricURL() (string, error) {
-	portAddress, err := e.portAddressToListen()
-	if err != nil {
-		return "", err
-	}
-
-	metricURL := fmt.Sprintf("http://localhost%s%s", portAddress, metricEndpoint)
-	return metricURL, nil
+func (e *PrometheusExporter) metricURL() string {
+	return fmt.Sprintf("http://localhost%s%s", e.addr, metricEndpoint)
 }
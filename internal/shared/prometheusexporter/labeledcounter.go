@@ -0,0 +1,33 @@
+package prometheusexporter
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type LabeledCounter struct {
+	metric *prometheus.CounterVec
+}
+
+func (e *PrometheusExporter) NewLabeledCounter(name, help string, labels ...string) (*LabeledCounter, error) {
+	counter := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: name,
+			Help: help,
+		},
+		labels,
+	)
+
+	if err := prometheus.Register(counter); err != nil {
+		return nil, errors.Join(errFailedRegisterRequestCounter, err)
+	}
+
+	return &LabeledCounter{
+		metric: counter,
+	}, nil
+}
+
+func (c LabeledCounter) Increment(labelValues ...string) {
+	c.metric.WithLabelValues(labelValues...).Inc()
+}
@@ -0,0 +1,33 @@
+package prometheusexporter
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type LabeledGauge struct {
+	metric *prometheus.GaugeVec
+}
+
+func (e *PrometheusExporter) NewLabeledGauge(name, help string, labels ...string) (*LabeledGauge, error) {
+	gauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: name,
+			Help: help,
+		},
+		labels,
+	)
+
+	if err := prometheus.Register(gauge); err != nil {
+		return nil, errors.Join(errFailedRegisterStatusDisplay, err)
+	}
+
+	return &LabeledGauge{
+		metric: gauge,
+	}, nil
+}
+
+func (g LabeledGauge) Set(value float64, labelValues ...string) {
+	g.metric.WithLabelValues(labelValues...).Set(value)
+}
@@ -0,0 +1,64 @@
+package prometheusexporter
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolStatsCollector reads stats of the pool on every scrape, so they are never stale.
+type poolStatsCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns    *prometheus.Desc
+	idleConns        *prometheus.Desc
+	totalConns       *prometheus.Desc
+	maxConns         *prometheus.Desc
+	acquiresTotal    *prometheus.Desc
+	emptyAcquires    *prometheus.Desc
+	acquireWaitTotal *prometheus.Desc
+}
+
+// RegisterPoolStats exports connections stats of pool labeled by dbName.
+func (e *PrometheusExporter) RegisterPoolStats(dbName string, pool *pgxpool.Pool) error {
+	labels := prometheus.Labels{"db": dbName}
+	collector := &poolStatsCollector{
+		pool:             pool,
+		acquiredConns:    prometheus.NewDesc("pgxpool_acquired_connections", "Number of connections currently in use", nil, labels),
+		idleConns:        prometheus.NewDesc("pgxpool_idle_connections", "Number of idle connections in the pool", nil, labels),
+		totalConns:       prometheus.NewDesc("pgxpool_total_connections", "Number of all connections in the pool", nil, labels),
+		maxConns:         prometheus.NewDesc("pgxpool_max_connections", "Maximum size of the pool", nil, labels),
+		acquiresTotal:    prometheus.NewDesc("pgxpool_acquires_total", "Total number of successful acquires from the pool", nil, labels),
+		emptyAcquires:    prometheus.NewDesc("pgxpool_empty_acquires_total", "Total number of acquires that waited for a connection because the pool was empty", nil, labels),
+		acquireWaitTotal: prometheus.NewDesc("pgxpool_acquire_wait_seconds_total", "Total time spent waiting for connections from the pool", nil, labels),
+	}
+
+	if err := prometheus.Register(collector); err != nil {
+		return errors.Join(errFailedRegisterPoolStats, err)
+	}
+
+	return nil
+}
+
+func (c *poolStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquiresTotal
+	ch <- c.emptyAcquires
+	ch <- c.acquireWaitTotal
+}
+
+func (c *poolStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquiresTotal, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireWaitTotal, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
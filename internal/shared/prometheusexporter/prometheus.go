@@ -2,9 +2,7 @@ package prometheusexporter
 
 import (
 	"context"
-	"errors"
 	"net/http"
-	"os"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
@@ -13,41 +11,33 @@ import (
 
 const metricEndpoint = "/metrics"
 
+type Config struct {
+	Address string `env:"METRICS_EXPORTER_ADDRESS" required:"true"`
+}
+
 type PrometheusExporter struct {
+	addr   string
 	server *http.Server
 }
 
-func New() *PrometheusExporter {
-	return &PrometheusExporter{}
+func New(config Config) *PrometheusExporter {
+	return &PrometheusExporter{
+		addr: config.Address,
+	}
 }
 
 func (e *PrometheusExporter) RunWithCtx(ctx context.Context) error {
-	return runapi.WithContext(ctx, e.run, func() error {
-		return e.server.Shutdown(context.Background())
+	return runapi.WithContext(ctx, e.run, func(ctx context.Context) error {
+		return e.server.Shutdown(ctx)
 	})
 }
 
 func (e *PrometheusExporter) run() error {
-	addr, err := e.portAddressToListen()
-	if err != nil {
-		return err
-	}
-
 	e.server = &http.Server{
-		Addr: addr,
+		Addr: e.addr,
 	}
 
 	http.Handle(metricEndpoint, promhttp.Handler())
 
 	return e.server.ListenAndServe()
 }
-
-func (e *PrometheusExporter) portAddressToListen() (string, error) {
-	const metricsServerListenAddrKey = "METRICS_EXPORTER_ADDRESS"
-
-	addr := os.Getenv(metricsServerListenAddrKey)
-	if addr == "" {
-		return "", errors.New("missing address to listen")
-	}
-	return addr, nil
-}
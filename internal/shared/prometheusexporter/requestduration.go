@@ -0,0 +1,42 @@
+package prometheusexporter
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type RequestDurationHistogram struct {
+	metric *prometheus.HistogramVec
+}
+
+func (e *PrometheusExporter) NewRequestDurationHistogram() (*RequestDurationHistogram, error) {
+	histogram := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Duration of HTTP requests handling",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"route", "method", "code"},
+	)
+
+	if err := prometheus.Register(histogram); err != nil {
+		return nil, errors.Join(errFailedRegisterRequestDuration, err)
+	}
+
+	return &RequestDurationHistogram{
+		metric: histogram,
+	}, nil
+}
+
+// Observe records duration by route pattern, so requests with different path params are in the same series.
+func (h RequestDurationHistogram) Observe(route, method string, statusCode int, duration time.Duration) {
+	if statusCode == http.StatusMethodNotAllowed {
+		method = "NOT ALLOWED"
+	}
+
+	h.metric.WithLabelValues(route, method, strconv.Itoa(statusCode)).Observe(duration.Seconds())
+}
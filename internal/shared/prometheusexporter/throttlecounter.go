@@ -0,0 +1,33 @@
+package prometheusexporter
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type ThrottleCounter struct {
+	metric *prometheus.CounterVec
+}
+
+func (e *PrometheusExporter) NewThrottleCounter() (*ThrottleCounter, error) {
+	counter := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_requests_throttled_total",
+			Help: "Total number of HTTP requests refused by rate limits",
+		},
+		[]string{"endpoint", "limitedBy"},
+	)
+
+	if err := prometheus.Register(counter); err != nil {
+		return nil, errors.Join(errFailedRegisterThrottleCounter, err)
+	}
+
+	return &ThrottleCounter{
+		metric: counter,
+	}, nil
+}
+
+func (c ThrottleCounter) Increment(route, limitedBy string) {
+	c.metric.WithLabelValues(route, limitedBy).Inc()
+}
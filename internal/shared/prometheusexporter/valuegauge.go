@@ -0,0 +1,30 @@
+package prometheusexporter
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type ValueGauge struct {
+	metric prometheus.Gauge
+}
+
+func (e *PrometheusExporter) NewValueGauge(name, help string) (*ValueGauge, error) {
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: name,
+		Help: help,
+	})
+
+	if err := prometheus.Register(gauge); err != nil {
+		return nil, errors.Join(errFailedRegisterStatusDisplay, err)
+	}
+
+	return &ValueGauge{
+		metric: gauge,
+	}, nil
+}
+
+func (g ValueGauge) Set(value float64) {
+	g.metric.Set(value)
+}
@@ -3,7 +3,6 @@ package rabbitmq
 import (
 	"context"
 	"errors"
-	"os"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -13,30 +12,40 @@ import (
 	"github.com/zhuboris/never-expires/internal/shared/try"
 )
 
-const connectionURLEnvKey = "RABBIT_MQ_CONN_STRING"
+type Config struct {
+	URL string `env:"RABBIT_MQ_CONN_STRING" required:"true" secret:"true"`
+}
 
 var errConnectionFail = errors.New("failed to connect to Rabbit MQ")
 
+// returnsBuffer keeps returned messages until the producer waits for their confirmations, only one message is published at a time.
+const returnsBuffer = 1
+
 type client struct {
-	url        string
-	queueName  string
-	connection *amqp.Connection
-	channel    *amqp.Channel
-	queue      amqp.Queue
-	logger     *zap.Logger
+	url         string
+	queueName   string
+	exchange    string
+	bindingKeys []string
+	connection  *amqp.Connection
+	channel     *amqp.Channel
+	queue       amqp.Queue
+	isConfirmed bool
+	returns     chan amqp.Return
+	metrics     *Metrics
+	logger      *zap.Logger
 }
 
-func newClient(queueName string, logger *zap.Logger) (client, error) {
-	url := os.Getenv(connectionURLEnvKey)
-	if url == "" {
-		return client{}, errors.New("connection url is not set in envs")
-	}
-
+func newClient(config Config, queueName string, logger *zap.Logger) client {
 	return client{
-		url:       url,
+		url:       config.URL,
 		logger:    logger,
 		queueName: queueName,
-	}, nil
+	}
+}
+
+// SetMetrics makes the client record its latencies, without metrics nothing is recorded.
+func (c *client) SetMetrics(metrics *Metrics) {
+	c.metrics = metrics
 }
 
 func (c *client) connectIfNeeded() error {
@@ -74,10 +83,28 @@ func (c *client) handleConnection() error {
 	}
 
 	c.channel = newChan
+	if err = c.setConfirmMode(); err != nil {
+		return err
+	}
+
 	err = c.setQueue()
 	return err
 }
 
+// setConfirmMode makes the broker acknowledge every published message and return the ones that have no queue to be routed to.
+func (c *client) setConfirmMode() error {
+	if !c.isConfirmed {
+		return nil
+	}
+
+	if err := c.channel.Confirm(false /* noWait*/); err != nil {
+		return err
+	}
+
+	c.returns = c.channel.NotifyReturn(make(chan amqp.Return, returnsBuffer))
+	return nil
+}
+
 func (c *client) connectToAMQP() error {
 	conn, err := amqp.Dial(c.url)
 	if err != nil {
@@ -96,16 +123,42 @@ func (c *client) makeChannel() (*amqp.Channel, error) {
 }
 
 func (c *client) setQueue() error {
+	if c.exchange != "" {
+		err := c.channel.ExchangeDeclare(c.exchange, amqp.ExchangeTopic, true /* durable*/, false /* autoDelete*/, false /* internal*/, false /* noWait*/, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.queueName == "" {
+		return nil
+	}
+
 	queue, err := c.channel.QueueDeclare(c.queueName, true /* durable*/, false /* autoDelete*/, false /* exclusive*/, false /* noWait*/, nil)
 
 	if err != nil {
 		return err
 	}
 
+	for _, key := range c.bindingKeys {
+		err = c.channel.QueueBind(queue.Name, key, c.exchange, false /* noWait*/, nil)
+		if err != nil {
+			return err
+		}
+	}
+
 	c.queue = queue
 	return nil
 }
 
+func (c *client) closeConnection() {
+	if c.connection == nil || c.connection.IsClosed() {
+		return
+	}
+
+	c.connection.Close()
+}
+
 func (c *client) logConnection(err error) {
 	msg := "Connected successfully"
 	logLvl := zapcore.InfoLevel
@@ -2,29 +2,39 @@ package rabbitmq
 
 import (
 	"context"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/zhuboris/never-expires/internal/shared/runapi"
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
 )
 
+const queueDepthInterval = 15 * time.Second
+
 type Job func(ctx context.Context, messageID string, message []byte) error
 
 type Consumer struct {
 	client
 }
 
-func NewConsumer(queueName string, logger *zap.Logger) (*Consumer, error) {
-	client, err := newClient(queueName, logger)
-	if err != nil {
-		return nil, err
-	}
+func NewConsumer(config Config, queueName string, logger *zap.Logger) *Consumer {
+	return &Consumer{newClient(config, queueName, logger)}
+}
 
-	return &Consumer{client}, nil
+func NewTopicConsumer(config Config, exchange, queueName string, bindingKeys []string, logger *zap.Logger) *Consumer {
+	client := newClient(config, queueName, logger)
+	client.exchange = exchange
+	client.bindingKeys = bindingKeys
+	return &Consumer{client}
 }
 
 func (c *Consumer) ExecuteJobOnMessages(ctx context.Context, job Job) error {
-	defer c.connection.Close()
+	defer c.closeConnection()
 
 	return c.consume(ctx, job)
 }
@@ -40,22 +50,80 @@ func (c *Consumer) consume(ctx context.Context, job Job) error {
 		return err
 	}
 
+	queueDepthTicker := time.NewTicker(queueDepthInterval)
+	defer queueDepthTicker.Stop()
+
 	for {
 		select {
+		case <-queueDepthTicker.C:
+			c.recordQueueDepth()
 		case msg, ok := <-messages:
 			if !ok {
 				c.logger.Error("Channel is closed, restarting")
 				return c.consume(ctx, job)
 			}
 
-			err := job(ctx, msg.MessageId, msg.Body)
-			c.handleJobResult(msg, err)
+			if ctx.Err() != nil {
+				c.requeue(msg)
+				return ctx.Err()
+			}
+
+			c.executeJob(ctx, job, msg)
 		case <-ctx.Done():
 			return ctx.Err()
 		}
 	}
 }
 
+// executeJob runs job in consumer span that continues the trace of the producer from message headers.
+// The job is not canceled on shutdown until the drain timeout is passed, so it is finished and acknowledged.
+func (c *Consumer) executeJob(ctx context.Context, job Job, msg amqp.Delivery) {
+	ctx, cancel := runapi.Detach(ctx)
+	defer cancel()
+
+	ctx = tracing.Extract(ctx, headerCarrier(msg.Headers))
+	ctx, span := tracing.Start(ctx, c.queue.Name+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystem(messagingSystem),
+			semconv.MessagingSourceName(c.queue.Name),
+			semconv.MessagingMessageID(msg.MessageId),
+		),
+	)
+
+	if requestID := tracing.RequestID(ctx); requestID != "" {
+		span.SetAttributes(tracing.RequestIDAttributeKey.String(requestID))
+	}
+
+	c.metrics.observeDelivery(c.queue.Name, msg.Timestamp)
+	startTime := time.Now()
+	err := job(ctx, msg.MessageId, msg.Body)
+	c.metrics.observeJob(c.queue.Name, time.Since(startTime), err)
+	tracing.End(span, err)
+	c.handleJobResult(msg, err)
+}
+
+// requeue returns delivery that was received after shutdown is started, so another consumer handles it.
+func (c *Consumer) requeue(msg amqp.Delivery) {
+	if err := msg.Nack(false /* multiple */, true /* requeue */); err != nil {
+		c.logger.Error("Failed to requeue message on shutdown", zap.String("messageID", msg.MessageId), zap.Error(err))
+	}
+}
+
+func (c *Consumer) recordQueueDepth() {
+	if c.metrics == nil {
+		return
+	}
+
+	queue, err := c.channel.QueueDeclarePassive(c.queue.Name, true /* durable*/, false /* autoDelete*/, false /* exclusive*/, false /* noWait*/, nil)
+	if err != nil {
+		c.logger.Warn("Failed to inspect queue", zap.Error(err))
+		return
+	}
+
+	c.metrics.setQueueDepth(queue.Name, queue.Messages)
+}
+
 func (c *Consumer) handleJobResult(msg amqp.Delivery, jobError error) {
 	if jobError != nil {
 		c.handleRejectOnJobError(msg, jobError)
@@ -0,0 +1,54 @@
+package rabbitmq
+
+import (
+	"context"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
+)
+
+func TestHeaderCarrier(t *testing.T) {
+	const requestID = "6f1d3c4e-2b1a-4c9e-9f2d-3a5b7c9d1e2f"
+
+	_, err := tracing.Init(context.Background(), tracing.Config{}, "test")
+	require.NoError(t, err)
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracing.NewProvider(recorder, "test", 100))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	ctx, span := tracing.Start(tracing.WithRequestID(context.Background(), requestID), "request")
+	defer span.End()
+
+	headers := make(amqp.Table)
+	tracing.Inject(ctx, headerCarrier(headers))
+	assert.Contains(t, headerCarrier(headers).Keys(), "traceparent")
+
+	consumerCtx := tracing.Extract(context.Background(), headerCarrier(headers))
+	remote := trace.SpanContextFromContext(consumerCtx)
+	assert.True(t, remote.IsRemote())
+	assert.Equal(t, span.SpanContext().TraceID(), remote.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), remote.SpanID())
+	assert.Equal(t, requestID, tracing.RequestID(consumerCtx))
+}
+
+func TestHeaderCarrier_Get(t *testing.T) {
+	headers := headerCarrier{
+		"traceparent":   "value",
+		"x-retry-count": int32(1),
+	}
+
+	assert.Equal(t, "value", headers.Get("traceparent"))
+	assert.Empty(t, headers.Get("x-retry-count"), "non-string header must be ignored")
+	assert.Empty(t, headers.Get("missing"))
+}
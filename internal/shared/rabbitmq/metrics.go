@@ -0,0 +1,94 @@
+package rabbitmq
+
+import (
+	"time"
+
+	"github.com/zhuboris/never-expires/internal/shared/prometheusexporter"
+)
+
+const (
+	successValue = "success"
+	failValue    = "fail"
+)
+
+type metricsExporter interface {
+	NewDurationHistogram(name, help string, labels ...string) (*prometheusexporter.DurationHistogram, error)
+	NewLabeledGauge(name, help string, labels ...string) (*prometheusexporter.LabeledGauge, error)
+}
+
+// Metrics are shared by all producers and consumers of the service, so they are made once and set to every client.
+type Metrics struct {
+	publishDuration *prometheusexporter.DurationHistogram
+	deliveryDelay   *prometheusexporter.DurationHistogram
+	jobDuration     *prometheusexporter.DurationHistogram
+	queueDepth      *prometheusexporter.LabeledGauge
+}
+
+func NewMetrics(exporter metricsExporter) (*Metrics, error) {
+	publishDuration, err := exporter.NewDurationHistogram("rabbitmq_publish_duration_seconds", "Duration of publishing messages to RabbitMQ", "routingKey", "result")
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryDelay, err := exporter.NewDurationHistogram("rabbitmq_delivery_delay_seconds", "Time from publishing a message until a consumer takes it", "queue")
+	if err != nil {
+		return nil, err
+	}
+
+	jobDuration, err := exporter.NewDurationHistogram("rabbitmq_job_duration_seconds", "Duration of handling consumed messages", "queue", "result")
+	if err != nil {
+		return nil, err
+	}
+
+	queueDepth, err := exporter.NewLabeledGauge("rabbitmq_queue_messages", "Number of messages that are ready to be consumed", "queue")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Metrics{
+		publishDuration: publishDuration,
+		deliveryDelay:   deliveryDelay,
+		jobDuration:     jobDuration,
+		queueDepth:      queueDepth,
+	}, nil
+}
+
+func (m *Metrics) observePublish(routingKey string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+
+	m.publishDuration.Observe(duration, routingKey, resultValue(err))
+}
+
+func (m *Metrics) observeDelivery(queue string, publishedAt time.Time) {
+	if m == nil || publishedAt.IsZero() {
+		return
+	}
+
+	m.deliveryDelay.Observe(time.Since(publishedAt), queue)
+}
+
+func (m *Metrics) observeJob(queue string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+
+	m.jobDuration.Observe(duration, queue, resultValue(err))
+}
+
+func (m *Metrics) setQueueDepth(queue string, messages int) {
+	if m == nil {
+		return
+	}
+
+	m.queueDepth.Set(float64(messages), queue)
+}
+
+func resultValue(err error) string {
+	if err != nil {
+		return failValue
+	}
+
+	return successValue
+}
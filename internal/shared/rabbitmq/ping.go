@@ -0,0 +1,46 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Pinger checks that the broker accepts connections, it does not affect connections of producers and consumers.
+type Pinger struct {
+	url string
+}
+
+func NewPinger(config Config) Pinger {
+	return Pinger{
+		url: config.URL,
+	}
+}
+
+func (p Pinger) Ping(ctx context.Context) error {
+	dialer := new(net.Dialer)
+	amqpConfig := amqp.Config{
+		Dial: func(network, addr string) (net.Conn, error) {
+			conn, err := dialer.DialContext(ctx, network, addr)
+			if err != nil {
+				return nil, err
+			}
+
+			// deadline also limits the handshake, that is not aware of the context
+			if deadline, ok := ctx.Deadline(); ok {
+				err = conn.SetDeadline(deadline)
+			}
+
+			return conn, err
+		},
+	}
+
+	conn, err := amqp.DialConfig(p.url, amqpConfig)
+	if err != nil {
+		return errors.Join(errConnectionFail, err)
+	}
+
+	return conn.Close()
+}
@@ -0,0 +1,241 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/zhuboris/never-expires/internal/shared/runapi"
+	"github.com/zhuboris/never-expires/internal/shared/tracing"
+)
+
+const messagingSystem = "rabbitmq"
+
+var (
+	ErrNotRouted    = errors.New("message is returned by the broker, no queue is bound for its routing key")
+	ErrNotConfirmed = errors.New("message is not confirmed by the broker")
+)
+
+type outgoingMessage struct {
+	routingKey string
+	messageID  string
+	body       []byte
+	headers    amqp.Table
+	span       trace.Span
+	result     chan error
+}
+
+type Producer struct {
+	produceChan chan outgoingMessage
+
+	client
+}
+
+func NewProducer(config Config, queueName string, logger *zap.Logger) *Producer {
+	return newProducer(newClient(config, queueName, logger))
+}
+
+func NewTopicProducer(config Config, exchange string, logger *zap.Logger) *Producer {
+	client := newClient(config, "", logger)
+	client.exchange = exchange
+	return newProducer(client)
+}
+
+func newProducer(client client) *Producer {
+	client.isConfirmed = true
+	return &Producer{
+		produceChan: make(chan outgoingMessage),
+		client:      client,
+	}
+}
+
+// DeclareQueue makes the topic producer declare and bind the queue of a consumer itself,
+// so messages are kept for the consumer even when it has not been started yet.
+func (p *Producer) DeclareQueue(queueName string, bindingKeys ...string) {
+	p.queueName = queueName
+	p.bindingKeys = bindingKeys
+}
+
+func (p *Producer) RunWithCtx(ctx context.Context) error {
+	defer p.closeConnection()
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.drain(ctx)
+			return ctx.Err()
+		case msg := <-p.produceChan:
+			if err := p.send(ctx, msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// drain keeps publishing messages of requests and jobs that are finished after the shutdown is started,
+// it stops when no message comes for a while or the drain timeout is passed.
+func (p *Producer) drain(ctx context.Context) {
+	const idleTimeout = 2 * time.Second
+
+	drainCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), runapi.DrainTimeout(ctx))
+	defer cancel()
+
+	for {
+		select {
+		case msg := <-p.produceChan:
+			if err := p.send(drainCtx, msg); err != nil {
+				return
+			}
+		case <-time.After(idleTimeout):
+			return
+		case <-drainCtx.Done():
+			return
+		}
+	}
+}
+
+func (p *Producer) send(ctx context.Context, msg outgoingMessage) error {
+	err := p.publish(ctx, msg)
+	if msg.result != nil {
+		msg.result <- err
+	}
+
+	return err
+}
+
+func (p *Producer) Publish(ctx context.Context, msg []byte) error {
+	outgoing := p.newOutgoingMessage(ctx, p.queueName, uuid.New().String(), msg)
+
+	select {
+	case p.produceChan <- outgoing:
+		return nil
+	case <-ctx.Done():
+		tracing.End(outgoing.span, ctx.Err())
+		return ctx.Err()
+	}
+}
+
+// PublishWithKey waits until the broker confirms that the message is routed to a queue, so the caller knows whether it was published.
+func (p *Producer) PublishWithKey(ctx context.Context, routingKey, messageID string, msg []byte) error {
+	outgoing := p.newOutgoingMessage(ctx, routingKey, messageID, msg)
+	outgoing.result = make(chan error, 1)
+
+	select {
+	case p.produceChan <- outgoing:
+	case <-ctx.Done():
+		tracing.End(outgoing.span, ctx.Err())
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-outgoing.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// newOutgoingMessage starts producer span that ends when the message is published,
+// its context is added to headers so the consumer continues the same trace.
+func (p *Producer) newOutgoingMessage(ctx context.Context, routingKey, messageID string, msg []byte) outgoingMessage {
+	ctx, span := tracing.Start(ctx, routingKey+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystem(messagingSystem),
+			semconv.MessagingRabbitmqDestinationRoutingKey(routingKey),
+			semconv.MessagingMessageID(messageID),
+		),
+	)
+
+	headers := make(amqp.Table)
+	tracing.Inject(ctx, headerCarrier(headers))
+
+	return outgoingMessage{
+		routingKey: routingKey,
+		messageID:  messageID,
+		body:       msg,
+		headers:    headers,
+		span:       span,
+	}
+}
+
+func (p *Producer) publish(ctx context.Context, msg outgoingMessage) error {
+	var err error
+	defer func(startTime time.Time) {
+		p.logPublish(msg.messageID, msg.routingKey, err)
+		p.metrics.observePublish(msg.routingKey, time.Since(startTime), err)
+		tracing.End(msg.span, err)
+	}(time.Now())
+
+	if err = p.connectIfNeeded(); err != nil {
+		return err
+	}
+
+	confirmation, err := p.channel.PublishWithDeferredConfirmWithContext(ctx,
+		p.exchange,
+		msg.routingKey,
+		true, /* mandatory*/
+		false,
+		amqp.Publishing{
+			MessageId:    msg.messageID,
+			Timestamp:    time.Now(),
+			DeliveryMode: amqp.Persistent,
+			ContentType:  "application/json",
+			Headers:      msg.headers,
+			Body:         msg.body,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	err = p.waitConfirmation(ctx, confirmation, msg.messageID)
+	return err
+}
+
+// waitConfirmation relies on the broker sending return of unroutable message before its ack,
+// both are dispatched in that order, so the return is already buffered when the ack is received.
+func (p *Producer) waitConfirmation(ctx context.Context, confirmation *amqp.DeferredConfirmation, messageID string) error {
+	isAcked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !isAcked {
+		return ErrNotConfirmed
+	}
+
+	for {
+		select {
+		case returned, ok := <-p.returns:
+			if !ok {
+				return ErrNotConfirmed
+			}
+
+			if returned.MessageId == messageID {
+				return fmt.Errorf("%w: %s", ErrNotRouted, returned.ReplyText)
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+func (p *Producer) logPublish(messageID, routingKey string, err error) {
+	msg := "Published successfully"
+	logLvl := zapcore.InfoLevel
+	if err != nil {
+		msg = "Failed to publish"
+		logLvl = zapcore.ErrorLevel
+	}
+
+	p.logger.Log(logLvl, msg, zap.String("messageID", messageID), zap.String("routingKey", routingKey), zap.Error(err))
+}
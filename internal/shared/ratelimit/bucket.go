@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit describes token bucket: Rate tokens are added every Period up to Burst tokens, every request takes one.
+type Limit struct {
+	Rate   int
+	Period time.Duration
+	Burst  int
+}
+
+func PerMinute(rate, burst int) Limit {
+	return Limit{
+		Rate:   rate,
+		Period: time.Minute,
+		Burst:  burst,
+	}
+}
+
+func PerHour(rate, burst int) Limit {
+	return Limit{
+		Rate:   rate,
+		Period: time.Hour,
+		Burst:  burst,
+	}
+}
+
+func (l Limit) tokensPerSecond() float64 {
+	return float64(l.Rate) / l.Period.Seconds()
+}
+
+// durationFor returns time needed to add the amount of tokens to the bucket.
+func (l Limit) durationFor(tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+
+	seconds := tokens / l.tokensPerSecond()
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
+
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is time until next request is allowed, it is zero for allowed requests.
+	RetryAfter time.Duration
+	// Reset is time until the bucket is full again.
+	Reset time.Duration
+}
+
+func newDecision(limit Limit, tokens float64, allowed bool) Decision {
+	decision := Decision{
+		Allowed:   allowed,
+		Limit:     limit.Burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     limit.durationFor(float64(limit.Burst) - tokens),
+	}
+
+	if !allowed {
+		decision.RetryAfter = limit.durationFor(1 - tokens)
+	}
+
+	return decision
+}
+
+// Store keeps token buckets, key of the bucket must include everything it is limited by.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Decision, error)
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// take refills the bucket for the time passed since last update and takes one token if it is available.
+func (b bucket) take(limit Limit, now time.Time) (bucket, bool) {
+	elapsed := now.Sub(b.updatedAt)
+	if elapsed > 0 {
+		b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed.Seconds()*limit.tokensPerSecond())
+		b.updatedAt = now
+	}
+
+	if b.tokens < 1 {
+		return b, false
+	}
+
+	b.tokens--
+	return b, true
+}
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// cleanupInterval bounds how often Take scans all buckets, so requests do not pay for the scan every time.
+const cleanupInterval = time.Minute
+
+// MemoryStore keeps buckets in the process memory, so every instance of the service has own limits.
+type MemoryStore struct {
+	now       func() time.Time
+	mu        sync.Mutex
+	buckets   map[string]bucketEntry
+	cleanedAt time.Time
+}
+
+type bucketEntry struct {
+	bucket
+	limit Limit
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		now:     time.Now,
+		buckets: make(map[string]bucketEntry),
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.cleanedAt) >= cleanupInterval {
+		s.removeFull(now)
+		s.cleanedAt = now
+	}
+
+	entry, ok := s.buckets[key]
+	if !ok {
+		entry.bucket = bucket{
+			tokens:    float64(limit.Burst),
+			updatedAt: now,
+		}
+	}
+
+	var allowed bool
+	entry.bucket, allowed = entry.take(limit, now)
+	entry.limit = limit
+	s.buckets[key] = entry
+
+	return newDecision(limit, entry.tokens, allowed), nil
+}
+
+// removeFull deletes buckets that are refilled completely, they are equal to the new ones.
+func (s *MemoryStore) removeFull(now time.Time) {
+	for key, entry := range s.buckets {
+		if now.Sub(entry.updatedAt) >= entry.limit.durationFor(float64(entry.limit.Burst)-entry.tokens) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	limit := PerMinute(6, 2) // one token every 10 seconds
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		hits     []time.Duration
+		key      string
+		at       time.Duration
+		expected Decision
+	}{
+		{
+			name: "first hit",
+			key:  "key",
+			at:   0,
+			expected: Decision{
+				Allowed:   true,
+				Limit:     2,
+				Remaining: 1,
+				Reset:     10 * time.Second,
+			},
+		},
+		{
+			name: "burst is used",
+			hits: []time.Duration{0},
+			key:  "key",
+			at:   0,
+			expected: Decision{
+				Allowed:   true,
+				Limit:     2,
+				Remaining: 0,
+				Reset:     20 * time.Second,
+			},
+		},
+		{
+			name: "bucket is empty",
+			hits: []time.Duration{0, 0},
+			key:  "key",
+			at:   4 * time.Second,
+			expected: Decision{
+				Allowed:    false,
+				Limit:      2,
+				Remaining:  0,
+				RetryAfter: 6 * time.Second,
+				Reset:      16 * time.Second,
+			},
+		},
+		{
+			name: "bucket is empty for other key",
+			hits: []time.Duration{0, 0},
+			key:  "other",
+			at:   4 * time.Second,
+			expected: Decision{
+				Allowed:   true,
+				Limit:     2,
+				Remaining: 1,
+				Reset:     10 * time.Second,
+			},
+		},
+		{
+			name: "token is added after period",
+			hits: []time.Duration{0, 0},
+			key:  "key",
+			at:   10 * time.Second,
+			expected: Decision{
+				Allowed:   true,
+				Limit:     2,
+				Remaining: 0,
+				Reset:     20 * time.Second,
+			},
+		},
+		{
+			name: "bucket is not filled over burst",
+			hits: []time.Duration{0},
+			key:  "key",
+			at:   time.Hour,
+			expected: Decision{
+				Allowed:   true,
+				Limit:     2,
+				Remaining: 1,
+				Reset:     10 * time.Second,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctx   = context.Background()
+				now   time.Time
+				store = NewMemoryStore()
+			)
+
+			store.now = func() time.Time {
+				return now
+			}
+
+			for _, hit := range tt.hits {
+				now = start.Add(hit)
+				_, err := store.Take(ctx, "key", limit)
+				require.NoError(t, err)
+			}
+
+			now = start.Add(tt.at)
+			decision, err := store.Take(ctx, tt.key, limit)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, decision)
+		})
+	}
+}
+
+func TestMemoryStore_TakeRemovesFullBuckets(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		now   = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		store = NewMemoryStore()
+		limit = PerMinute(60, 1)
+	)
+
+	store.now = func() time.Time {
+		return now
+	}
+
+	for _, key := range []string{"first", "second", "third"} {
+		_, err := store.Take(ctx, key, limit)
+		require.NoError(t, err)
+	}
+
+	now = now.Add(cleanupInterval / 2)
+	_, err := store.Take(ctx, "fourth", limit)
+	require.NoError(t, err)
+	assert.Len(t, store.buckets, 4, "buckets must not be scanned before the interval passes")
+
+	now = now.Add(cleanupInterval / 2)
+	_, err = store.Take(ctx, "new", limit)
+	require.NoError(t, err)
+	assert.Len(t, store.buckets, 1)
+}
+
+func TestParseScriptResult(t *testing.T) {
+	tests := []struct {
+		name        string
+		result      []any
+		wantAllowed bool
+		wantTokens  float64
+		wantErr     error
+	}{
+		{
+			name:        "allowed",
+			result:      []any{int64(1), "4.5"},
+			wantAllowed: true,
+			wantTokens:  4.5,
+		},
+		{
+			name:       "throttled",
+			result:     []any{int64(0), "0.25"},
+			wantTokens: 0.25,
+		},
+		{
+			name:    "unexpected length",
+			result:  []any{int64(1)},
+			wantErr: errUnexpectedScriptResult,
+		},
+		{
+			name:    "tokens are not a number",
+			result:  []any{int64(1), "many"},
+			wantErr: errUnexpectedScriptResult,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, tokens, err := parseScriptResult(tt.result)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantAllowed, allowed)
+			assert.Equal(t, tt.wantTokens, tokens)
+		})
+	}
+}
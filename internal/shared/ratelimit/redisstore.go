@@ -0,0 +1,122 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "ratelimit:"
+
+var errUnexpectedScriptResult = errors.New("unexpected result of rate limit script")
+
+// takeScript is the same as bucket.take, but it is done atomically in Redis,
+// so all instances of the service share the limits.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated_at')
+local tokens = tonumber(state[1])
+local updatedAt = tonumber(state[2])
+if tokens == nil or updatedAt == nil then
+	tokens = burst
+	updatedAt = now
+end
+
+if now > updatedAt then
+	tokens = math.min(burst, tokens + (now - updatedAt) * rate)
+	updatedAt = now
+end
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated_at', updatedAt)
+redis.call('PEXPIRE', KEYS[1], math.ceil((burst - tokens) / rate) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+type Config struct {
+	// RedisAddress is set to share limits between instances, otherwise they are kept in memory.
+	RedisAddress  string `env:"RATE_LIMIT_REDIS_ADDR"`
+	RedisUsername string `env:"RATE_LIMIT_REDIS_USERNAME"`
+	RedisPassword string `env:"RATE_LIMIT_REDIS_PASSWORD" secret:"true"`
+}
+
+func NewStore(config Config) Store {
+	if config.RedisAddress == "" {
+		return NewMemoryStore()
+	}
+
+	options := &redis.Options{
+		Addr:     config.RedisAddress,
+		Username: config.RedisUsername,
+		Password: config.RedisPassword,
+	}
+
+	return NewRedisStore(redis.NewClient(options))
+}
+
+type RedisStore struct {
+	client redis.Scripter
+	now    func() time.Time
+}
+
+func NewRedisStore(client redis.Scripter) *RedisStore {
+	return &RedisStore{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (Decision, error) {
+	var (
+		tokensPerMillisecond = limit.tokensPerSecond() / 1000
+		now                  = s.now().UnixMilli()
+	)
+
+	result, err := takeScript.Run(ctx, s.client, []string{redisKeyPrefix + key}, tokensPerMillisecond, limit.Burst, now).Slice()
+	if err != nil {
+		return Decision{}, err
+	}
+
+	allowed, tokens, err := parseScriptResult(result)
+	if err != nil {
+		return Decision{}, err
+	}
+
+	return newDecision(limit, tokens, allowed), nil
+}
+
+func parseScriptResult(result []any) (allowed bool, tokens float64, err error) {
+	const resultLength = 2
+
+	if len(result) != resultLength {
+		return false, 0, errUnexpectedScriptResult
+	}
+
+	allowedFlag, ok := result[0].(int64)
+	if !ok {
+		return false, 0, errUnexpectedScriptResult
+	}
+
+	tokensValue, ok := result[1].(string)
+	if !ok {
+		return false, 0, errUnexpectedScriptResult
+	}
+
+	tokens, err = strconv.ParseFloat(tokensValue, 64)
+	if err != nil {
+		return false, 0, errors.Join(errUnexpectedScriptResult, err)
+	}
+
+	return allowedFlag == 1, tokens, nil
+}
@@ -1,12 +1,123 @@
 package reqbody
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/zhuboris/never-expires/internal/shared/validation"
+)
+
+var (
+	ErrBodyTooLarge = errors.New("request body is too large")
+	ErrTrailingData = errors.New("request body must contain a single JSON value")
 )
 
+const unknownFieldErrorPrefix = "json: unknown field "
+
+// Decode strictly reads a single JSON value into to and checks it with validation.Struct.
+// Unknown fields, fields of wrong type or format and fields that failed validation are returned together as *validation.Report.
+// Body exceeding the limit set by http.MaxBytesReader is reported with ErrBodyTooLarge.
 func Decode(to any, from io.ReadCloser) error {
 	defer from.Close()
 
-	return json.NewDecoder(from).Decode(to)
+	data, err := io.ReadAll(from)
+	if err != nil {
+		return decodingError(err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	decodeErr := decoder.Decode(to)
+	if isSyntaxError(decodeErr) {
+		return decodeErr
+	}
+
+	if err := decoder.Decode(new(json.RawMessage)); !errors.Is(err, io.EOF) {
+		return errors.Join(ErrTrailingData, decodingError(err))
+	}
+
+	// Decoding goes on after errors of single values, so the rest of fields are set and can be validated too.
+	report := new(validation.Report)
+	if decodeErr != nil {
+		checkFields(reflect.TypeOf(to), data, "", report)
+		if len(report.Fields) == 0 {
+			return decodingError(decodeErr)
+		}
+	}
+
+	validationErr := validation.Struct(to)
+	fields, ok := validation.FieldErrors(validationErr)
+	if validationErr != nil && !ok {
+		return validationErr
+	}
+
+	for _, field := range fields {
+		report.Add(field.Field, field.Reason)
+	}
+
+	if len(report.Fields) == 0 {
+		return nil
+	}
+
+	return report
+}
+
+// isSyntaxError reports errors after which decoding is stopped, so decoded value is incomplete.
+func isSyntaxError(err error) bool {
+	var syntaxError *json.SyntaxError
+	return errors.As(err, &syntaxError) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+func decodingError(err error) error {
+	var (
+		maxBytesError *http.MaxBytesError
+		typeError     *json.UnmarshalTypeError
+	)
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &maxBytesError):
+		return errors.Join(ErrBodyTooLarge, err)
+	case errors.As(err, &typeError) && typeError.Field != "":
+		return fieldError(typeError.Field, "must be "+jsonTypeName(typeError.Type))
+	case strings.HasPrefix(err.Error(), unknownFieldErrorPrefix):
+		field, unquoteErr := strconv.Unquote(strings.TrimPrefix(err.Error(), unknownFieldErrorPrefix))
+		if unquoteErr != nil {
+			return err
+		}
+
+		return fieldError(field, "is not allowed")
+	default:
+		return err
+	}
+}
+
+func fieldError(field, reason string) error {
+	report := new(validation.Report)
+	report.Add(field, reason)
+	return report
+}
+
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
 }
@@ -0,0 +1,122 @@
+package reqbody
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhuboris/never-expires/internal/shared/validation"
+)
+
+type testBody struct {
+	Name      string      `json:"name" validate:"required"`
+	Count     int         `json:"count" validate:"min=1"`
+	StorageID pgtype.UUID `json:"storage_id"`
+	Details   struct {
+		Note string `json:"note" validate:"max=5"`
+	} `json:"details"`
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		limit      int64
+		want       testBody
+		wantErr    error
+		wantFields []validation.FieldError
+	}{
+		{
+			name: "valid body",
+			body: `{"name": "milk", "count": 2}`,
+			want: testBody{Name: "milk", Count: 2},
+		},
+		{
+			name:    "invalid json",
+			body:    `{"name": `,
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "more than one value",
+			body:    `{"name": "milk", "count": 2} {}`,
+			wantErr: ErrTrailingData,
+		},
+		{
+			name:       "unknown field",
+			body:       `{"name": "milk", "count": 2, "color": "white"}`,
+			wantErr:    validation.ErrInvalidFields,
+			wantFields: []validation.FieldError{{Field: "color", Reason: "is not allowed"}},
+		},
+		{
+			name:       "field of wrong type",
+			body:       `{"name": 1, "count": 2}`,
+			wantErr:    validation.ErrInvalidFields,
+			wantFields: []validation.FieldError{{Field: "name", Reason: "must be a string"}},
+		},
+		{
+			name:    "every invalid field is reported",
+			body:    `{"count": 0}`,
+			wantErr: validation.ErrInvalidFields,
+			wantFields: []validation.FieldError{
+				{Field: "name", Reason: "is required"},
+				{Field: "count", Reason: "must be at least 1"},
+			},
+		},
+		{
+			name:       "field of invalid format",
+			body:       `{"name": "milk", "count": 2, "storage_id": "not uuid"}`,
+			wantErr:    validation.ErrInvalidFields,
+			wantFields: []validation.FieldError{{Field: "storage_id", Reason: "must be a valid UUID"}},
+		},
+		{
+			name:    "decoding and validation errors are reported together",
+			body:    `{"count": "2", "storage_id": 1, "details": {"note": "too long", "color": "white"}}`,
+			wantErr: validation.ErrInvalidFields,
+			wantFields: []validation.FieldError{
+				{Field: "count", Reason: "must be a number"},
+				{Field: "storage_id", Reason: "must be a valid UUID"},
+				{Field: "details.color", Reason: "is not allowed"},
+				{Field: "name", Reason: "is required"},
+				{Field: "details.note", Reason: "must be at most 5 characters long"},
+			},
+		},
+		{
+			name:    "body is larger than limit",
+			body:    `{"name": "milk", "count": 2}`,
+			limit:   10,
+			wantErr: ErrBodyTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body io.ReadCloser = io.NopCloser(strings.NewReader(tt.body))
+			if tt.limit != 0 {
+				body = http.MaxBytesReader(httptest.NewRecorder(), body, tt.limit)
+			}
+
+			var got testBody
+			err := Decode(&got, body)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				return
+			}
+
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantFields == nil {
+				return
+			}
+
+			fields, ok := validation.FieldErrors(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
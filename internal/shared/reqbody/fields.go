@@ -0,0 +1,182 @@
+package reqbody
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/zhuboris/never-expires/internal/shared/validation"
+)
+
+type (
+	jsonField struct {
+		name      string
+		fieldType reflect.Type
+	}
+	jsonMember struct {
+		key   string
+		value json.RawMessage
+	}
+)
+
+var (
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	// formatReasons describe values that are rejected by their own UnmarshalJSON, which errors do not name the field.
+	formatReasons = map[reflect.Type]string{
+		reflect.TypeOf(pgtype.UUID{}): "must be a valid UUID",
+	}
+)
+
+const defaultFormatReason = "has invalid format"
+
+// checkFields decodes every member of JSON object separately to find all fields that can not be decoded into struct type t.
+// Members of nested objects are checked recursively and named with dots, as validation does.
+func checkFields(t reflect.Type, data []byte, prefix string, report *validation.Report) {
+	t = indirectType(t)
+	if t.Kind() != reflect.Struct || reflect.PointerTo(t).Implements(unmarshalerType) {
+		return
+	}
+
+	members, ok := objectMembers(data)
+	if !ok {
+		return
+	}
+
+	fields := jsonFields(t)
+	for _, member := range members {
+		field, ok := fieldByKey(fields, member.key)
+		if !ok {
+			report.Add(prefix+member.key, "is not allowed")
+			continue
+		}
+
+		checkField(field, member.value, prefix+field.name, report)
+	}
+}
+
+func checkField(field jsonField, value json.RawMessage, name string, report *validation.Report) {
+	fieldType := indirectType(field.fieldType)
+	if fieldType.Kind() == reflect.Struct && !reflect.PointerTo(fieldType).Implements(unmarshalerType) {
+		if _, ok := objectMembers(value); !ok && !bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			report.Add(name, "must be an object")
+			return
+		}
+
+		checkFields(fieldType, value, name+".", report)
+		return
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(reflect.New(field.fieldType).Interface())
+
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case err == nil:
+		return
+	case errors.As(err, &typeError):
+		report.Add(name, "must be "+jsonTypeName(typeError.Type))
+	case strings.HasPrefix(err.Error(), unknownFieldErrorPrefix):
+		report.Add(name, "must not contain "+strings.TrimPrefix(err.Error(), unknownFieldErrorPrefix))
+	default:
+		reason, ok := formatReasons[fieldType]
+		if !ok {
+			reason = defaultFormatReason
+		}
+
+		report.Add(name, reason)
+	}
+}
+
+// objectMembers returns members in order of the document, so fields are reported in the order client sent them.
+func objectMembers(data []byte) ([]jsonMember, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, false
+	}
+
+	var members []jsonMember
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, false
+		}
+
+		var member jsonMember
+		member.key, _ = token.(string)
+		if err := decoder.Decode(&member.value); err != nil {
+			return nil, false
+		}
+
+		members = append(members, member)
+	}
+
+	return members, true
+}
+
+// jsonFields lists fields that encoding/json decodes into struct type t, fields of embedded structs without name are promoted.
+func jsonFields(t reflect.Type) []jsonField {
+	var (
+		fields   []jsonField
+		promoted []jsonField
+	)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tagName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tagName == "-" {
+			continue
+		}
+
+		fieldType := indirectType(field.Type)
+		if field.Anonymous && tagName == "" && fieldType.Kind() == reflect.Struct {
+			promoted = append(promoted, jsonFields(fieldType)...)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name := tagName
+		if name == "" {
+			name = field.Name
+		}
+
+		fields = append(fields, jsonField{
+			name:      name,
+			fieldType: field.Type,
+		})
+	}
+
+	return append(fields, promoted...)
+}
+
+// fieldByKey matches keys as encoding/json does, exact name is preferred to case-insensitive one.
+func fieldByKey(fields []jsonField, key string) (jsonField, bool) {
+	for _, field := range fields {
+		if field.name == key {
+			return field, true
+		}
+	}
+
+	for _, field := range fields {
+		if strings.EqualFold(field.name, key) {
+			return field, true
+		}
+	}
+
+	return jsonField{}, false
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
@@ -0,0 +1,85 @@
+package runapi
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const defaultDrainTimeout = 25 * time.Second
+
+// ShutdownConfig sets how the service stops on SIGTERM, sum of both values must be less than the stop timeout of the container.
+type ShutdownConfig struct {
+	// ReadinessDelay is time between the signal and the stop, readiness already fails, so load balancer stops sending new requests.
+	ReadinessDelay time.Duration `env:"SHUTDOWN_READINESS_DELAY" default:"5s"`
+	// DrainTimeout is time given to finish requests and jobs that are already started.
+	DrainTimeout time.Duration `env:"SHUTDOWN_DRAIN_TIMEOUT" default:"25s"`
+}
+
+type drainTimeoutKeyType struct{}
+
+var drainTimeoutKey drainTimeoutKeyType
+
+// OnSignal returns context that is canceled when SIGINT or SIGTERM is received and readiness delay is passed.
+// Functions from onSignal are called right after the signal, they are used to mark the service as not ready.
+func OnSignal(parent context.Context, config ShutdownConfig, onSignal ...func()) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.WithValue(parent, drainTimeoutKey, config.DrainTimeout))
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		defer signal.Stop(signals)
+
+		select {
+		case <-signals:
+		case <-ctx.Done():
+			return
+		}
+
+		for _, f := range onSignal {
+			f()
+		}
+
+		select {
+		case <-time.After(config.ReadinessDelay):
+		case <-ctx.Done():
+		}
+
+		cancel()
+	}()
+
+	return ctx, cancel
+}
+
+// DrainTimeout returns drain timeout of the context made by OnSignal or the default one.
+func DrainTimeout(ctx context.Context) time.Duration {
+	timeout, ok := ctx.Value(drainTimeoutKey).(time.Duration)
+	if !ok || timeout <= 0 {
+		return defaultDrainTimeout
+	}
+
+	return timeout
+}
+
+// Detach returns context for work that is already started, it is canceled only when drain timeout is passed after ctx is done.
+func Detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-detached.Done():
+			return
+		}
+
+		select {
+		case <-time.After(DrainTimeout(ctx)):
+			cancel()
+		case <-detached.Done():
+		}
+	}()
+
+	return detached, cancel
+}
@@ -0,0 +1,97 @@
+package runapi
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnSignal(t *testing.T) {
+	const readinessDelay = 50 * time.Millisecond
+
+	var notReady atomic.Bool
+	ctx, cancel := OnSignal(context.Background(), ShutdownConfig{ReadinessDelay: readinessDelay, DrainTimeout: time.Second}, func() {
+		notReady.Store(true)
+	})
+	defer cancel()
+
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGTERM))
+
+	require.Eventually(t, notReady.Load, time.Second, time.Millisecond, "readiness must be flipped right after the signal")
+	assert.NoError(t, ctx.Err(), "context must not be canceled before readiness delay")
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("context is not canceled after readiness delay")
+	}
+
+	assert.Equal(t, time.Second, DrainTimeout(ctx))
+}
+
+func TestDrainTimeout(t *testing.T) {
+	assert.Equal(t, defaultDrainTimeout, DrainTimeout(context.Background()))
+}
+
+func TestDetach(t *testing.T) {
+	const drainTimeout = 50 * time.Millisecond
+
+	parent, cancelParent := context.WithCancel(context.WithValue(context.Background(), drainTimeoutKey, drainTimeout))
+	detached, cancel := Detach(parent)
+	defer cancel()
+
+	cancelParent()
+	time.Sleep(drainTimeout / 2)
+	assert.NoError(t, detached.Err(), "started work must not be canceled right after shutdown")
+
+	select {
+	case <-detached.Done():
+	case <-time.After(time.Second):
+		t.Fatal("detached context is not canceled after drain timeout")
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	errRun := errors.New("failed to listen")
+
+	t.Run("run error is returned", func(t *testing.T) {
+		err := WithContext(context.Background(), func() error {
+			return errRun
+		}, func(context.Context) error {
+			t.Fatal("shutdown must not be called")
+			return nil
+		})
+
+		assert.ErrorIs(t, err, errRun)
+	})
+
+	t.Run("shutdown gets context with drain timeout", func(t *testing.T) {
+		const drainTimeout = time.Minute
+
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), drainTimeoutKey, drainTimeout))
+		stopped := make(chan struct{})
+
+		var shutdownCtxErr error
+		var deadline time.Time
+		go cancel()
+		err := WithContext(ctx, func() error {
+			<-stopped
+			return nil
+		}, func(ctx context.Context) error {
+			defer close(stopped)
+			shutdownCtxErr = ctx.Err()
+			deadline, _ = ctx.Deadline()
+			return nil
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NoError(t, shutdownCtxErr, "shutdown context must not be canceled with the parent")
+		assert.WithinDuration(t, time.Now().Add(drainTimeout), deadline, time.Second)
+	})
+}
@@ -5,27 +5,22 @@ import (
 	"errors"
 )
 
-func WithContext(ctx context.Context, runFunc func() error, shutdownFunc func() error) error {
-	var err error
-	errChan := make(chan error)
+// WithContext runs runFunc until ctx is done, then shutdownFunc is given the drain timeout to finish started work.
+func WithContext(ctx context.Context, runFunc func() error, shutdownFunc func(ctx context.Context) error) error {
+	errChan := make(chan error, 1)
 	go func() {
 		errChan <- runFunc()
 	}()
 
-loop:
-	for {
-		select {
-		case <-ctx.Done():
-			err = shutdownFunc()
-			break loop
-		case err = <-errChan:
-			break loop
-		default:
-			continue
-		}
+	select {
+	case err := <-errChan:
+		return errors.Join(err, ctx.Err())
+	case <-ctx.Done():
 	}
 
-	err = errors.Join(err, ctx.Err())
-	return err
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), DrainTimeout(ctx))
+	defer cancel()
 
+	err := shutdownFunc(shutdownCtx)
+	return errors.Join(err, ctx.Err())
 }
@@ -0,0 +1,124 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName          = "github.com/zhuboris/never-expires"
+	requestIDBaggageKey = "request.id"
+	// RequestIDAttributeKey is added to spans that are made while handling API request.
+	RequestIDAttributeKey = attribute.Key(requestIDBaggageKey)
+)
+
+type Config struct {
+	// Endpoint is host and port of OTLP HTTP collector, spans are not exported if it is empty.
+	Endpoint      string `env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	Insecure      bool   `env:"OTEL_EXPORTER_OTLP_INSECURE" default:"true"`
+	SamplePercent int    `env:"OTEL_TRACES_SAMPLE_PERCENT" default:"100"`
+}
+
+// ShutdownFunc exports spans that are not sent yet and stops the provider.
+type ShutdownFunc func(ctx context.Context) error
+
+// Init sets global tracer provider that exports spans of the service to OTLP endpoint from config,
+// without endpoint spans are only propagated to other services.
+func Init(ctx context.Context, config Config, serviceName string) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if config.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.Endpoint)}
+	if config.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create traces exporter: %w", err)
+	}
+
+	provider := NewProvider(sdktrace.NewBatchSpanProcessor(exporter), serviceName, config.SamplePercent)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// NewProvider makes provider that sends spans to processor, tests use it with the in-memory exporter.
+func NewProvider(processor sdktrace.SpanProcessor, serviceName string, samplePercent int) *sdktrace.TracerProvider {
+	serviceResource := resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))
+	sampler := sdktrace.ParentBased(sdktrace.TraceIDRatioBased(float64(samplePercent) / 100))
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(serviceResource),
+		sdktrace.WithSampler(sampler),
+	)
+}
+
+// Start starts span with the tracer of global provider, it is not recorded until Init is called.
+func Start(ctx context.Context, name string, options ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, options...)
+}
+
+// End marks span as failed if err is not nil and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// Inject writes context of the current span to carrier, so it can be continued by another service.
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+}
+
+// Extract returns context with remote span from carrier as a parent.
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// TraceID returns ID of the trace of current span, it is empty if there is no valid span.
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+
+	return spanContext.TraceID().String()
+}
+
+// WithRequestID adds ID of the API request to the baggage, so it is propagated with the trace to other services.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	member, err := baggage.NewMember(requestIDBaggageKey, id)
+	if err != nil {
+		return ctx
+	}
+
+	current, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		return ctx
+	}
+
+	return baggage.ContextWithBaggage(ctx, current)
+}
+
+// RequestID returns ID of the API request that started the trace, it is empty if the trace is not started by API.
+func RequestID(ctx context.Context) string {
+	return baggage.FromContext(ctx).Member(requestIDBaggageKey).Value()
+}
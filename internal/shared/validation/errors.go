@@ -0,0 +1,72 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidFields   = errors.New("request has invalid fields")
+	ErrUnsupportedRule = errors.New("validation rule is not supported")
+)
+
+// FieldError describes why a single field is rejected, Field is the name used in JSON.
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// Report collects every invalid field, so clients can show all of them at once.
+type Report struct {
+	Fields []FieldError
+}
+
+func (r *Report) Error() string {
+	var builder strings.Builder
+	builder.WriteString(ErrInvalidFields.Error())
+
+	for _, field := range r.Fields {
+		builder.WriteString("\n  ")
+		builder.WriteString(field.Field)
+		builder.WriteString(": ")
+		builder.WriteString(field.Reason)
+	}
+
+	return builder.String()
+}
+
+func (r *Report) Unwrap() error {
+	return ErrInvalidFields
+}
+
+// Add appends field error, only the first reason for the field is kept.
+func (r *Report) Add(field, reason string) {
+	for _, added := range r.Fields {
+		if added.Field == field {
+			return
+		}
+	}
+
+	r.Fields = append(r.Fields, FieldError{
+		Field:  field,
+		Reason: reason,
+	})
+}
+
+func (r *Report) errOrNil() error {
+	if len(r.Fields) == 0 {
+		return nil
+	}
+
+	return r
+}
+
+// FieldErrors returns fields of *Report found in err chain.
+func FieldErrors(err error) ([]FieldError, bool) {
+	var report *Report
+	if !errors.As(err, &report) {
+		return nil, false
+	}
+
+	return report.Fields, true
+}
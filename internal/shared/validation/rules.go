@@ -0,0 +1,164 @@
+package validation
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// rule returns non-empty reason when value is invalid, error is returned only for misconfigured tags.
+type rule func(param string, parent, value reflect.Value) (reason string, err error)
+
+var rules = map[string]rule{
+	"required":         required,
+	"required_without": requiredWithout,
+	"required_if":      requiredIf,
+	"min":              minimum,
+	"max":              maximum,
+	"email":            email,
+	"rfc3339":          rfc3339,
+}
+
+const timeFormatExample = "2023-07-11T15:04:05Z"
+
+func required(_ string, _, value reflect.Value) (string, error) {
+	if isZero(value) {
+		return "is required", nil
+	}
+
+	return "", nil
+}
+
+func requiredWithout(param string, parent, value reflect.Value) (string, error) {
+	other, ok := fieldByJSONName(parent, param)
+	if !ok {
+		return "", fmt.Errorf("%w: required_without refers to unknown field %q", ErrUnsupportedRule, param)
+	}
+
+	if isZero(value) && isZero(other) {
+		return fmt.Sprintf("is required when %s is missing", param), nil
+	}
+
+	return "", nil
+}
+
+// requiredIf refers to the other field by JSON name or, for fields set by the server, by Go name.
+func requiredIf(param string, parent, value reflect.Value) (string, error) {
+	name, want, ok := strings.Cut(param, " ")
+	if !ok {
+		return "", fmt.Errorf("%w: required_if must have field and value, got %q", ErrUnsupportedRule, param)
+	}
+
+	other, ok := fieldByJSONName(parent, name)
+	if !ok {
+		return "", fmt.Errorf("%w: required_if refers to unknown field %q", ErrUnsupportedRule, name)
+	}
+
+	if other.Kind() != reflect.String {
+		return "", fmt.Errorf("%w: required_if compares %s", ErrUnsupportedRule, other.Type())
+	}
+
+	if isZero(value) && other.String() == want {
+		return fmt.Sprintf("is required when %s is %s", name, want), nil
+	}
+
+	return "", nil
+}
+
+func minimum(param string, _, value reflect.Value) (string, error) {
+	return compareSize(param, value, func(size, limit int64) bool { return size >= limit }, "at least")
+}
+
+func maximum(param string, _, value reflect.Value) (string, error) {
+	return compareSize(param, value, func(size, limit int64) bool { return size <= limit }, "at most")
+}
+
+func compareSize(param string, value reflect.Value, isAllowed func(size, limit int64) bool, bound string) (string, error) {
+	limit, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("%w: limit %q is not an integer", ErrUnsupportedRule, param)
+	}
+
+	value, ok := indirect(value)
+	if !ok {
+		return "", nil
+	}
+
+	var size int64
+	var reason string
+	switch value.Kind() {
+	case reflect.String:
+		size = int64(utf8.RuneCountInString(value.String()))
+		reason = fmt.Sprintf("must be %s %d characters long", bound, limit)
+	case reflect.Slice, reflect.Map, reflect.Array:
+		size = int64(value.Len())
+		reason = fmt.Sprintf("must contain %s %d items", bound, limit)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		size = value.Int()
+		reason = fmt.Sprintf("must be %s %d", bound, limit)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		size = int64(value.Uint())
+		reason = fmt.Sprintf("must be %s %d", bound, limit)
+	default:
+		return "", fmt.Errorf("%w: size of %s", ErrUnsupportedRule, value.Type())
+	}
+
+	if isAllowed(size, limit) {
+		return "", nil
+	}
+
+	return reason, nil
+}
+
+func email(_ string, _, value reflect.Value) (string, error) {
+	raw, err := nonEmptyString(value)
+	if err != nil || raw == "" {
+		return "", err
+	}
+
+	if _, err := mail.ParseAddress(raw); err != nil {
+		return "must be a valid email address", nil
+	}
+
+	return "", nil
+}
+
+func rfc3339(_ string, _, value reflect.Value) (string, error) {
+	raw, err := nonEmptyString(value)
+	if err != nil || raw == "" {
+		return "", err
+	}
+
+	if _, err := time.Parse(time.RFC3339, raw); err != nil {
+		return fmt.Sprintf("must be a date in ISO 8601 format, for example %q", timeFormatExample), nil
+	}
+
+	return "", nil
+}
+
+// nonEmptyString returns empty string for nil pointers, format rules skip such values, so required rule decides about them.
+func nonEmptyString(value reflect.Value) (string, error) {
+	value, ok := indirect(value)
+	if !ok {
+		return "", nil
+	}
+
+	if value.Kind() != reflect.String {
+		return "", fmt.Errorf("%w: format of %s", ErrUnsupportedRule, value.Type())
+	}
+
+	return value.String(), nil
+}
+
+func isZero(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	default:
+		return value.IsZero()
+	}
+}
@@ -0,0 +1,131 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	validateTag = "validate"
+	jsonTag     = "json"
+)
+
+// Struct checks fields of a struct, passed by value or by pointer, against comma separated rules of validate tag:
+// required rejects zero values, required_without=<field> does it only when the other JSON field is zero too,
+// required_if=<field> <value> does it only when the other string field has the value,
+// min=N and max=N limit length of strings and slices or integer values, email and rfc3339 check format of non-empty strings.
+// Nested structs without validate tag are checked recursively. Fields are named as in JSON,
+// every invalid field is returned together in *Report with the reason of the first failed rule.
+func Struct(v any) error {
+	value, ok := indirect(reflect.ValueOf(v))
+	if !ok || value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	report := new(Report)
+	if err := checkStruct(value, "", report); err != nil {
+		return err
+	}
+
+	return report.errOrNil()
+}
+
+func checkStruct(value reflect.Value, prefix string, report *Report) error {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field, fieldValue := valueType.Field(i), value.Field(i)
+		if !field.IsExported() && !field.Anonymous { // JSON decodes embedded structs even of unexported types
+			continue
+		}
+
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+
+		tag := field.Tag.Get(validateTag)
+		if tag != "" {
+			if err := checkField(value, fieldValue, prefix+name, tag, report); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		nested, ok := indirect(fieldValue)
+		if !ok || nested.Kind() != reflect.Struct {
+			continue
+		}
+
+		nestedPrefix := prefix
+		if !field.Anonymous {
+			nestedPrefix += name + "."
+		}
+
+		if err := checkStruct(nested, nestedPrefix, report); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkField(parent, value reflect.Value, name, tag string, report *Report) error {
+	for _, raw := range strings.Split(tag, ",") {
+		ruleName, param, _ := strings.Cut(strings.TrimSpace(raw), "=")
+		check, ok := rules[ruleName]
+		if !ok {
+			return fmt.Errorf("%w: %q, field %s", ErrUnsupportedRule, ruleName, name)
+		}
+
+		reason, err := check(param, parent, value)
+		if err != nil {
+			return fmt.Errorf("%w, field %s", err, name)
+		}
+
+		if reason != "" {
+			report.Add(name, reason)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// jsonName returns false for fields that are never decoded from JSON.
+func jsonName(field reflect.StructField) (string, bool) {
+	name, _, _ := strings.Cut(field.Tag.Get(jsonTag), ",")
+	switch name {
+	case "-":
+		return "", false
+	case "":
+		return field.Name, true
+	default:
+		return name, true
+	}
+}
+
+func fieldByJSONName(parent reflect.Value, name string) (reflect.Value, bool) {
+	parentType := parent.Type()
+	for i := 0; i < parentType.NumField(); i++ {
+		if fieldName, ok := jsonName(parentType.Field(i)); ok && fieldName == name {
+			return parent.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// indirect dereferences pointers, it returns false for nil.
+func indirect(value reflect.Value) (reflect.Value, bool) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}, false
+		}
+
+		value = value.Elem()
+	}
+
+	return value, value.IsValid()
+}
@@ -0,0 +1,199 @@
+package validation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCredentials struct {
+	Token string `json:"token"`
+	Code  string `json:"code" validate:"required_without=token"`
+}
+
+type testProviderToken struct {
+	AuthCode string `json:"auth_code" validate:"required_if=provider apple"`
+
+	provider string
+}
+
+type testInput struct {
+	Name      string            `json:"name" validate:"required,max=5"`
+	Email     string            `json:"email" validate:"required,email"`
+	Date      string            `json:"date" validate:"rfc3339"`
+	Hours     int               `json:"hours" validate:"min=0"`
+	Tags      []string          `json:"tags" validate:"max=2"`
+	Note      *string           `json:"note" validate:"min=2"`
+	Payload   json.RawMessage   `json:"payload" validate:"required"`
+	Ignored   string            `json:"-" validate:"required"`
+	Nested    testCredentials   `json:"nested"`
+	Provider  testProviderToken `json:"provider"`
+	unchecked string
+
+	testCredentials
+}
+
+func validTestInput() testInput {
+	return testInput{
+		Name:            "milk",
+		Email:           "user@example.com",
+		Date:            "2023-07-11T15:04:05Z",
+		Hours:           24,
+		Tags:            []string{"a"},
+		Payload:         json.RawMessage(`{}`),
+		Nested:          testCredentials{Token: "token"},
+		Provider:        testProviderToken{provider: "apple", AuthCode: "code"},
+		testCredentials: testCredentials{Code: "1234"},
+	}
+}
+
+func TestStruct(t *testing.T) {
+	note := "a"
+
+	tests := []struct {
+		name   string
+		modify func(input *testInput)
+		want   []FieldError
+	}{
+		{
+			name:   "valid input",
+			modify: func(input *testInput) {},
+		},
+		{
+			name: "every invalid field is reported with its first failed rule",
+			modify: func(input *testInput) {
+				input.Name = ""
+				input.Email = "not an email"
+				input.Date = "11.07.2023"
+				input.Hours = -1
+				input.Tags = []string{"a", "b", "c"}
+				input.Note = &note
+				input.Payload = nil
+			},
+			want: []FieldError{
+				{Field: "name", Reason: "is required"},
+				{Field: "email", Reason: "must be a valid email address"},
+				{Field: "date", Reason: `must be a date in ISO 8601 format, for example "2023-07-11T15:04:05Z"`},
+				{Field: "hours", Reason: "must be at least 0"},
+				{Field: "tags", Reason: "must contain at most 2 items"},
+				{Field: "note", Reason: "must be at least 2 characters long"},
+				{Field: "payload", Reason: "is required"},
+			},
+		},
+		{
+			name: "length counts characters, not bytes",
+			modify: func(input *testInput) {
+				input.Name = "молоко"
+			},
+			want: []FieldError{
+				{Field: "name", Reason: "must be at most 5 characters long"},
+			},
+		},
+		{
+			name: "nested and embedded structs are checked",
+			modify: func(input *testInput) {
+				input.Nested = testCredentials{}
+				input.testCredentials = testCredentials{}
+			},
+			want: []FieldError{
+				{Field: "nested.code", Reason: "is required when token is missing"},
+				{Field: "code", Reason: "is required when token is missing"},
+			},
+		},
+		{
+			name: "field is required only for the value of other field",
+			modify: func(input *testInput) {
+				input.Provider.AuthCode = ""
+			},
+			want: []FieldError{
+				{Field: "provider.auth_code", Reason: "is required when provider is apple"},
+			},
+		},
+		{
+			name: "field is optional for other values",
+			modify: func(input *testInput) {
+				input.Provider = testProviderToken{provider: "google"}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := validTestInput()
+			tt.modify(&input)
+
+			err := Struct(&input)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrInvalidFields)
+			fields, ok := FieldErrors(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, fields)
+		})
+	}
+}
+
+func TestStruct_NotStruct(t *testing.T) {
+	var input *testInput
+	assert.NoError(t, Struct(input))
+	assert.NoError(t, Struct(map[string]any{}))
+}
+
+func TestStruct_UnsupportedRule(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+	}{
+		{
+			name: "unknown rule",
+			input: struct {
+				Name string `validate:"unknown"`
+			}{},
+		},
+		{
+			name: "limit is not an integer",
+			input: struct {
+				Name string `validate:"max=ten"`
+			}{},
+		},
+		{
+			name: "format of not a string",
+			input: struct {
+				Count int `validate:"email"`
+			}{Count: 1},
+		},
+		{
+			name: "unknown other field",
+			input: struct {
+				Name string `validate:"required_without=missing"`
+			}{},
+		},
+		{
+			name: "missing value of other field",
+			input: struct {
+				Name string `validate:"required_if=kind"`
+				Kind string
+			}{},
+		},
+		{
+			name: "other field is not a string",
+			input: struct {
+				Name  string `validate:"required_if=count 1"`
+				Count int    `json:"count"`
+			}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Struct(tt.input)
+			assert.ErrorIs(t, err, ErrUnsupportedRule)
+			assert.NotErrorIs(t, err, ErrInvalidFields)
+		})
+	}
+}
@@ -1,3 +0,0 @@
-CREATE TABLE test (
-    name TEXT
-)
\ No newline at end of file
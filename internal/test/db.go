@@ -3,12 +3,13 @@ package test
 import (
 	"context"
 	"fmt"
-	"io"
-	"os"
+	"io/fs"
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/require"
+
+	"github.com/zhuboris/never-expires/internal/shared/migration"
 )
 
 type PostgresConfig struct {
@@ -27,7 +28,8 @@ func (c PostgresConfig) connStringToDB() string {
 	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s", c.Username, c.Password, c.Host, c.Port, c.DBName)
 }
 
-func SetupDatabase(t *testing.T, config PostgresConfig, pathToSQLFile string) *pgxpool.Pool {
+// SetupDatabase creates database and builds its schema by applying every migration of source, nil source leaves it empty.
+func SetupDatabase(t *testing.T, config PostgresConfig, source fs.FS) *pgxpool.Pool {
 	t.Helper()
 	connStr := config.connStringToPostgres()
 	pool, err := pgxpool.New(context.Background(), connStr)
@@ -41,7 +43,7 @@ func SetupDatabase(t *testing.T, config PostgresConfig, pathToSQLFile string) *p
 	pool, err = pgxpool.New(context.Background(), connStr)
 	require.NoErrorf(t, err, "Unable to connect to database: %v\n", err)
 
-	executeSQLFile(t, pool, pathToSQLFile)
+	applyMigrations(t, pool, source)
 	return pool
 }
 
@@ -59,22 +61,16 @@ func DropDatabase(t *testing.T, pool *pgxpool.Pool, config PostgresConfig) {
 	require.NoErrorf(t, err, "Unable to drop test database: %v\n", err)
 }
 
-func executeSQLFile(t *testing.T, pool *pgxpool.Pool, path string) {
+func applyMigrations(t *testing.T, pool *pgxpool.Pool, source fs.FS) {
 	t.Helper()
-	if path == "" {
-		t.Logf("SQL file not provided for %q test", t.Name())
+	if source == nil {
+		t.Logf("Migrations not provided for %q test", t.Name())
 		return
 	}
 
-	file, err := os.Open(path)
-	require.NoErrorf(t, err, "Unable to open sql file: %v\n", err)
-
-	defer file.Close()
-
-	content, err := io.ReadAll(file)
-	require.NoErrorf(t, err, "Reading sql file error: %v\n", err)
+	runner, err := migration.NewRunner(pool, source)
+	require.NoErrorf(t, err, "Unable to load migrations: %v\n", err)
 
-	sql := string(content)
-	_, err = pool.Exec(context.Background(), sql)
-	require.NoErrorf(t, err, "Executing sql file error: %v\n", err)
+	err = runner.Up(context.Background())
+	require.NoErrorf(t, err, "Applying migrations error: %v\n", err)
 }
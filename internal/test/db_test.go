@@ -3,6 +3,7 @@ package test
 import (
 	"context"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,7 +18,12 @@ func TestCreateDB(t *testing.T) {
 		DBName:   "test",
 	}
 
-	pool := SetupDatabase(t, config, "./.sql")
+	migrations := fstest.MapFS{
+		"0001_test.up.sql":   {Data: []byte("CREATE TABLE test (name TEXT);")},
+		"0001_test.down.sql": {Data: []byte("DROP TABLE test;")},
+	}
+
+	pool := SetupDatabase(t, config, migrations)
 	t.Cleanup(func() {
 		DropDatabase(t, pool, config)
 	})
@@ -0,0 +1,19 @@
+const Statuses = {
+    CONFIRMED: "confirmed",
+    REVERTED: "reverted",
+    FAILURE: "failure",
+};
+
+const StatusMessages = {
+    [Statuses.CONFIRMED]: "Your new email has been successfully confirmed! Use it to log in from now on.",
+    [Statuses.REVERTED]: "The email change has been cancelled, your previous email is active again. Change your password if it was not you.",
+    [Statuses.FAILURE]: "This link is no longer valid. You can check your current email in the app.",
+};
+
+document.addEventListener("DOMContentLoaded", function() {
+    const urlParams = new URLSearchParams(window.location.search);
+    const status = urlParams.get("status");
+    let messageElement = document.getElementById("statusMessage");
+
+    messageElement.textContent = StatusMessages[status] || StatusMessages[Statuses.FAILURE];
+});
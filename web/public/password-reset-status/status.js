@@ -4,7 +4,7 @@ const Statuses = {
 };
 
 const StatusMessages = {
-    [Statuses.SUCCESS]: "Your password has been successfully reset! We have sent a new one to your email.",
+    [Statuses.SUCCESS]: "Your password has been successfully changed! You can log in with it in the app.",
     [Statuses.FAILURE]: "This link is no longer valid. You can request a new one in the app.",
 };
 
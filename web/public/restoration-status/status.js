@@ -0,0 +1,17 @@
+const Statuses = {
+    SUCCESS: "success",
+    FAILURE: "failure",
+};
+
+const StatusMessages = {
+    [Statuses.SUCCESS]: "Your account has been successfully restored! You can log in to it in the app.",
+    [Statuses.FAILURE]: "This restoration link is no longer valid. The account may be already restored or deleted.",
+};
+
+document.addEventListener("DOMContentLoaded", function() {
+    const urlParams = new URLSearchParams(window.location.search);
+    const status = urlParams.get("status");
+    let messageElement = document.getElementById("statusMessage");
+
+    messageElement.textContent = StatusMessages[status] || StatusMessages[Statuses.FAILURE];
+});